@@ -1,4 +1,4 @@
-// Copyright © 2023 Weald Technology Trading.
+// Copyright © 2023, 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -14,6 +14,7 @@
 package beacon
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"encoding/json"
@@ -191,12 +192,12 @@ func (c *ChainInfo) FetchValidatorInfo(ctx context.Context, id string) (*Validat
 	var validatorInfo *ValidatorInfo
 	switch {
 	case id == "":
-		return nil, errors.New("no validator specified")
+		return nil, util.NewError(util.ErrorCodeInvalidInput, "no validator specified")
 	case strings.HasPrefix(id, "0x"):
 		// ID is a public key.
 		// Check that the key is the correct length.
 		if len(id) != 98 {
-			return nil, errors.New("invalid public key: incorrect length")
+			return nil, util.NewError(util.ErrorCodeInvalidInput, "invalid public key: incorrect length")
 		}
 		for _, validator := range c.Validators {
 			if strings.EqualFold(id, fmt.Sprintf("%#x", validator.Pubkey)) {
@@ -225,7 +226,7 @@ func (c *ChainInfo) FetchValidatorInfo(ctx context.Context, id string) (*Validat
 		// An index.
 		index, err := strconv.ParseUint(id, 10, 64)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to parse validator index")
+			return nil, util.ErrorWithCode(errors.Wrap(err, "failed to parse validator index"), util.ErrorCodeInvalidInput, nil)
 		}
 		validatorIndex := phase0.ValidatorIndex(index)
 		for _, validator := range c.Validators {
@@ -237,7 +238,10 @@ func (c *ChainInfo) FetchValidatorInfo(ctx context.Context, id string) (*Validat
 	}
 
 	if validatorInfo == nil {
-		return nil, errors.New("unknown validator")
+		return nil, util.ErrorWithCode(errors.New("unknown validator"),
+			util.ErrorCodeValidatorNotFound,
+			map[string]any{"validator": id},
+		)
 	}
 
 	return validatorInfo, nil
@@ -252,39 +256,119 @@ func ObtainChainInfoFromNode(ctx context.Context,
 	error,
 ) {
 	res := &ChainInfo{
-		Version:    3,
+		Version:    4,
 		Validators: make([]*ValidatorInfo, 0),
 		Epoch:      chainTime.CurrentEpoch(),
 	}
 
 	// Obtain validators.
-	validatorsResponse, err := consensusClient.(consensusclient.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{State: "head"})
+	validators, err := util.RequireQuorum(ctx, consensusClient, "validators", obtainValidatorInfos, validatorInfosEqual)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain validators")
 	}
+	res.Validators = validators
 
-	for _, validator := range validatorsResponse.Data {
-		res.Validators = append(res.Validators, &ValidatorInfo{
-			Index:                 validator.Index,
-			Pubkey:                validator.Validator.PublicKey,
-			WithdrawalCredentials: validator.Validator.WithdrawalCredentials,
-			State:                 validator.Status,
-		})
+	// Genesis validators root obtained from beacon node.
+	res.GenesisValidatorsRoot, err = util.RequireQuorum(ctx, consensusClient, "genesis validators root",
+		func(ctx context.Context, client consensusclient.Service) (phase0.Root, error) {
+			genesisResponse, err := client.(consensusclient.GenesisProvider).Genesis(ctx, &api.GenesisOpts{})
+			if err != nil {
+				return phase0.Root{}, err
+			}
+
+			return genesisResponse.Data.GenesisValidatorsRoot, nil
+		},
+		func(a phase0.Root, b phase0.Root) bool {
+			return a == b
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain genesis information")
 	}
-	// Order validators by index.
-	sort.Slice(res.Validators, func(i int, j int) bool {
-		return res.Validators[i].Index < res.Validators[j].Index
-	})
 
-	// Genesis validators root obtained from beacon node.
-	genesisResponse, err := consensusClient.(consensusclient.GenesisProvider).Genesis(ctx, &api.GenesisOpts{})
+	if err := populateChainParameters(ctx, res, consensusClient); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ObtainChainInfoFromNetworkConfig obtains the chain information from a network
+// configuration, using the supplied validators.
+func ObtainChainInfoFromNetworkConfig(ctx context.Context,
+	networkConfig *util.NetworkConfig,
+	chainTime chaintime.Service,
+	validators []*ValidatorInfo,
+) (
+	*ChainInfo,
+	error,
+) {
+	res := &ChainInfo{
+		Version:    4,
+		Validators: validators,
+		Epoch:      chainTime.CurrentEpoch(),
+	}
+
+	genesisResponse, err := networkConfig.Genesis(ctx, &api.GenesisOpts{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain genesis information")
 	}
 	res.GenesisValidatorsRoot = genesisResponse.Data.GenesisValidatorsRoot
 
+	if err := populateChainParameters(ctx, res, networkConfig); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// chainParameters are the chain parameters used to sign operations.
+type chainParameters struct {
+	genesisForkVersion             phase0.Version
+	exitForkVersion                phase0.Version
+	currentForkVersion             phase0.Version
+	blsToExecutionChangeDomainType phase0.DomainType
+	voluntaryExitDomainType        phase0.DomainType
+}
+
+// populateChainParameters populates the fork versions and domain types of the chain information.
+func populateChainParameters(ctx context.Context,
+	res *ChainInfo,
+	client consensusclient.Service,
+) error {
+	params, err := util.RequireQuorum(ctx, client, "chain parameters",
+		func(ctx context.Context, client consensusclient.Service) (*chainParameters, error) {
+			return obtainChainParameters(ctx, client, res.Epoch)
+		},
+		func(a *chainParameters, b *chainParameters) bool {
+			return *a == *b
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	res.GenesisForkVersion = params.genesisForkVersion
+	res.ExitForkVersion = params.exitForkVersion
+	res.CurrentForkVersion = params.currentForkVersion
+	res.BLSToExecutionChangeDomainType = params.blsToExecutionChangeDomainType
+	res.VoluntaryExitDomainType = params.voluntaryExitDomainType
+
+	return nil
+}
+
+// obtainChainParameters obtains the chain parameters from a node.
+func obtainChainParameters(ctx context.Context,
+	client consensusclient.Service,
+	epoch phase0.Epoch,
+) (
+	*chainParameters,
+	error,
+) {
+	res := &chainParameters{}
+
 	// Fetch the genesis fork version from the specification.
-	specResponse, err := consensusClient.(consensusclient.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	specResponse, err := client.(consensusclient.SpecProvider).Spec(ctx, &api.SpecOpts{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain spec")
 	}
@@ -293,7 +377,7 @@ func ObtainChainInfoFromNode(ctx context.Context,
 		return nil, errors.New("genesis fork version not known by chain")
 	}
 	var isForkVersion bool
-	res.GenesisForkVersion, isForkVersion = tmp.(phase0.Version)
+	res.genesisForkVersion, isForkVersion = tmp.(phase0.Version)
 	if !isForkVersion {
 		return nil, errors.New("could not obtain GENESIS_FORK_VERSION")
 	}
@@ -303,19 +387,19 @@ func ObtainChainInfoFromNode(ctx context.Context,
 	if !exists {
 		return nil, errors.New("capella fork version not known by chain")
 	}
-	res.ExitForkVersion, isForkVersion = tmp.(phase0.Version)
+	res.exitForkVersion, isForkVersion = tmp.(phase0.Version)
 	if !isForkVersion {
 		return nil, errors.New("could not obtain CAPELLA_FORK_VERSION")
 	}
 
 	// Fetch the current fork version from the fork schedule.
-	forkScheduleResponse, err := consensusClient.(consensusclient.ForkScheduleProvider).ForkSchedule(ctx, &api.ForkScheduleOpts{})
+	forkScheduleResponse, err := client.(consensusclient.ForkScheduleProvider).ForkSchedule(ctx, &api.ForkScheduleOpts{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain fork schedule")
 	}
 	for i := range forkScheduleResponse.Data {
-		if forkScheduleResponse.Data[i].Epoch <= res.Epoch {
-			res.CurrentForkVersion = forkScheduleResponse.Data[i].CurrentVersion
+		if forkScheduleResponse.Data[i].Epoch <= epoch {
+			res.currentForkVersion = forkScheduleResponse.Data[i].CurrentVersion
 		}
 	}
 
@@ -323,13 +407,60 @@ func ObtainChainInfoFromNode(ctx context.Context,
 	if !exists {
 		return nil, errors.New("failed to obtain DOMAIN_BLS_TO_EXECUTION_CHANGE")
 	}
-	copy(res.BLSToExecutionChangeDomainType[:], blsToExecutionChangeDomainType[:])
+	res.blsToExecutionChangeDomainType = blsToExecutionChangeDomainType
 
 	voluntaryExitDomainType, exists := specResponse.Data["DOMAIN_VOLUNTARY_EXIT"].(phase0.DomainType)
 	if !exists {
 		return nil, errors.New("failed to obtain DOMAIN_VOLUNTARY_EXIT")
 	}
-	copy(res.VoluntaryExitDomainType[:], voluntaryExitDomainType[:])
+	res.voluntaryExitDomainType = voluntaryExitDomainType
 
 	return res, nil
 }
+
+// obtainValidatorInfos obtains validator information from a node, ordered by index.
+func obtainValidatorInfos(ctx context.Context, client consensusclient.Service) ([]*ValidatorInfo, error) {
+	validatorsResponse, err := client.(consensusclient.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{State: "head"})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ValidatorInfo, 0, len(validatorsResponse.Data))
+	for _, validator := range validatorsResponse.Data {
+		res = append(res, &ValidatorInfo{
+			Index:                 validator.Index,
+			Pubkey:                validator.Validator.PublicKey,
+			WithdrawalCredentials: validator.Validator.WithdrawalCredentials,
+			State:                 validator.Status,
+			EffectiveBalance:      validator.Validator.EffectiveBalance,
+			ActivationEpoch:       validator.Validator.ActivationEpoch,
+			ExitEpoch:             validator.Validator.ExitEpoch,
+		})
+	}
+	// Order validators by index.
+	sort.Slice(res, func(i int, j int) bool {
+		return res[i].Index < res[j].Index
+	})
+
+	return res, nil
+}
+
+// validatorInfosEqual returns true if the two sets of validator information match.
+func validatorInfosEqual(a []*ValidatorInfo, b []*ValidatorInfo) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Index != b[i].Index ||
+			a[i].Pubkey != b[i].Pubkey ||
+			a[i].State != b[i].State ||
+			!bytes.Equal(a[i].WithdrawalCredentials, b[i].WithdrawalCredentials) ||
+			a[i].EffectiveBalance != b[i].EffectiveBalance ||
+			a[i].ActivationEpoch != b[i].ActivationEpoch ||
+			a[i].ExitEpoch != b[i].ExitEpoch {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,90 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorInfosEqual(t *testing.T) {
+	validator := func(index phase0.ValidatorIndex) *ValidatorInfo {
+		return &ValidatorInfo{
+			Index:                 index,
+			Pubkey:                phase0.BLSPubKey{byte(index) + 1},
+			State:                 apiv1.ValidatorStateActiveOngoing,
+			WithdrawalCredentials: append([]byte{0x01}, make([]byte, 31)...),
+			EffectiveBalance:      32000000000,
+			ExitEpoch:             farFutureEpoch,
+		}
+	}
+	validators := []*ValidatorInfo{validator(0), validator(1)}
+
+	tests := []struct {
+		name   string
+		modify func(*ValidatorInfo)
+		extra  bool
+		equal  bool
+	}{
+		{
+			name:  "Equal",
+			equal: true,
+		},
+		{
+			name:  "ExtraValidator",
+			extra: true,
+		},
+		{
+			name:   "Pubkey",
+			modify: func(v *ValidatorInfo) { v.Pubkey = phase0.BLSPubKey{0x04} },
+		},
+		{
+			name:   "State",
+			modify: func(v *ValidatorInfo) { v.State = apiv1.ValidatorStateActiveExiting },
+		},
+		{
+			name:   "WithdrawalCredentials",
+			modify: func(v *ValidatorInfo) { v.WithdrawalCredentials = append([]byte{0x02}, make([]byte, 31)...) },
+		},
+		{
+			name:   "EffectiveBalance",
+			modify: func(v *ValidatorInfo) { v.EffectiveBalance = 31000000000 },
+		},
+		{
+			name:   "ActivationEpoch",
+			modify: func(v *ValidatorInfo) { v.ActivationEpoch = 10 },
+		},
+		{
+			name:   "ExitEpoch",
+			modify: func(v *ValidatorInfo) { v.ExitEpoch = 20 },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			other := []*ValidatorInfo{validator(0), validator(1)}
+			if test.modify != nil {
+				test.modify(other[1])
+			}
+			if test.extra {
+				other = append(other, validator(2))
+			}
+			require.Equal(t, test.equal, validatorInfosEqual(validators, other))
+			require.Equal(t, test.equal, validatorInfosEqual(other, validators))
+		})
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright © 2023 Weald Technology Trading.
+// Copyright © 2023, 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -25,11 +25,17 @@ import (
 	"github.com/pkg/errors"
 )
 
+// farFutureEpoch is the exit epoch of a validator that is not exiting.
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
 type ValidatorInfo struct {
 	Index                 phase0.ValidatorIndex
 	Pubkey                phase0.BLSPubKey
 	State                 apiv1.ValidatorState
 	WithdrawalCredentials []byte
+	EffectiveBalance      phase0.Gwei
+	ActivationEpoch       phase0.Epoch
+	ExitEpoch             phase0.Epoch
 }
 
 type validatorInfoJSON struct {
@@ -37,6 +43,9 @@ type validatorInfoJSON struct {
 	Pubkey                string               `json:"pubkey"`
 	State                 apiv1.ValidatorState `json:"state"`
 	WithdrawalCredentials string               `json:"withdrawal_credentials"`
+	EffectiveBalance      string               `json:"effective_balance,omitempty"`
+	ActivationEpoch       string               `json:"activation_epoch,omitempty"`
+	ExitEpoch             string               `json:"exit_epoch,omitempty"`
 }
 
 // MarshalJSON implements json.Marshaler.
@@ -46,6 +55,9 @@ func (v *ValidatorInfo) MarshalJSON() ([]byte, error) {
 		Pubkey:                fmt.Sprintf("%#x", v.Pubkey),
 		State:                 v.State,
 		WithdrawalCredentials: fmt.Sprintf("%#x", v.WithdrawalCredentials),
+		EffectiveBalance:      fmt.Sprintf("%d", v.EffectiveBalance),
+		ActivationEpoch:       fmt.Sprintf("%d", v.ActivationEpoch),
+		ExitEpoch:             fmt.Sprintf("%d", v.ExitEpoch),
 	})
 }
 
@@ -93,6 +105,34 @@ func (v *ValidatorInfo) UnmarshalJSON(input []byte) error {
 		return fmt.Errorf("incorrect length %d for withdrawal credentials", len(v.WithdrawalCredentials))
 	}
 
+	// Effective balance and activation epoch are not present in older versions.
+	if data.EffectiveBalance != "" {
+		effectiveBalance, err := strconv.ParseUint(data.EffectiveBalance, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid value for effective balance")
+		}
+		v.EffectiveBalance = phase0.Gwei(effectiveBalance)
+	}
+
+	if data.ActivationEpoch != "" {
+		activationEpoch, err := strconv.ParseUint(data.ActivationEpoch, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid value for activation epoch")
+		}
+		v.ActivationEpoch = phase0.Epoch(activationEpoch)
+	}
+
+	// Exit epoch is not present in older versions, in which case the validator
+	// is assumed not to be exiting.
+	v.ExitEpoch = farFutureEpoch
+	if data.ExitEpoch != "" {
+		exitEpoch, err := strconv.ParseUint(data.ExitEpoch, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid value for exit epoch")
+		}
+		v.ExitEpoch = phase0.Epoch(exitEpoch)
+	}
+
 	return nil
 }
 
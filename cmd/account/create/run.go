@@ -19,6 +19,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 )
 
 // Run runs the account create data command.
@@ -26,7 +27,7 @@ func Run(cmd *cobra.Command) (string, error) {
 	ctx := context.Background()
 	dataIn, err := input(ctx)
 	if err != nil {
-		return "", errors.Join(errors.New("failed to set up command"), err)
+		return "", util.ErrorWithCode(errors.Join(errors.New("failed to set up command"), err), util.ErrorCodeInvalidInput, nil)
 	}
 
 	// Further errors do not need a usage report.
@@ -36,7 +37,7 @@ func Run(cmd *cobra.Command) (string, error) {
 	if err != nil {
 		switch {
 		case errors.Is(err, context.DeadlineExceeded):
-			return "", errors.New("operation timed out; try increasing with --timeout option")
+			return "", util.NewError(util.ErrorCodeTimeout, "operation timed out; try increasing with --timeout option")
 		default:
 			return "", errors.Join(errors.New("failed to process"), err)
 		}
@@ -50,7 +50,7 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 				}
 			}
 			if !unlocked {
-				return nil, errors.New("failed to unlock account")
+				return nil, util.NewError(util.ErrorCodeInvalidPassphrase, "failed to unlock account")
 			}
 			// Because we unlocked the accout we should re-lock it when we're done.
 			defer func() {
@@ -28,7 +28,7 @@ var accountCreateCmd = &cobra.Command{
 
     ethdo account create --account="primary/operations" --passphrase="my secret"
 
-In quiet mode this will return 0 if the account is created successfully, otherwise 1.`,
+In quiet mode this will return 0 if the account is created successfully, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := accountcreate.Run(cmd)
 		if err != nil {
@@ -28,7 +28,7 @@ var accountDeriveCmd = &cobra.Command{
 
     ethdo account derive --mnemonic="..." --path="m/12381/3600/0/0"
 
-In quiet mode this will return 0 if the inputs can derive an account account, otherwise 1.`,
+In quiet mode this will return 0 if the inputs can derive an account account, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := accountderive.Run(cmd)
 		if err != nil {
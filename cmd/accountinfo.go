@@ -32,12 +32,12 @@ var accountInfoCmd = &cobra.Command{
 
     ethdo account info --account="primary/my funds"
 
-In quiet mode this will return 0 if the account exists, otherwise 1.`,
+In quiet mode this will return 0 if the account exists, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 		defer cancel()
 
-		assert(viper.GetString("account") != "", "--account is required")
+		assertInput(viper.GetString("account") != "", "--account is required")
 		wallet, account, err := walletAndAccountFromInput(ctx)
 		errCheck(err, "Failed to obtain account")
 
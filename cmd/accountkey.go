@@ -29,7 +29,7 @@ var accountKeyCmd = &cobra.Command{
 
     ethdo account key --account="Personal wallet/Operations" --passphrase="my account passphrase"
 
-In quiet mode this will return 0 if the key can be obtained, otherwise 1.`,
+In quiet mode this will return 0 if the key can be obtained, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := accountkey.Run(cmd)
 		if err != nil {
@@ -28,12 +28,12 @@ var accountLockCmd = &cobra.Command{
 
     ethdo account lock --account="primary/my funds"
 
-In quiet mode this will return 0 if the account is locked, otherwise 1.`,
+In quiet mode this will return 0 if the account is locked, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 		defer cancel()
 
-		assert(viper.GetString("account") != "", "--account is required")
+		assertInput(viper.GetString("account") != "", "--account is required")
 
 		_, account, err := walletAndAccountFromInput(ctx)
 		errCheck(err, "Failed to obtain account")
@@ -29,12 +29,12 @@ var accountUnlockCmd = &cobra.Command{
 
     ethdo account unlock --account="primary/my funds" --passphrase="secret"
 
-In quiet mode this will return 0 if the account is unlocked, otherwise 1.`,
+In quiet mode this will return 0 if the account is unlocked, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 		defer cancel()
 
-		assert(viper.GetString("account") != "", "--account is required")
+		assertInput(viper.GetString("account") != "", "--account is required")
 
 		_, account, err := walletAndAccountFromInput(ctx)
 		errCheck(err, "Failed to obtain account")
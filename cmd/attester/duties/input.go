@@ -28,11 +28,12 @@ import (
 
 type dataIn struct {
 	// System.
-	timeout time.Duration
-	quiet   bool
-	verbose bool
-	debug   bool
-	json    bool
+	timeout  time.Duration
+	quiet    bool
+	verbose  bool
+	debug    bool
+	format   string
+	template string
 	// Operation.
 	validator  string
 	eth2Client eth2client.Service
@@ -50,7 +51,8 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
-	data.json = viper.GetBool("json")
+	data.format = util.OutputFormat()
+	data.template = viper.GetString("template")
 
 	// Validator.
 	data.validator = viper.GetString("validator")
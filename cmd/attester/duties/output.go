@@ -20,17 +20,19 @@ import (
 
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type dataOut struct {
-	debug   bool
-	quiet   bool
-	verbose bool
-	json    bool
-	duty    *api.AttesterDuty
+	debug    bool
+	quiet    bool
+	verbose  bool
+	format   string
+	template string
+	duty     *api.AttesterDuty
 }
 
-func output(_ context.Context, data *dataOut) (string, error) {
+func output(ctx context.Context, data *dataOut) (string, error) {
 	if data == nil {
 		return "", errors.New("no data")
 	}
@@ -43,13 +45,18 @@ func output(_ context.Context, data *dataOut) (string, error) {
 		return "No duties found", nil
 	}
 
-	if data.json {
-		bytes, err := json.Marshal(data.duty)
-		if err != nil {
-			return "", errors.Wrap(err, "failed to marshalJSON")
-		}
-		return string(bytes), nil
-	}
-
-	return fmt.Sprintf("Validator attesting in slot %d committee %d", data.duty.Slot, data.duty.CommitteeIndex), nil
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   data.format,
+		Template: data.template,
+		JSON: func(_ context.Context) (string, error) {
+			bytes, err := json.Marshal(data.duty)
+			if err != nil {
+				return "", errors.Wrap(err, "failed to marshalJSON")
+			}
+			return string(bytes), nil
+		},
+		Text: func(_ context.Context) (string, error) {
+			return fmt.Sprintf("Validator attesting in slot %d committee %d", data.duty.Slot, data.duty.CommitteeIndex), nil
+		},
+	})
 }
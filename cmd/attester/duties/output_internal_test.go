@@ -20,6 +20,7 @@ import (
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/stretchr/testify/require"
 	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
 )
 
 func TestOutput(t *testing.T) {
@@ -56,7 +57,7 @@ func TestOutput(t *testing.T) {
 		{
 			name: "JSON",
 			dataOut: &dataOut{
-				json: true,
+				format: util.OutputFormatJSON,
 				duty: &api.AttesterDuty{
 					PubKey:                  testutil.HexToPubKey("0x933ad9491b62059dd065b560d256d8957a8c402cc6e8d8ee7290ae11e8f7329267a8811c397529dac52ae1342ba58c95"),
 					Slot:                    1,
@@ -35,9 +35,11 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	}
 
 	results := &dataOut{
-		debug:   data.debug,
-		quiet:   data.quiet,
-		verbose: data.verbose,
+		debug:    data.debug,
+		quiet:    data.quiet,
+		verbose:  data.verbose,
+		format:   data.format,
+		template: data.template,
 	}
 
 	duty, err := duty(ctx, data.eth2Client, validator, data.epoch)
@@ -28,7 +28,7 @@ var attesterDutiesCmd = &cobra.Command{
 
     ethdo attester duties --validator=Validators/00001 --epoch=12345
 
-In quiet mode this will return 0 if a duty from the attester is found, otherwise 1.`,
+In quiet mode this will return 0 if a duty from the attester is found, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := attesterduties.Run(cmd)
 		if err != nil {
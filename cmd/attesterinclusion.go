@@ -28,7 +28,7 @@ var attesterInclusionCmd = &cobra.Command{
 
     ethdo attester inclusion --validator=Validators/00001 --epoch=12345
 
-In quiet mode this will return 0 if an attestation from the attester is found on the block of the given epoch, otherwise 1.`,
+In quiet mode this will return 0 if an attestation from the attester is found on the block of the given epoch, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := attesterinclusion.Run(cmd)
 		if err != nil {
@@ -23,6 +23,7 @@ import (
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type command struct {
@@ -36,9 +37,10 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Operation.
-	blockID    string
-	stream     bool
-	jsonOutput bool
+	blockID  string
+	stream   bool
+	format   string
+	template string
 
 	// Data access.
 	eth2Client           eth2client.Service
@@ -127,7 +129,8 @@ func newCommand(_ context.Context) (*command, error) {
 
 	c.blockID = viper.GetString("blockid")
 	c.stream = viper.GetBool("stream")
-	c.jsonOutput = viper.GetBool("json")
+	c.format = util.OutputFormat()
+	c.template = viper.GetString("template")
 
 	return c, nil
 }
@@ -30,9 +30,11 @@ type dataIn struct {
 	verbose bool
 	debug   bool
 	// Operation.
-	eth2Client eth2client.Service
-	jsonOutput bool
-	sszOutput  bool
+	eth2Client       eth2client.Service
+	structuredOutput bool
+	sszOutput        bool
+	format           string
+	template         string
 	// Chain information.
 	blockID   string
 	blockTime string
@@ -49,7 +51,9 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
-	data.jsonOutput = viper.GetBool("json")
+	data.format = util.OutputFormat()
+	data.template = viper.GetString("template")
+	data.structuredOutput = data.format != util.OutputFormatText || data.template != ""
 	data.sszOutput = viper.GetBool("ssz")
 	data.blockID = viper.GetString("blockid")
 	data.blockTime = viper.GetString("block-time")
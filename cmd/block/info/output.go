@@ -1,4 +1,4 @@
-// Copyright © 2019 - 2023 Weald Technology Trading.
+// Copyright © 2019 - 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -42,6 +42,8 @@ import (
 type dataOut struct {
 	debug         bool
 	verbose       bool
+	format        string
+	template      string
 	eth2Client    eth2client.Service
 	genesisTime   time.Time
 	slotDuration  time.Duration
@@ -99,7 +101,8 @@ func outputBlockETH1Data(_ context.Context, eth1Data *phase0.ETH1Data) (string,
 	return res.String(), nil
 }
 
-func outputBlockAttestations(ctx context.Context, eth2Client eth2client.Service, verbose bool, attestations []*phase0.Attestation) (string, error) {
+// OutputAttestations outputs phase0 attestations, as found in blocks and the attestation pool.
+func OutputAttestations(ctx context.Context, eth2Client eth2client.Service, verbose bool, attestations []*phase0.Attestation) (string, error) {
 	res := strings.Builder{}
 
 	validatorCommittees := make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
@@ -149,7 +152,8 @@ func outputBlockAttestations(ctx context.Context, eth2Client eth2client.Service,
 	return res.String(), nil
 }
 
-func outputElectraBlockAttestations(ctx context.Context, eth2Client eth2client.Service, verbose bool, attestations []*electra.Attestation) (string, error) {
+// OutputElectraAttestations outputs Electra attestations, as found in blocks and the attestation pool.
+func OutputElectraAttestations(ctx context.Context, eth2Client eth2client.Service, verbose bool, attestations []*electra.Attestation) (string, error) {
 	res := strings.Builder{}
 
 	validatorCommittees := make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
@@ -203,7 +207,8 @@ func outputElectraBlockAttestations(ctx context.Context, eth2Client eth2client.S
 	return res.String(), nil
 }
 
-func outputBlockAttesterSlashings(ctx context.Context, eth2Client eth2client.Service, verbose bool, attesterSlashings []*phase0.AttesterSlashing) (string, error) {
+// OutputAttesterSlashings outputs phase0 attester slashings.
+func OutputAttesterSlashings(ctx context.Context, eth2Client eth2client.Service, verbose bool, attesterSlashings []*phase0.AttesterSlashing) (string, error) {
 	res := strings.Builder{}
 
 	res.WriteString(fmt.Sprintf("Attester slashings: %d\n", len(attesterSlashings)))
@@ -253,7 +258,8 @@ func outputBlockAttesterSlashings(ctx context.Context, eth2Client eth2client.Ser
 	return res.String(), nil
 }
 
-func outputElectraBlockAttesterSlashings(ctx context.Context, eth2Client eth2client.Service, verbose bool, attesterSlashings []*electra.AttesterSlashing) (string, error) {
+// OutputElectraAttesterSlashings outputs Electra attester slashings.
+func OutputElectraAttesterSlashings(ctx context.Context, eth2Client eth2client.Service, verbose bool, attesterSlashings []*electra.AttesterSlashing) (string, error) {
 	res := strings.Builder{}
 
 	res.WriteString(fmt.Sprintf("Attester slashings: %d\n", len(attesterSlashings)))
@@ -303,6 +309,34 @@ func outputElectraBlockAttesterSlashings(ctx context.Context, eth2Client eth2cli
 	return res.String(), nil
 }
 
+// OutputProposerSlashings outputs proposer slashings.
+func OutputProposerSlashings(ctx context.Context, eth2Client eth2client.Service, verbose bool, proposerSlashings []*phase0.ProposerSlashing) (string, error) {
+	res := strings.Builder{}
+
+	res.WriteString(fmt.Sprintf("Proposer slashings: %d\n", len(proposerSlashings)))
+	if verbose {
+		for i, slashing := range proposerSlashings {
+			header1 := slashing.SignedHeader1.Message
+			header2 := slashing.SignedHeader2.Message
+			res.WriteString(fmt.Sprintf("  %d:\n", i))
+			response, err := eth2Client.(eth2client.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{
+				State:   "head",
+				Indices: []phase0.ValidatorIndex{header1.ProposerIndex},
+			})
+			if err != nil {
+				res.WriteString(fmt.Sprintf("  Error: failed to obtain validators: %v\n", err))
+			} else {
+				res.WriteString(fmt.Sprintf("    Slashed validator: %#x (%d)\n", response.Data[header1.ProposerIndex].Validator.PublicKey, header1.ProposerIndex))
+			}
+			res.WriteString(fmt.Sprintf("    Double proposed for slot %d:\n", header1.Slot))
+			res.WriteString(fmt.Sprintf("      Header 1 body root: %#x\n", header1.BodyRoot))
+			res.WriteString(fmt.Sprintf("      Header 2 body root: %#x\n", header2.BodyRoot))
+		}
+	}
+
+	return res.String(), nil
+}
+
 func outputBlockDeposits(_ context.Context, verbose bool, deposits []*phase0.Deposit) (string, error) {
 	res := strings.Builder{}
 
@@ -322,7 +356,8 @@ func outputBlockDeposits(_ context.Context, verbose bool, deposits []*phase0.Dep
 	return res.String(), nil
 }
 
-func outputBlockVoluntaryExits(ctx context.Context, eth2Client eth2client.Service, verbose bool, voluntaryExits []*phase0.SignedVoluntaryExit) (string, error) {
+// OutputVoluntaryExits outputs voluntary exits.
+func OutputVoluntaryExits(ctx context.Context, eth2Client eth2client.Service, verbose bool, voluntaryExits []*phase0.SignedVoluntaryExit) (string, error) {
 	res := strings.Builder{}
 
 	res.WriteString(fmt.Sprintf("Voluntary exits: %d\n", len(voluntaryExits)))
@@ -345,7 +380,8 @@ func outputBlockVoluntaryExits(ctx context.Context, eth2Client eth2client.Servic
 	return res.String(), nil
 }
 
-func outputBlockBLSToExecutionChanges(ctx context.Context, eth2Client eth2client.Service, verbose bool, ops []*capella.SignedBLSToExecutionChange) (string, error) {
+// OutputBLSToExecutionChanges outputs BLS to execution changes.
+func OutputBLSToExecutionChanges(ctx context.Context, eth2Client eth2client.Service, verbose bool, ops []*capella.SignedBLSToExecutionChange) (string, error) {
 	res := strings.Builder{}
 
 	res.WriteString(fmt.Sprintf("BLS to execution changes: %d\n", len(ops)))
@@ -462,21 +498,25 @@ func outputCapellaBlockText(ctx context.Context, data *dataOut, signedBlock *cap
 	res.WriteString(tmp)
 
 	// Attestations.
-	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
+	tmp, err = OutputAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
 	// Attester slashings.
-	tmp, err = outputBlockAttesterSlashings(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.AttesterSlashings)
+	tmp, err = OutputAttesterSlashings(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.AttesterSlashings)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
-	res.WriteString(fmt.Sprintf("Proposer slashings: %d\n", len(body.ProposerSlashings)))
-	// Add verbose proposer slashings.
+	// Proposer slashings.
+	tmp, err = OutputProposerSlashings(ctx, data.eth2Client, data.verbose, body.ProposerSlashings)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
 
 	tmp, err = outputBlockDeposits(ctx, data.verbose, signedBlock.Message.Body.Deposits)
 	if err != nil {
@@ -485,13 +525,13 @@ func outputCapellaBlockText(ctx context.Context, data *dataOut, signedBlock *cap
 	res.WriteString(tmp)
 
 	// Voluntary exits.
-	tmp, err = outputBlockVoluntaryExits(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.VoluntaryExits)
+	tmp, err = OutputVoluntaryExits(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.VoluntaryExits)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
-	tmp, err = outputBlockBLSToExecutionChanges(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.BLSToExecutionChanges)
+	tmp, err = OutputBLSToExecutionChanges(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.BLSToExecutionChanges)
 	if err != nil {
 		return "", err
 	}
@@ -566,21 +606,25 @@ func outputDenebBlockText(ctx context.Context,
 	res.WriteString(tmp)
 
 	// Attestations.
-	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
+	tmp, err = OutputAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
 	// Attester slashings.
-	tmp, err = outputBlockAttesterSlashings(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.AttesterSlashings)
+	tmp, err = OutputAttesterSlashings(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.AttesterSlashings)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
-	res.WriteString(fmt.Sprintf("Proposer slashings: %d\n", len(body.ProposerSlashings)))
-	// Add verbose proposer slashings.
+	// Proposer slashings.
+	tmp, err = OutputProposerSlashings(ctx, data.eth2Client, data.verbose, body.ProposerSlashings)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
 
 	tmp, err = outputBlockDeposits(ctx, data.verbose, signedBlock.Message.Body.Deposits)
 	if err != nil {
@@ -589,13 +633,13 @@ func outputDenebBlockText(ctx context.Context,
 	res.WriteString(tmp)
 
 	// Voluntary exits.
-	tmp, err = outputBlockVoluntaryExits(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.VoluntaryExits)
+	tmp, err = OutputVoluntaryExits(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.VoluntaryExits)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
-	tmp, err = outputBlockBLSToExecutionChanges(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.BLSToExecutionChanges)
+	tmp, err = OutputBLSToExecutionChanges(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.BLSToExecutionChanges)
 	if err != nil {
 		return "", err
 	}
@@ -676,30 +720,34 @@ func outputElectraBlockText(ctx context.Context,
 	res.WriteString(tmp)
 
 	// Attestations.
-	tmp, err = outputElectraBlockAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
+	tmp, err = OutputElectraAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
 	// Attester slashings.
-	tmp, err = outputElectraBlockAttesterSlashings(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.AttesterSlashings)
+	tmp, err = OutputElectraAttesterSlashings(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.AttesterSlashings)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
-	res.WriteString(fmt.Sprintf("Proposer slashings: %d\n", len(body.ProposerSlashings)))
-	// Add verbose proposer slashings.
+	// Proposer slashings.
+	tmp, err = OutputProposerSlashings(ctx, data.eth2Client, data.verbose, body.ProposerSlashings)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
 
 	// Voluntary exits.
-	tmp, err = outputBlockVoluntaryExits(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.VoluntaryExits)
+	tmp, err = OutputVoluntaryExits(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.VoluntaryExits)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
-	tmp, err = outputBlockBLSToExecutionChanges(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.BLSToExecutionChanges)
+	tmp, err = OutputBLSToExecutionChanges(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.BLSToExecutionChanges)
 	if err != nil {
 		return "", err
 	}
@@ -779,21 +827,25 @@ func outputBellatrixBlockText(ctx context.Context, data *dataOut, signedBlock *b
 	res.WriteString(tmp)
 
 	// Attestations.
-	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
+	tmp, err = OutputAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
 	// Attester slashings.
-	tmp, err = outputBlockAttesterSlashings(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.AttesterSlashings)
+	tmp, err = OutputAttesterSlashings(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.AttesterSlashings)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
-	res.WriteString(fmt.Sprintf("Proposer slashings: %d\n", len(body.ProposerSlashings)))
-	// Add verbose proposer slashings.
+	// Proposer slashings.
+	tmp, err = OutputProposerSlashings(ctx, data.eth2Client, data.verbose, body.ProposerSlashings)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
 
 	tmp, err = outputBlockDeposits(ctx, data.verbose, signedBlock.Message.Body.Deposits)
 	if err != nil {
@@ -802,7 +854,7 @@ func outputBellatrixBlockText(ctx context.Context, data *dataOut, signedBlock *b
 	res.WriteString(tmp)
 
 	// Voluntary exits.
-	tmp, err = outputBlockVoluntaryExits(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.VoluntaryExits)
+	tmp, err = OutputVoluntaryExits(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.VoluntaryExits)
 	if err != nil {
 		return "", err
 	}
@@ -870,21 +922,25 @@ func outputAltairBlockText(ctx context.Context, data *dataOut, signedBlock *alta
 	res.WriteString(tmp)
 
 	// Attestations.
-	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
+	tmp, err = OutputAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
 	// Attester slashings.
-	tmp, err = outputBlockAttesterSlashings(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.AttesterSlashings)
+	tmp, err = OutputAttesterSlashings(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.AttesterSlashings)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
-	res.WriteString(fmt.Sprintf("Proposer slashings: %d\n", len(body.ProposerSlashings)))
-	// Add verbose proposer slashings.
+	// Proposer slashings.
+	tmp, err = OutputProposerSlashings(ctx, data.eth2Client, data.verbose, body.ProposerSlashings)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
 
 	tmp, err = outputBlockDeposits(ctx, data.verbose, signedBlock.Message.Body.Deposits)
 	if err != nil {
@@ -893,7 +949,7 @@ func outputAltairBlockText(ctx context.Context, data *dataOut, signedBlock *alta
 	res.WriteString(tmp)
 
 	// Voluntary exits.
-	tmp, err = outputBlockVoluntaryExits(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.VoluntaryExits)
+	tmp, err = OutputVoluntaryExits(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.VoluntaryExits)
 	if err != nil {
 		return "", err
 	}
@@ -947,21 +1003,25 @@ func outputPhase0BlockText(ctx context.Context, data *dataOut, signedBlock *phas
 	}
 
 	// Attestations.
-	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
+	tmp, err = OutputAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
 	// Attester slashings.
-	tmp, err = outputBlockAttesterSlashings(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.AttesterSlashings)
+	tmp, err = OutputAttesterSlashings(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.AttesterSlashings)
 	if err != nil {
 		return "", err
 	}
 	res.WriteString(tmp)
 
-	res.WriteString(fmt.Sprintf("Proposer slashings: %d\n", len(body.ProposerSlashings)))
-	// Add verbose proposer slashings.
+	// Proposer slashings.
+	tmp, err = OutputProposerSlashings(ctx, data.eth2Client, data.verbose, body.ProposerSlashings)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
 
 	tmp, err = outputBlockDeposits(ctx, data.verbose, signedBlock.Message.Body.Deposits)
 	if err != nil {
@@ -970,7 +1030,7 @@ func outputPhase0BlockText(ctx context.Context, data *dataOut, signedBlock *phas
 	res.WriteString(tmp)
 
 	// Voluntary exits.
-	tmp, err = outputBlockVoluntaryExits(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.VoluntaryExits)
+	tmp, err = OutputVoluntaryExits(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.VoluntaryExits)
 	if err != nil {
 		return "", err
 	}
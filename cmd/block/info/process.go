@@ -35,12 +35,13 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
 )
 
 var (
-	jsonOutput bool
-	sszOutput  bool
-	results    *dataOut
+	structuredOutput bool
+	sszOutput        bool
+	results          *dataOut
 )
 
 func process(ctx context.Context, data *dataIn) (*dataOut, error) {
@@ -54,6 +55,8 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	results = &dataOut{
 		debug:      data.debug,
 		verbose:    data.verbose,
+		format:     data.format,
+		template:   data.template,
 		eth2Client: data.eth2Client,
 	}
 
@@ -79,13 +82,13 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 
 	switch block.Version {
 	case spec.DataVersionPhase0:
-		err = outputPhase0Block(ctx, data.jsonOutput, block.Phase0)
+		err = outputPhase0Block(ctx, data.structuredOutput, block.Phase0)
 	case spec.DataVersionAltair:
-		err = outputAltairBlock(ctx, data.jsonOutput, data.sszOutput, block.Altair)
+		err = outputAltairBlock(ctx, data.structuredOutput, data.sszOutput, block.Altair)
 	case spec.DataVersionBellatrix:
-		err = outputBellatrixBlock(ctx, data.jsonOutput, data.sszOutput, block.Bellatrix)
+		err = outputBellatrixBlock(ctx, data.structuredOutput, data.sszOutput, block.Bellatrix)
 	case spec.DataVersionCapella:
-		err = outputCapellaBlock(ctx, data.jsonOutput, data.sszOutput, block.Capella)
+		err = outputCapellaBlock(ctx, data.structuredOutput, data.sszOutput, block.Capella)
 	case spec.DataVersionDeneb:
 		err = processDenebBlock(ctx, data, block)
 	case spec.DataVersionElectra:
@@ -100,9 +103,9 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	}
 
 	if data.stream {
-		jsonOutput = data.jsonOutput
+		structuredOutput = data.structuredOutput
 		sszOutput = data.sszOutput
-		if !jsonOutput && !sszOutput {
+		if !structuredOutput && !sszOutput {
 			fmt.Println("")
 		}
 		err := data.eth2Client.(eth2client.EventsProvider).Events(ctx, &api.EventsOpts{
@@ -181,7 +184,7 @@ func processDenebBlock(ctx context.Context,
 			blobSidecars = blobSidecarsResponse.Data
 		}
 	}
-	if err := outputDenebBlock(ctx, data.jsonOutput, data.sszOutput, block.Deneb, blobSidecars); err != nil {
+	if err := outputDenebBlock(ctx, data.structuredOutput, data.sszOutput, block.Deneb, blobSidecars); err != nil {
 		return errors.Wrap(err, "failed to output block")
 	}
 
@@ -210,7 +213,7 @@ func processElectraBlock(ctx context.Context,
 			blobSidecars = blobSidecarsResponse.Data
 		}
 	}
-	if err := outputElectraBlock(ctx, data.jsonOutput, data.sszOutput, block.Electra, blobSidecars); err != nil {
+	if err := outputElectraBlock(ctx, data.structuredOutput, data.sszOutput, block.Electra, blobSidecars); err != nil {
 		return errors.Wrap(err, "failed to output block")
 	}
 
@@ -239,7 +242,7 @@ func processFuluBlock(ctx context.Context,
 			blobSidecars = blobSidecarsResponse.Data
 		}
 	}
-	if err := outputFuluBlock(ctx, data.jsonOutput, data.sszOutput, block.Fulu, blobSidecars); err != nil {
+	if err := outputFuluBlock(ctx, data.structuredOutput, data.sszOutput, block.Fulu, blobSidecars); err != nil {
 		return errors.Wrap(err, "failed to output block")
 	}
 
@@ -252,14 +255,14 @@ func headEventHandler(ctx context.Context, headEvent *apiv1.HeadEvent) {
 		Block: blockID,
 	})
 	if err != nil {
-		if !jsonOutput && !sszOutput {
+		if !structuredOutput && !sszOutput {
 			fmt.Printf("Failed to obtain block: %v\n", err)
 		}
 		return
 	}
 	block := blockResponse.Data
 	if block == nil {
-		if !jsonOutput && !sszOutput {
+		if !structuredOutput && !sszOutput {
 			fmt.Println("Empty beacon block")
 		}
 		return
@@ -267,13 +270,13 @@ func headEventHandler(ctx context.Context, headEvent *apiv1.HeadEvent) {
 
 	switch block.Version {
 	case spec.DataVersionPhase0:
-		err = outputPhase0Block(ctx, jsonOutput, block.Phase0)
+		err = outputPhase0Block(ctx, structuredOutput, block.Phase0)
 	case spec.DataVersionAltair:
-		err = outputAltairBlock(ctx, jsonOutput, sszOutput, block.Altair)
+		err = outputAltairBlock(ctx, structuredOutput, sszOutput, block.Altair)
 	case spec.DataVersionBellatrix:
-		err = outputBellatrixBlock(ctx, jsonOutput, sszOutput, block.Bellatrix)
+		err = outputBellatrixBlock(ctx, structuredOutput, sszOutput, block.Bellatrix)
 	case spec.DataVersionCapella:
-		err = outputCapellaBlock(ctx, jsonOutput, sszOutput, block.Capella)
+		err = outputCapellaBlock(ctx, structuredOutput, sszOutput, block.Capella)
 	case spec.DataVersionDeneb:
 		var blobSidecars []*deneb.BlobSidecar
 		var kzgCommitments []deneb.KZGCommitment
@@ -293,7 +296,7 @@ func headEventHandler(ctx context.Context, headEvent *apiv1.HeadEvent) {
 			}
 			blobSidecars = blobSidecarsResponse.Data
 		}
-		err = outputDenebBlock(context.Background(), jsonOutput, sszOutput, block.Deneb, blobSidecars)
+		err = outputDenebBlock(context.Background(), structuredOutput, sszOutput, block.Deneb, blobSidecars)
 	case spec.DataVersionElectra:
 		var blobSidecars []*deneb.BlobSidecar
 		var kzgCommitments []deneb.KZGCommitment
@@ -313,7 +316,7 @@ func headEventHandler(ctx context.Context, headEvent *apiv1.HeadEvent) {
 			}
 			blobSidecars = blobSidecarsResponse.Data
 		}
-		err = outputElectraBlock(context.Background(), jsonOutput, sszOutput, block.Electra, blobSidecars)
+		err = outputElectraBlock(context.Background(), structuredOutput, sszOutput, block.Electra, blobSidecars)
 	case spec.DataVersionFulu:
 		var blobSidecars []*deneb.BlobSidecar
 		var kzgCommitments []deneb.KZGCommitment
@@ -333,28 +336,46 @@ func headEventHandler(ctx context.Context, headEvent *apiv1.HeadEvent) {
 			}
 			blobSidecars = blobSidecarsResponse.Data
 		}
-		err = outputFuluBlock(context.Background(), jsonOutput, sszOutput, block.Fulu, blobSidecars)
+		err = outputFuluBlock(context.Background(), structuredOutput, sszOutput, block.Fulu, blobSidecars)
 	default:
 		err = errors.New("unknown block version")
 	}
-	if err != nil && !jsonOutput && !sszOutput {
+	if err != nil && !structuredOutput && !sszOutput {
 		fmt.Printf("Failed to output block: %v\n", err)
 		return
 	}
 
-	if !jsonOutput && !sszOutput {
+	if !structuredOutput && !sszOutput {
 		fmt.Println("")
 	}
 }
 
-func outputPhase0Block(ctx context.Context, jsonOutput bool, signedBlock *phase0.SignedBeaconBlock) error {
+// outputStructuredBlock outputs a block in the structured format requested by the user.
+func outputStructuredBlock(ctx context.Context, signedBlock any) error {
+	data, err := util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   results.format,
+		Template: results.template,
+		JSON: func(_ context.Context) (string, error) {
+			data, err := json.Marshal(signedBlock)
+			if err != nil {
+				return "", errors.Wrap(err, "failed to generate JSON")
+			}
+
+			return string(data), nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func outputPhase0Block(ctx context.Context, structuredOutput bool, signedBlock *phase0.SignedBeaconBlock) error {
 	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
-		}
-		fmt.Printf("%s\n", string(data))
+	case structuredOutput:
+		return outputStructuredBlock(ctx, signedBlock)
 	default:
 		data, err := outputPhase0BlockText(ctx, results, signedBlock)
 		if err != nil {
@@ -365,14 +386,10 @@ func outputPhase0Block(ctx context.Context, jsonOutput bool, signedBlock *phase0
 	return nil
 }
 
-func outputAltairBlock(ctx context.Context, jsonOutput bool, sszOutput bool, signedBlock *altair.SignedBeaconBlock) error {
+func outputAltairBlock(ctx context.Context, structuredOutput bool, sszOutput bool, signedBlock *altair.SignedBeaconBlock) error {
 	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
-		}
-		fmt.Printf("%s\n", string(data))
+	case structuredOutput:
+		return outputStructuredBlock(ctx, signedBlock)
 	case sszOutput:
 		data, err := signedBlock.MarshalSSZ()
 		if err != nil {
@@ -389,14 +406,10 @@ func outputAltairBlock(ctx context.Context, jsonOutput bool, sszOutput bool, sig
 	return nil
 }
 
-func outputBellatrixBlock(ctx context.Context, jsonOutput bool, sszOutput bool, signedBlock *bellatrix.SignedBeaconBlock) error {
+func outputBellatrixBlock(ctx context.Context, structuredOutput bool, sszOutput bool, signedBlock *bellatrix.SignedBeaconBlock) error {
 	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
-		}
-		fmt.Printf("%s\n", string(data))
+	case structuredOutput:
+		return outputStructuredBlock(ctx, signedBlock)
 	case sszOutput:
 		data, err := signedBlock.MarshalSSZ()
 		if err != nil {
@@ -413,14 +426,10 @@ func outputBellatrixBlock(ctx context.Context, jsonOutput bool, sszOutput bool,
 	return nil
 }
 
-func outputCapellaBlock(ctx context.Context, jsonOutput bool, sszOutput bool, signedBlock *capella.SignedBeaconBlock) error {
+func outputCapellaBlock(ctx context.Context, structuredOutput bool, sszOutput bool, signedBlock *capella.SignedBeaconBlock) error {
 	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
-		}
-		fmt.Printf("%s\n", string(data))
+	case structuredOutput:
+		return outputStructuredBlock(ctx, signedBlock)
 	case sszOutput:
 		data, err := signedBlock.MarshalSSZ()
 		if err != nil {
@@ -438,18 +447,14 @@ func outputCapellaBlock(ctx context.Context, jsonOutput bool, sszOutput bool, si
 }
 
 func outputDenebBlock(ctx context.Context,
-	jsonOutput bool,
+	structuredOutput bool,
 	sszOutput bool,
 	signedBlock *deneb.SignedBeaconBlock,
 	blobs []*deneb.BlobSidecar,
 ) error {
 	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
-		}
-		fmt.Printf("%s\n", string(data))
+	case structuredOutput:
+		return outputStructuredBlock(ctx, signedBlock)
 	case sszOutput:
 		data, err := signedBlock.MarshalSSZ()
 		if err != nil {
@@ -467,18 +472,14 @@ func outputDenebBlock(ctx context.Context,
 }
 
 func outputElectraBlock(ctx context.Context,
-	jsonOutput bool,
+	structuredOutput bool,
 	sszOutput bool,
 	signedBlock *electra.SignedBeaconBlock,
 	blobs []*deneb.BlobSidecar,
 ) error {
 	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
-		}
-		fmt.Printf("%s\n", string(data))
+	case structuredOutput:
+		return outputStructuredBlock(ctx, signedBlock)
 	case sszOutput:
 		data, err := signedBlock.MarshalSSZ()
 		if err != nil {
@@ -496,18 +497,14 @@ func outputElectraBlock(ctx context.Context,
 }
 
 func outputFuluBlock(ctx context.Context,
-	jsonOutput bool,
+	structuredOutput bool,
 	sszOutput bool,
 	signedBlock *electra.SignedBeaconBlock,
 	blobs []*deneb.BlobSidecar,
 ) error {
 	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
-		}
-		fmt.Printf("%s\n", string(data))
+	case structuredOutput:
+		return outputStructuredBlock(ctx, signedBlock)
 	case sszOutput:
 		data, err := signedBlock.MarshalSSZ()
 		if err != nil {
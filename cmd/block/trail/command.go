@@ -22,6 +22,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type command struct {
@@ -35,10 +36,11 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Operation.
-	blockID    string
-	jsonOutput bool
-	target     string
-	maxBlocks  int
+	blockID   string
+	format    string
+	template  string
+	target    string
+	maxBlocks int
 
 	// Data access.
 	consensusClient      eth2client.Service
@@ -71,7 +73,8 @@ func newCommand(_ context.Context) (*command, error) {
 		quiet:                    viper.GetBool("quiet"),
 		verbose:                  viper.GetBool("verbose"),
 		debug:                    viper.GetBool("debug"),
-		jsonOutput:               viper.GetBool("json"),
+		format:                   util.OutputFormat(),
+		template:                 viper.GetString("template"),
 		connection:               viper.GetString("connection"),
 		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
 		blockID:                  viper.GetString("blockid"),
@@ -20,6 +20,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 )
 
 // Run runs the command.
@@ -28,7 +29,7 @@ func Run(cmd *cobra.Command) (string, error) {
 
 	c, err := newCommand(ctx)
 	if err != nil {
-		return "", errors.Join(errors.New("failed to set up command"), err)
+		return "", util.ErrorWithCode(errors.Join(errors.New("failed to set up command"), err), util.ErrorCodeInvalidInput, nil)
 	}
 
 	// Further errors do not need a usage report.
@@ -37,7 +38,7 @@ func Run(cmd *cobra.Command) (string, error) {
 	if err := c.process(ctx); err != nil {
 		switch {
 		case errors.Is(err, context.DeadlineExceeded):
-			return "", errors.New("operation timed out; try increasing with --timeout option")
+			return "", util.NewError(util.ErrorCodeTimeout, "operation timed out; try increasing with --timeout option")
 		default:
 			return "", errors.Join(errors.New("failed to process"), err)
 		}
@@ -28,7 +28,7 @@ var blockAnalyzeCmd = &cobra.Command{
 
     ethdo block analyze --blockid=12345
 
-In quiet mode this will return 0 if the block information is present and not skipped, otherwise 1.`,
+In quiet mode this will return 0 if the block information is present and not skipped, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := blockanalyze.Run(cmd)
 		if err != nil {
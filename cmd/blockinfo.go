@@ -28,7 +28,7 @@ var blockInfoCmd = &cobra.Command{
 
     ethdo block info --blockid=12345
 
-In quiet mode this will return 0 if the block information is present and not skipped, otherwise 1.`,
+In quiet mode this will return 0 if the block information is present and not skipped, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := blockinfo.Run(cmd)
 		if err != nil {
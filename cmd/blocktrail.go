@@ -28,7 +28,7 @@ var blockTrailCmd = &cobra.Command{
 
     ethdo block trail --blockid=12345 --target=finalized
 
-In quiet mode this will return 0 if the block trail ends up at the finalized state, otherwise 1.`,
+In quiet mode this will return 0 if the block trail ends up at the finalized state, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := blocktrail.Run(cmd)
 		if err != nil {
@@ -0,0 +1,106 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+type networkJSON struct {
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	Entries               int    `json:"entries"`
+	Size                  int64  `json:"size"`
+	Oldest                string `json:"oldest"`
+	Newest                string `json:"newest"`
+}
+
+type jsonOutput struct {
+	Directory string         `json:"directory"`
+	MaxSize   int64          `json:"max_size"`
+	Size      int64          `json:"size"`
+	Networks  []*networkJSON `json:"networks"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputText,
+	})
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Directory: c.dir,
+		MaxSize:   c.maxSize,
+		Networks:  make([]*networkJSON, 0, len(c.networks)),
+	}
+	for _, network := range c.networks {
+		output.Size += network.Size
+		output.Networks = append(output.Networks, &networkJSON{
+			GenesisValidatorsRoot: network.GenesisValidatorsRoot,
+			Entries:               network.Entries,
+			Size:                  network.Size,
+			Oldest:                network.Oldest.Format(time.RFC3339),
+			Newest:                network.Newest.Format(time.RFC3339),
+		})
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	size := int64(0)
+	for _, network := range c.networks {
+		size += network.Size
+	}
+
+	builder.WriteString(fmt.Sprintf("Directory: %s\n", c.dir))
+	builder.WriteString(fmt.Sprintf("Size: %s\n", formatSize(size)))
+	builder.WriteString(fmt.Sprintf("Maximum size: %s\n", formatSize(c.maxSize)))
+	for _, network := range c.networks {
+		builder.WriteString(fmt.Sprintf("Network %s:\n", network.GenesisValidatorsRoot))
+		builder.WriteString(fmt.Sprintf("  Entries: %d\n", network.Entries))
+		builder.WriteString(fmt.Sprintf("  Size: %s\n", formatSize(network.Size)))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("  Least recently used: %s\n", network.Oldest.Format("2006-01-02 15:04:05")))
+			builder.WriteString(fmt.Sprintf("  Most recently used: %s\n", network.Newest.Format("2006-01-02 15:04:05")))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// formatSize formats a size in bytes.
+func formatSize(size int64) string {
+	return fmt.Sprintf("%.1f MiB", float64(size)/(1024*1024))
+}
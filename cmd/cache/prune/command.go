@@ -0,0 +1,53 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheprune
+
+import (
+	"context"
+
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet    bool
+	verbose  bool
+	debug    bool
+	format   string
+	template string
+
+	// Input.
+	dir     string
+	maxSize int64
+	all     bool
+
+	// Output.
+	before int64
+	after  int64
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:    viper.GetBool("quiet"),
+		verbose:  viper.GetBool("verbose"),
+		debug:    viper.GetBool("debug"),
+		format:   util.OutputFormat(),
+		template: viper.GetString("template"),
+		dir:      util.BeaconNodeCacheDir(),
+		maxSize:  util.BeaconNodeCacheMaxSize(),
+		all:      viper.GetBool("all"),
+	}
+
+	return c, nil
+}
@@ -0,0 +1,56 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheprune
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+type jsonOutput struct {
+	Before int64 `json:"before"`
+	After  int64 `json:"after"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputText,
+	})
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		Before: c.before,
+		After:  c.after,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	return fmt.Sprintf("Cache pruned from %.1f MiB to %.1f MiB", float64(c.before)/(1024*1024), float64(c.after)/(1024*1024)), nil
+}
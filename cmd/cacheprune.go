@@ -0,0 +1,59 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	cacheprune "github.com/wealdtech/ethdo/cmd/cache/prune"
+)
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Prune the on-disk cache",
+	Long: `Prune the on-disk cache of finalized chain data, removing the least recently used entries until it is no larger than --cache-max-size.  For example:
+
+    ethdo cache prune --cache-max-size=512
+
+All entries can be removed with --all.
+
+In quiet mode this will return 0 if the cache was pruned, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := cacheprune.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cachePruneCmd)
+	cacheFlags(cachePruneCmd)
+	cachePruneCmd.Flags().Bool("all", false, "remove all entries from the cache")
+}
+
+func cachePruneBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("all", cmd.Flags().Lookup("all")); err != nil {
+		panic(err)
+	}
+}
@@ -22,13 +22,15 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type command struct {
-	quiet   bool
-	verbose bool
-	debug   bool
-	json    bool
+	quiet    bool
+	verbose  bool
+	debug    bool
+	format   string
+	template string
 
 	// Beacon node connection.
 	timeout                  time.Duration
@@ -64,10 +66,11 @@ type vote struct {
 
 func newCommand(_ context.Context) (*command, error) {
 	c := &command{
-		quiet:   viper.GetBool("quiet"),
-		verbose: viper.GetBool("verbose"),
-		debug:   viper.GetBool("debug"),
-		json:    viper.GetBool("json"),
+		quiet:    viper.GetBool("quiet"),
+		verbose:  viper.GetBool("verbose"),
+		debug:    viper.GetBool("debug"),
+		format:   util.OutputFormat(),
+		template: viper.GetString("template"),
 	}
 
 	// Timeout.
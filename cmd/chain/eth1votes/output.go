@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type jsonOutput struct {
@@ -38,10 +39,12 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
-	if c.json {
-		return c.outputJSON(ctx)
-	}
-	return c.outputText(ctx)
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputText,
+	})
 }
 
 func (c *command) outputJSON(_ context.Context) (string, error) {
@@ -0,0 +1,105 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainforks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hako/durafmt"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type jsonFork struct {
+	Name               string `json:"name"`
+	Epoch              string `json:"epoch"`
+	Version            string `json:"version"`
+	Digest             string `json:"digest"`
+	BlobParametersOnly bool   `json:"blob_parameters_only"`
+	MaxBlobsPerBlock   uint64 `json:"max_blobs_per_block"`
+	StartTime          string `json:"start_time"`
+	Current            bool   `json:"current"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputText,
+	})
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := make([]*jsonFork, 0, len(c.forks))
+	for _, fork := range c.forks {
+		output = append(output, &jsonFork{
+			Name:               fork.Name,
+			Epoch:              fmt.Sprintf("%d", fork.Epoch),
+			Version:            fmt.Sprintf("%#x", fork.Version),
+			Digest:             fmt.Sprintf("%#x", fork.digest),
+			BlobParametersOnly: fork.BlobParametersOnly,
+			MaxBlobsPerBlock:   fork.MaxBlobsPerBlock,
+			StartTime:          fork.startTime.Format(time.RFC3339),
+			Current:            fork.current,
+		})
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	for _, fork := range c.forks {
+		builder.WriteString(fork.Name)
+		builder.WriteString(": epoch ")
+		builder.WriteString(fmt.Sprintf("%d", fork.Epoch))
+		builder.WriteString(", ")
+		builder.WriteString(fork.startTime.Format("2006-01-02 15:04:05"))
+		switch {
+		case fork.current:
+			builder.WriteString(" (current)")
+		case fork.startTime.After(c.now):
+			builder.WriteString(" (in ")
+			builder.WriteString(durafmt.Parse(fork.startTime.Sub(c.now).Truncate(time.Second)).LimitFirstN(2).String())
+			builder.WriteString(")")
+		}
+		builder.WriteString("\n")
+
+		if fork.BlobParametersOnly {
+			builder.WriteString(fmt.Sprintf("  Blob parameters only; max blobs per block %d\n", fork.MaxBlobsPerBlock))
+		}
+		if c.verbose || !fork.BlobParametersOnly {
+			builder.WriteString(fmt.Sprintf("  Version: %#x\n", fork.Version))
+		}
+		builder.WriteString(fmt.Sprintf("  Digest: %#x\n", fork.digest))
+		if c.verbose && !fork.BlobParametersOnly && fork.MaxBlobsPerBlock > 0 {
+			builder.WriteString(fmt.Sprintf("  Max blobs per block: %d\n", fork.MaxBlobsPerBlock))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
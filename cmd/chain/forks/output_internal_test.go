@@ -0,0 +1,118 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainforks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestOutput(t *testing.T) {
+	now := time.Date(2025, 12, 1, 0, 0, 0, 0, time.Local)
+	forks := []*fork{
+		{
+			Fork: &chaintime.Fork{
+				Name:             "electra",
+				Epoch:            364032,
+				Version:          phase0.Version{0x05, 0x00, 0x00, 0x00},
+				MaxBlobsPerBlock: 9,
+			},
+			digest:    phase0.ForkDigest{0xad, 0x53, 0x2c, 0xeb},
+			startTime: time.Date(2025, 5, 7, 10, 5, 11, 0, time.Local),
+			current:   true,
+		},
+		{
+			Fork: &chaintime.Fork{
+				Name:             "fulu",
+				Epoch:            411392,
+				Version:          phase0.Version{0x06, 0x00, 0x00, 0x00},
+				MaxBlobsPerBlock: 9,
+			},
+			digest:    phase0.ForkDigest{0xcc, 0x2c, 0x5c, 0xdb},
+			startTime: time.Date(2025, 12, 3, 21, 49, 11, 0, time.Local),
+		},
+		{
+			Fork: &chaintime.Fork{
+				Name:               "bpo1",
+				Epoch:              412672,
+				Version:            phase0.Version{0x06, 0x00, 0x00, 0x00},
+				BlobParametersOnly: true,
+				MaxBlobsPerBlock:   15,
+			},
+			digest:    phase0.ForkDigest{0xcb, 0x0d, 0x1a, 0xcc},
+			startTime: time.Date(2025, 12, 9, 14, 21, 11, 0, time.Local),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Empty",
+			command: &command{
+				now: now,
+			},
+		},
+		{
+			name: "Quiet",
+			command: &command{
+				quiet: true,
+				now:   now,
+				forks: forks,
+			},
+		},
+		{
+			name: "Text",
+			command: &command{
+				now:   now,
+				forks: forks,
+			},
+			res: "electra: epoch 364032, 2025-05-07 10:05:11 (current)\n  Version: 0x05000000\n  Digest: 0xad532ceb\nfulu: epoch 411392, 2025-12-03 21:49:11 (in 2 days 21 hours)\n  Version: 0x06000000\n  Digest: 0xcc2c5cdb\nbpo1: epoch 412672, 2025-12-09 14:21:11 (in 1 week 1 day)\n  Blob parameters only; max blobs per block 15\n  Digest: 0xcb0d1acc",
+		},
+		{
+			name: "Verbose",
+			command: &command{
+				verbose: true,
+				now:     now,
+				forks:   forks[2:],
+			},
+			res: "bpo1: epoch 412672, 2025-12-09 14:21:11 (in 1 week 1 day)\n  Blob parameters only; max blobs per block 15\n  Version: 0x06000000\n  Digest: 0xcb0d1acc",
+		},
+		{
+			name: "JSON",
+			command: &command{
+				format: util.OutputFormatJSON,
+				now:    now,
+				forks:  forks[2:],
+			},
+			res: `[{"name":"bpo1","epoch":"412672","version":"0x06000000","digest":"0xcb0d1acc","blob_parameters_only":true,"max_blobs_per_block":15,"start_time":"` + time.Date(2025, 12, 9, 14, 21, 11, 0, time.Local).Format(time.RFC3339) + `","current":false}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
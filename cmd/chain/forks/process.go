@@ -0,0 +1,74 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainforks
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.now = time.Now()
+	currentFork := c.chainTime.ForkAtEpoch(c.chainTime.CurrentEpoch())
+	for _, chainFork := range c.chainTime.Forks() {
+		c.forks = append(c.forks, &fork{
+			Fork:      chainFork,
+			digest:    c.chainTime.ForkDigestAtEpoch(chainFork.Epoch),
+			startTime: c.chainTime.StartOfEpoch(chainFork.Epoch),
+			current:   chainFork == currentFork,
+		})
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var eth2Client eth2client.Service
+	if c.networkConfig != nil {
+		// Use the network configuration in place of a beacon node.
+		eth2Client = c.networkConfig
+	} else {
+		var err error
+		eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+			Address:       c.connection,
+			Timeout:       c.timeout,
+			AllowInsecure: c.allowInsecureConnections,
+			LogFallback:   !c.quiet,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to connect to beacon node")
+		}
+	}
+
+	var err error
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	return nil
+}
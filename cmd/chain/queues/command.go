@@ -1,4 +1,4 @@
-// Copyright © 2022 Weald Technology Trading.
+// Copyright © 2022, 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -18,16 +18,19 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type command struct {
-	quiet   bool
-	verbose bool
-	debug   bool
-	json    bool
+	quiet    bool
+	verbose  bool
+	debug    bool
+	format   string
+	template string
 
 	// Beacon node connection.
 	timeout                  time.Duration
@@ -43,16 +46,29 @@ type command struct {
 	chainTime          chaintime.Service
 
 	// Output.
-	activationQueue int
-	exitQueue       int
+	activationQueue        int
+	activationQueueBalance phase0.Gwei
+	exitQueue              int
+	exitQueueBalance       phase0.Gwei
+	exitQueueEpoch         phase0.Epoch
+	// Electra queues and churn; only populated from Electra onwards.
+	electra                   bool
+	depositQueue              int
+	depositQueueBalance       phase0.Gwei
+	withdrawalQueue           int
+	withdrawalQueueBalance    phase0.Gwei
+	consolidationQueue        int
+	consolidationQueueBalance phase0.Gwei
+	churnLimits               *util.ChurnLimits
 }
 
 func newCommand(_ context.Context) (*command, error) {
 	c := &command{
-		quiet:   viper.GetBool("quiet"),
-		verbose: viper.GetBool("verbose"),
-		debug:   viper.GetBool("debug"),
-		json:    viper.GetBool("json"),
+		quiet:    viper.GetBool("quiet"),
+		verbose:  viper.GetBool("verbose"),
+		debug:    viper.GetBool("debug"),
+		format:   util.OutputFormat(),
+		template: viper.GetString("template"),
 	}
 
 	// Timeout.
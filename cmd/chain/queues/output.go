@@ -1,4 +1,4 @@
-// Copyright © 2022 Weald Technology Trading.
+// Copyright © 2022, 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -18,11 +18,30 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
 )
 
 type jsonOutput struct {
-	ActivationQueue int `json:"activation_queue"`
-	ExitQueue       int `json:"exit_queue"`
+	ActivationQueue           int        `json:"activation_queue"`
+	ActivationQueueBalance    uint64     `json:"activation_queue_balance"`
+	ExitQueue                 int        `json:"exit_queue"`
+	ExitQueueBalance          uint64     `json:"exit_queue_balance"`
+	DepositQueue              int        `json:"deposit_queue"`
+	DepositQueueBalance       uint64     `json:"deposit_queue_balance"`
+	WithdrawalQueue           int        `json:"withdrawal_queue"`
+	WithdrawalQueueBalance    uint64     `json:"withdrawal_queue_balance"`
+	ConsolidationQueue        int        `json:"consolidation_queue"`
+	ConsolidationQueueBalance uint64     `json:"consolidation_queue_balance"`
+	Churn                     *churnJSON `json:"churn,omitempty"`
+}
+
+type churnJSON struct {
+	Balance        uint64 `json:"balance"`
+	ActivationExit uint64 `json:"activation_exit"`
+	Consolidation  uint64 `json:"consolidation"`
 }
 
 func (c *command) output(ctx context.Context) (string, error) {
@@ -30,16 +49,33 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
-	if c.json {
-		return c.outputJSON(ctx)
-	}
-	return c.outputText(ctx)
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputText,
+	})
 }
 
 func (c *command) outputJSON(_ context.Context) (string, error) {
 	output := &jsonOutput{
-		ActivationQueue: c.activationQueue,
-		ExitQueue:       c.exitQueue,
+		ActivationQueue:           c.activationQueue,
+		ActivationQueueBalance:    uint64(c.activationQueueBalance),
+		ExitQueue:                 c.exitQueue,
+		ExitQueueBalance:          uint64(c.exitQueueBalance),
+		DepositQueue:              c.depositQueue,
+		DepositQueueBalance:       uint64(c.depositQueueBalance),
+		WithdrawalQueue:           c.withdrawalQueue,
+		WithdrawalQueueBalance:    uint64(c.withdrawalQueueBalance),
+		ConsolidationQueue:        c.consolidationQueue,
+		ConsolidationQueueBalance: uint64(c.consolidationQueueBalance),
+	}
+	if c.churnLimits != nil {
+		output.Churn = &churnJSON{
+			Balance:        uint64(c.churnLimits.Balance),
+			ActivationExit: uint64(c.churnLimits.ActivationExit),
+			Consolidation:  uint64(c.churnLimits.Consolidation),
+		}
 	}
 	data, err := json.Marshal(output)
 	if err != nil {
@@ -53,11 +89,40 @@ func (c *command) outputText(_ context.Context) (string, error) {
 	builder := strings.Builder{}
 
 	if c.activationQueue > 0 {
-		builder.WriteString(fmt.Sprintf("Activation queue: %d\n", c.activationQueue))
+		builder.WriteString(fmt.Sprintf("Activation queue: %d (%s)\n", c.activationQueue, ethString(c.activationQueueBalance)))
 	}
 	if c.exitQueue > 0 {
-		builder.WriteString(fmt.Sprintf("Exit queue: %d\n", c.exitQueue))
+		builder.WriteString(fmt.Sprintf("Exit queue: %d (%s), clearing at epoch %d\n", c.exitQueue, ethString(c.exitQueueBalance), c.exitQueueEpoch))
+	}
+
+	if c.churnLimits != nil {
+		if c.depositQueue > 0 {
+			builder.WriteString(fmt.Sprintf("Deposit queue: %d (%s), approximately %d epochs to process\n",
+				c.depositQueue,
+				ethString(c.depositQueueBalance),
+				util.EpochsToProcess(c.depositQueueBalance, c.churnLimits.ActivationExit),
+			))
+		}
+		if c.withdrawalQueue > 0 {
+			builder.WriteString(fmt.Sprintf("Partial withdrawal queue: %d (%s)\n", c.withdrawalQueue, ethString(c.withdrawalQueueBalance)))
+		}
+		if c.consolidationQueue > 0 {
+			builder.WriteString(fmt.Sprintf("Consolidation queue: %d (%s), approximately %d epochs to process\n",
+				c.consolidationQueue,
+				ethString(c.consolidationQueueBalance),
+				util.EpochsToProcess(c.consolidationQueueBalance, c.churnLimits.Consolidation),
+			))
+		}
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("Balance churn: %s per epoch\n", ethString(c.churnLimits.Balance)))
+		}
+		builder.WriteString(fmt.Sprintf("Activation and exit churn: %s per epoch\n", ethString(c.churnLimits.ActivationExit)))
+		builder.WriteString(fmt.Sprintf("Consolidation churn: %s per epoch\n", ethString(c.churnLimits.Consolidation)))
 	}
 
 	return strings.TrimSuffix(builder.String(), "\n"), nil
 }
+
+func ethString(balance phase0.Gwei) string {
+	return string2eth.GWeiToString(uint64(balance), true)
+}
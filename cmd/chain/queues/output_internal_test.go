@@ -0,0 +1,98 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainqueues
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  *command
+		res  string
+	}{
+		{
+			name: "Empty",
+			cmd:  &command{},
+			res:  "",
+		},
+		{
+			name: "PreElectra",
+			cmd: &command{
+				activationQueue:        2,
+				activationQueueBalance: 64000000000,
+				exitQueue:              1,
+				exitQueueBalance:       32000000000,
+				exitQueueEpoch:         1000,
+			},
+			res: "Activation queue: 2 (64 Ether)\nExit queue: 1 (32 Ether), clearing at epoch 1000",
+		},
+		{
+			name: "Electra",
+			cmd: &command{
+				electra:                   true,
+				depositQueue:              3,
+				depositQueueBalance:       600000000000,
+				withdrawalQueue:           1,
+				withdrawalQueueBalance:    1000000000,
+				consolidationQueue:        1,
+				consolidationQueueBalance: 32000000000,
+				churnLimits: &util.ChurnLimits{
+					Balance:        512000000000,
+					ActivationExit: 256000000000,
+					Consolidation:  256000000000,
+				},
+			},
+			res: "Deposit queue: 3 (600 Ether), approximately 3 epochs to process\nPartial withdrawal queue: 1 (1 Ether)\nConsolidation queue: 1 (32 Ether), approximately 1 epochs to process\nActivation and exit churn: 256 Ether per epoch\nConsolidation churn: 256 Ether per epoch",
+		},
+		{
+			name: "ElectraVerbose",
+			cmd: &command{
+				verbose: true,
+				electra: true,
+				churnLimits: &util.ChurnLimits{
+					Balance:        128000000000,
+					ActivationExit: 128000000000,
+				},
+			},
+			res: "Balance churn: 128 Ether per epoch\nActivation and exit churn: 128 Ether per epoch\nConsolidation churn: 0 per epoch",
+		},
+		{
+			name: "JSON",
+			cmd: &command{
+				format:                 util.OutputFormatJSON,
+				activationQueue:        1,
+				activationQueueBalance: 32000000000,
+				churnLimits: &util.ChurnLimits{
+					Balance:        128000000000,
+					ActivationExit: 128000000000,
+				},
+			},
+			res: `{"activation_queue":1,"activation_queue_balance":32000000000,"exit_queue":0,"exit_queue_balance":0,"deposit_queue":0,"deposit_queue_balance":0,"withdrawal_queue":0,"withdrawal_queue_balance":0,"consolidation_queue":0,"consolidation_queue_balance":0,"churn":{"balance":128000000000,"activation_exit":128000000000,"consolidation":0}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.cmd.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright © 2022 Weald Technology Trading.
+// Copyright © 2022, 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -19,11 +19,15 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
 )
 
+// farFutureEpoch is the epoch used for validators that are not exiting.
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
 func (c *command) process(ctx context.Context) error {
 	// Obtain information we need to process.
 	if err := c.setup(ctx); err != nil {
@@ -34,25 +38,106 @@ func (c *command) process(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	stateID := fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch))
 
 	response, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{
-		State: fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch)),
+		State: stateID,
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain validators")
 	}
 
+	effectiveBalances := make(map[phase0.ValidatorIndex]phase0.Gwei, len(response.Data))
+	totalActiveBalance := phase0.Gwei(0)
 	for _, validator := range response.Data {
 		if validator.Validator == nil {
 			continue
 		}
+		effectiveBalances[validator.Index] = validator.Validator.EffectiveBalance
+		if validator.Validator.ActivationEpoch <= epoch && validator.Validator.ExitEpoch > epoch {
+			totalActiveBalance += validator.Validator.EffectiveBalance
+		}
 		if validator.Validator.ActivationEligibilityEpoch <= epoch && validator.Validator.ActivationEpoch > epoch {
 			c.activationQueue++
+			c.activationQueueBalance += validator.Validator.EffectiveBalance
 		}
-		if validator.Validator.ExitEpoch != 0xffffffffffffffff && validator.Validator.ExitEpoch > epoch {
+		if validator.Validator.ExitEpoch != farFutureEpoch && validator.Validator.ExitEpoch > epoch {
 			c.exitQueue++
+			c.exitQueueBalance += validator.Validator.EffectiveBalance
+			if validator.Validator.ExitEpoch > c.exitQueueEpoch {
+				c.exitQueueEpoch = validator.Validator.ExitEpoch
+			}
+		}
+	}
+
+	for _, fork := range c.chainTime.Forks() {
+		if fork.Name == "electra" && fork.Epoch <= epoch {
+			c.electra = true
 		}
 	}
+	if !c.electra {
+		return nil
+	}
+
+	return c.processElectra(ctx, stateID, totalActiveBalance, effectiveBalances)
+}
+
+// processElectra processes the balance-based queues and churn introduced in Electra.
+func (c *command) processElectra(ctx context.Context,
+	stateID string,
+	totalActiveBalance phase0.Gwei,
+	effectiveBalances map[phase0.ValidatorIndex]phase0.Gwei,
+) error {
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	c.churnLimits = util.CalculateChurnLimits(specResponse.Data, totalActiveBalance)
+
+	depositsProvider, isProvider := c.eth2Client.(eth2client.PendingDepositProvider)
+	if !isProvider {
+		return errors.New("connection does not provide pending deposits")
+	}
+	depositsResponse, err := depositsProvider.PendingDeposits(ctx, &api.PendingDepositsOpts{
+		State: stateID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain pending deposits")
+	}
+	for _, deposit := range depositsResponse.Data {
+		c.depositQueue++
+		c.depositQueueBalance += deposit.Amount
+	}
+
+	withdrawalsProvider, isProvider := c.eth2Client.(eth2client.PendingPartialWithdrawalsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide pending partial withdrawals")
+	}
+	withdrawalsResponse, err := withdrawalsProvider.PendingPartialWithdrawals(ctx, &api.PendingPartialWithdrawalsOpts{
+		State: stateID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain pending partial withdrawals")
+	}
+	for _, withdrawal := range withdrawalsResponse.Data {
+		c.withdrawalQueue++
+		c.withdrawalQueueBalance += withdrawal.Amount
+	}
+
+	consolidationsProvider, isProvider := c.eth2Client.(eth2client.PendingConsolidationsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide pending consolidations")
+	}
+	consolidationsResponse, err := consolidationsProvider.PendingConsolidations(ctx, &api.PendingConsolidationsOpts{
+		State: stateID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain pending consolidations")
+	}
+	for _, consolidation := range consolidationsResponse.Data {
+		c.consolidationQueue++
+		c.consolidationQueueBalance += effectiveBalances[consolidation.SourceIndex]
+	}
 
 	return nil
 }
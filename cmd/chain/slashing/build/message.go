@@ -0,0 +1,145 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainslashingbuild
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+const (
+	// signedBeaconBlockHeaderSSZSize is the size of an SSZ-encoded signed beacon block header.
+	signedBeaconBlockHeaderSSZSize = 208
+	// singleAttestationSSZSize is the size of an SSZ-encoded single attestation.
+	singleAttestationSSZSize = 240
+)
+
+// message is a signed message that can form half of a slashing.
+// Exactly one of the fields is populated.
+type message struct {
+	header      *phase0.SignedBeaconBlockHeader
+	attestation *electra.IndexedAttestation
+}
+
+// parseMessage parses a message from its input, which can be JSON, hex-encoded
+// SSZ, or the path to a file containing either of those or raw SSZ.
+func parseMessage(input string) (*message, error) {
+	data := []byte(strings.TrimSpace(input))
+	if !bytes.HasPrefix(data, []byte("{")) && !bytes.HasPrefix(data, []byte("0x")) {
+		// This looks like a file; read it in.
+		fileData, err := os.ReadFile(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read input file")
+		}
+		data = fileData
+		if trimmed := bytes.TrimSpace(fileData); bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("0x")) {
+			data = trimmed
+		}
+	}
+
+	switch {
+	case bytes.HasPrefix(data, []byte("{")):
+		return parseJSONMessage(data)
+	case bytes.HasPrefix(data, []byte("0x")):
+		sszData, err := hex.DecodeString(string(data[2:]))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid hex-encoded SSZ")
+		}
+
+		return parseSSZMessage(sszData)
+	default:
+		return parseSSZMessage(data)
+	}
+}
+
+// parseJSONMessage parses a JSON message, using its fields to decide its type.
+func parseJSONMessage(data []byte) (*message, error) {
+	probe := struct {
+		Message          json.RawMessage `json:"message"`
+		AttestingIndices json.RawMessage `json:"attesting_indices"`
+		AttesterIndex    json.RawMessage `json:"attester_index"`
+	}{}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, errors.Wrap(err, "invalid JSON")
+	}
+
+	switch {
+	case probe.Message != nil:
+		header := &phase0.SignedBeaconBlockHeader{}
+		if err := json.Unmarshal(data, header); err != nil {
+			return nil, errors.Wrap(err, "invalid signed beacon block header")
+		}
+
+		return &message{header: header}, nil
+	case probe.AttestingIndices != nil:
+		attestation := &electra.IndexedAttestation{}
+		if err := json.Unmarshal(data, attestation); err != nil {
+			return nil, errors.Wrap(err, "invalid indexed attestation")
+		}
+
+		return &message{attestation: attestation}, nil
+	case probe.AttesterIndex != nil:
+		attestation := &electra.SingleAttestation{}
+		if err := json.Unmarshal(data, attestation); err != nil {
+			return nil, errors.Wrap(err, "invalid single attestation")
+		}
+
+		return &message{attestation: indexSingleAttestation(attestation)}, nil
+	default:
+		return nil, errors.New("data is not a signed beacon block header, indexed attestation or single attestation")
+	}
+}
+
+// parseSSZMessage parses an SSZ message, using its size to decide its type.
+func parseSSZMessage(data []byte) (*message, error) {
+	switch len(data) {
+	case signedBeaconBlockHeaderSSZSize:
+		header := &phase0.SignedBeaconBlockHeader{}
+		if err := header.UnmarshalSSZ(data); err != nil {
+			return nil, errors.Wrap(err, "invalid signed beacon block header")
+		}
+
+		return &message{header: header}, nil
+	case singleAttestationSSZSize:
+		attestation := &electra.SingleAttestation{}
+		if err := attestation.UnmarshalSSZ(data); err != nil {
+			return nil, errors.Wrap(err, "invalid single attestation")
+		}
+
+		return &message{attestation: indexSingleAttestation(attestation)}, nil
+	default:
+		attestation := &electra.IndexedAttestation{}
+		if err := attestation.UnmarshalSSZ(data); err != nil {
+			return nil, errors.Wrap(err, "invalid indexed attestation")
+		}
+
+		return &message{attestation: attestation}, nil
+	}
+}
+
+// indexSingleAttestation turns a single attestation in to an indexed attestation.
+func indexSingleAttestation(attestation *electra.SingleAttestation) *electra.IndexedAttestation {
+	return &electra.IndexedAttestation{
+		AttestingIndices: []uint64{uint64(attestation.AttesterIndex)},
+		Data:             attestation.Data,
+		Signature:        attestation.Signature,
+	}
+}
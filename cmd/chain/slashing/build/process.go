@@ -0,0 +1,465 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainslashingbuild
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// maxPhase0AttestingIndices is the maximum number of attesting indices in a
+// pre-Electra indexed attestation (MAX_VALIDATORS_PER_COMMITTEE).
+const maxPhase0AttestingIndices = 2048
+
+func (c *command) process(ctx context.Context) error {
+	var err error
+	c.firstMessage, err = parseMessage(c.first)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse first message")
+	}
+	c.secondMessage, err = parseMessage(c.second)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse second message")
+	}
+
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	switch {
+	case c.firstMessage.header != nil && c.secondMessage.header != nil:
+		if err := c.buildProposerSlashing(ctx); err != nil {
+			return err
+		}
+	case c.firstMessage.attestation != nil && c.secondMessage.attestation != nil:
+		if err := c.buildAttesterSlashing(ctx); err != nil {
+			return err
+		}
+	default:
+		return errors.New("messages must both be signed beacon block headers or both be attestations")
+	}
+
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "Slashable validators: %v\n", c.slashableIndices)
+	}
+
+	if c.broadcast {
+		return c.broadcastSlashing(ctx)
+	}
+
+	return nil
+}
+
+// buildProposerSlashing builds a proposer slashing from two signed beacon block headers.
+func (c *command) buildProposerSlashing(ctx context.Context) error {
+	header1 := c.firstMessage.header
+	header2 := c.secondMessage.header
+
+	if header1.Message.Slot != header2.Message.Slot {
+		return fmt.Errorf("headers are for different slots (%d and %d)", header1.Message.Slot, header2.Message.Slot)
+	}
+	if header1.Message.ProposerIndex != header2.Message.ProposerIndex {
+		return fmt.Errorf("headers are from different proposers (%d and %d)", header1.Message.ProposerIndex, header2.Message.ProposerIndex)
+	}
+	root1, err := header1.Message.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain root of first header")
+	}
+	root2, err := header2.Message.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain root of second header")
+	}
+	if root1 == root2 {
+		return errors.New("headers are identical")
+	}
+
+	validators, err := c.obtainValidators(ctx, []phase0.ValidatorIndex{header1.Message.ProposerIndex})
+	if err != nil {
+		return err
+	}
+
+	domain, err := c.domainProvider.Domain(ctx,
+		phase0.DomainType(e2types.DomainBeaconProposer),
+		phase0.Epoch(uint64(header1.Message.Slot)/c.slotsPerEpoch),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain proposer domain")
+	}
+	if err := verifySignature(root1, domain, header1.Signature, validators); err != nil {
+		return errors.Wrap(err, "first header")
+	}
+	if err := verifySignature(root2, domain, header2.Signature, validators); err != nil {
+		return errors.Wrap(err, "second header")
+	}
+
+	if !isSlashable(validators[0]) {
+		return fmt.Errorf("validator %d is not slashable", header1.Message.ProposerIndex)
+	}
+	c.slashableIndices = []phase0.ValidatorIndex{header1.Message.ProposerIndex}
+
+	c.proposerSlashing = &phase0.ProposerSlashing{
+		SignedHeader1: header1,
+		SignedHeader2: header2,
+	}
+
+	return nil
+}
+
+// buildAttesterSlashing builds an attester slashing from two indexed attestations.
+func (c *command) buildAttesterSlashing(ctx context.Context) error {
+	attestation1 := c.firstMessage.attestation
+	attestation2 := c.secondMessage.attestation
+
+	for i, attestation := range []*electra.IndexedAttestation{attestation1, attestation2} {
+		if err := checkIndexedAttestation(attestation); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("attestation %d is invalid", i+1))
+		}
+	}
+
+	slashable, err := isSlashableAttestationData(attestation1.Data, attestation2.Data)
+	if err != nil {
+		return err
+	}
+	if !slashable {
+		// The first attestation must be the surrounding attestation, so try them the other way round.
+		slashable, err = isSlashableAttestationData(attestation2.Data, attestation1.Data)
+		if err != nil {
+			return err
+		}
+		if !slashable {
+			return errors.New("attestations are neither a double vote nor a surround vote")
+		}
+		attestation1, attestation2 = attestation2, attestation1
+	}
+
+	commonIndices := intersection(attestation1.AttestingIndices, attestation2.AttestingIndices)
+	if len(commonIndices) == 0 {
+		return errors.New("attestations have no attesting validators in common")
+	}
+
+	for i, attestation := range []*electra.IndexedAttestation{attestation1, attestation2} {
+		if err := c.verifyAttestation(ctx, attestation); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("attestation %d", i+1))
+		}
+	}
+
+	validators, err := c.obtainValidators(ctx, commonIndices)
+	if err != nil {
+		return err
+	}
+	c.slashableIndices = make([]phase0.ValidatorIndex, 0, len(validators))
+	for _, validator := range validators {
+		if isSlashable(validator) {
+			c.slashableIndices = append(c.slashableIndices, validator.Index)
+		}
+	}
+	if len(c.slashableIndices) == 0 {
+		return errors.New("none of the validators in common are slashable")
+	}
+
+	c.attesterSlashing = &electra.AttesterSlashing{
+		Attestation1: attestation1,
+		Attestation2: attestation2,
+	}
+
+	return nil
+}
+
+// verifyAttestation verifies the aggregate signature of an indexed attestation.
+func (c *command) verifyAttestation(ctx context.Context, attestation *electra.IndexedAttestation) error {
+	indices := make([]phase0.ValidatorIndex, len(attestation.AttestingIndices))
+	for i, index := range attestation.AttestingIndices {
+		indices[i] = phase0.ValidatorIndex(index)
+	}
+	validators, err := c.obtainValidators(ctx, indices)
+	if err != nil {
+		return err
+	}
+
+	domain, err := c.domainProvider.Domain(ctx,
+		phase0.DomainType(e2types.DomainBeaconAttester),
+		attestation.Data.Target.Epoch,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain attester domain")
+	}
+
+	root, err := attestation.Data.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain root of attestation data")
+	}
+
+	return verifySignature(root, domain, attestation.Signature, validators)
+}
+
+// obtainValidators obtains the validators with the given indices, in the same order.
+func (c *command) obtainValidators(ctx context.Context, indices []phase0.ValidatorIndex) ([]*apiv1.Validator, error) {
+	response, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{
+		State:   "head",
+		Indices: indices,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validators")
+	}
+
+	validators := make([]*apiv1.Validator, len(indices))
+	for i, index := range indices {
+		validator, exists := response.Data[index]
+		if !exists {
+			return nil, util.ErrorWithCode(fmt.Errorf("validator %d not known", index),
+				util.ErrorCodeValidatorNotFound,
+				map[string]any{"index": index},
+			)
+		}
+		validators[i] = validator
+	}
+
+	return validators, nil
+}
+
+// broadcastSlashing broadcasts the slashing to the beacon node.
+func (c *command) broadcastSlashing(ctx context.Context) error {
+	if c.proposerSlashing != nil {
+		submitter, isSubmitter := c.eth2Client.(eth2client.ProposalSlashingSubmitter)
+		if !isSubmitter {
+			return errors.New("connection does not support submitting proposer slashings")
+		}
+		if err := submitter.SubmitProposalSlashing(ctx, c.proposerSlashing); err != nil {
+			return errors.Wrap(err, "failed to submit proposer slashing")
+		}
+
+		return nil
+	}
+
+	// Electra attester slashings can only be submitted through the versioned endpoint.
+	if c.electra {
+		if err := util.SubmitBeaconNodePool(ctx, c.eth2Client, c.timeout, "/eth/v2/beacon/pool/attester_slashings", c.forkName, c.attesterSlashing); err != nil {
+			return errors.Wrap(err, "failed to submit attester slashing")
+		}
+
+		return nil
+	}
+
+	submitter, isSubmitter := c.eth2Client.(eth2client.AttesterSlashingSubmitter)
+	if !isSubmitter {
+		return errors.New("connection does not support submitting attester slashings")
+	}
+	attesterSlashing, err := phase0AttesterSlashing(c.attesterSlashing)
+	if err != nil {
+		return err
+	}
+	if err := submitter.SubmitAttesterSlashing(ctx, attesterSlashing); err != nil {
+		return errors.Wrap(err, "failed to submit attester slashing")
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.domainProvider, isProvider = c.eth2Client.(eth2client.DomainProvider)
+	if !isProvider {
+		return errors.New("connection does not provide domain information")
+	}
+
+	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	tmp, exists := specResponse.Data["SLOTS_PER_EPOCH"]
+	if !exists {
+		return errors.New("spec did not contain SLOTS_PER_EPOCH")
+	}
+	var good bool
+	c.slotsPerEpoch, good = tmp.(uint64)
+	if !good {
+		return errors.New("SLOTS_PER_EPOCH value invalid")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	// Attester slashings change format with Electra.
+	for _, fork := range c.chainTime.Forks() {
+		if fork.Name == "electra" && fork.Epoch <= c.chainTime.CurrentEpoch() {
+			c.electra = true
+		}
+	}
+	c.forkName = c.chainTime.ForkAtEpoch(c.chainTime.CurrentEpoch()).Name
+
+	return nil
+}
+
+// checkIndexedAttestation checks that the indices of an indexed attestation are present, sorted and unique.
+func checkIndexedAttestation(attestation *electra.IndexedAttestation) error {
+	if attestation.Data == nil || attestation.Data.Source == nil || attestation.Data.Target == nil {
+		return errors.New("attestation data missing")
+	}
+	if len(attestation.AttestingIndices) == 0 {
+		return errors.New("no attesting indices")
+	}
+	for i := 1; i < len(attestation.AttestingIndices); i++ {
+		if attestation.AttestingIndices[i] <= attestation.AttestingIndices[i-1] {
+			return errors.New("attesting indices are not sorted and unique")
+		}
+	}
+
+	return nil
+}
+
+// isSlashableAttestationData returns true if the two pieces of attestation data
+// are a double vote, or if the first surrounds the second.
+func isSlashableAttestationData(data1 *phase0.AttestationData, data2 *phase0.AttestationData) (bool, error) {
+	root1, err := data1.HashTreeRoot()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain root of attestation data")
+	}
+	root2, err := data2.HashTreeRoot()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain root of attestation data")
+	}
+
+	doubleVote := root1 != root2 && data1.Target.Epoch == data2.Target.Epoch
+	surroundVote := data1.Source.Epoch < data2.Source.Epoch && data2.Target.Epoch < data1.Target.Epoch
+
+	return doubleVote || surroundVote, nil
+}
+
+// isSlashable returns true if the validator can be slashed.
+func isSlashable(validator *apiv1.Validator) bool {
+	if validator.Validator.Slashed {
+		return false
+	}
+
+	return validator.Status.IsActive() || validator.Status == apiv1.ValidatorStateExitedUnslashed
+}
+
+// intersection returns the sorted indices present in both lists.
+func intersection(indices1 []uint64, indices2 []uint64) []phase0.ValidatorIndex {
+	present := make(map[uint64]bool, len(indices1))
+	for _, index := range indices1 {
+		present[index] = true
+	}
+
+	res := make([]phase0.ValidatorIndex, 0)
+	for _, index := range indices2 {
+		if present[index] {
+			res = append(res, phase0.ValidatorIndex(index))
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+
+	return res
+}
+
+// verifySignature verifies the signature of a root by the aggregate of the given validators.
+func verifySignature(root phase0.Root,
+	domain phase0.Domain,
+	signature phase0.BLSSignature,
+	validators []*apiv1.Validator,
+) error {
+	sig, err := e2types.BLSSignatureFromBytes(signature[:])
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+
+	var aggregatePubKey *e2types.BLSPublicKey
+	for _, validator := range validators {
+		pubKey, err := e2types.BLSPublicKeyFromBytes(validator.Validator.PublicKey[:])
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("invalid public key for validator %d", validator.Index))
+		}
+		if aggregatePubKey == nil {
+			aggregatePubKey = pubKey
+		} else {
+			aggregatePubKey.Aggregate(pubKey)
+		}
+	}
+
+	container := &phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	}
+	signingRoot, err := container.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain signing root")
+	}
+
+	if !sig.Verify(signingRoot[:], aggregatePubKey) {
+		return errors.New("signature is invalid")
+	}
+
+	return nil
+}
+
+// phase0AttesterSlashing converts an attester slashing to its phase0 form for
+// submission to pre-Electra beacon nodes.
+func phase0AttesterSlashing(slashing *electra.AttesterSlashing) (*phase0.AttesterSlashing, error) {
+	for _, attestation := range []*electra.IndexedAttestation{slashing.Attestation1, slashing.Attestation2} {
+		if len(attestation.AttestingIndices) > maxPhase0AttestingIndices {
+			return nil, fmt.Errorf("attestation has %d attesting indices; pre-Electra slashings allow at most %d", len(attestation.AttestingIndices), maxPhase0AttestingIndices)
+		}
+	}
+
+	return &phase0.AttesterSlashing{
+		Attestation1: &phase0.IndexedAttestation{
+			AttestingIndices: slashing.Attestation1.AttestingIndices,
+			Data:             slashing.Attestation1.Data,
+			Signature:        slashing.Attestation1.Signature,
+		},
+		Attestation2: &phase0.IndexedAttestation{
+			AttestingIndices: slashing.Attestation2.AttestingIndices,
+			Data:             slashing.Attestation2.Data,
+			Signature:        slashing.Attestation2.Signature,
+		},
+	}, nil
+}
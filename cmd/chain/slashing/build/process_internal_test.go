@@ -0,0 +1,498 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainslashingbuild
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/mock"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// testChain is a mock chain with validators whose keys are known.
+type testChain struct {
+	client *mock.Service
+	keys   map[phase0.ValidatorIndex]*e2types.BLSPrivateKey
+}
+
+func newTestChain(t *testing.T, slashed phase0.ValidatorIndex) *testChain {
+	t.Helper()
+	require.NoError(t, e2types.InitBLS())
+
+	client, err := mock.New(context.Background())
+	require.NoError(t, err)
+
+	chain := &testChain{
+		client: client,
+		keys:   make(map[phase0.ValidatorIndex]*e2types.BLSPrivateKey),
+	}
+	validators := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+	for i := range phase0.ValidatorIndex(4) {
+		key, err := e2types.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+		chain.keys[i] = key
+		validators[i] = &apiv1.Validator{
+			Index:  i,
+			Status: apiv1.ValidatorStateActiveOngoing,
+			Validator: &phase0.Validator{
+				PublicKey: phase0.BLSPubKey(key.PublicKey().Marshal()),
+				Slashed:   i == slashed,
+			},
+		}
+	}
+	client.ValidatorsFunc = func(_ context.Context, opts *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator], error) {
+		data := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+		for _, index := range opts.Indices {
+			if validator, exists := validators[index]; exists {
+				data[index] = validator
+			}
+		}
+
+		return &api.Response[map[phase0.ValidatorIndex]*apiv1.Validator]{Data: data}, nil
+	}
+
+	return chain
+}
+
+func (tc *testChain) command(first *message, second *message) *command {
+	return &command{
+		quiet:              true,
+		eth2Client:         tc.client,
+		validatorsProvider: tc.client,
+		domainProvider:     tc.client,
+		slotsPerEpoch:      32,
+		firstMessage:       first,
+		secondMessage:      second,
+	}
+}
+
+func (tc *testChain) sign(t *testing.T,
+	root phase0.Root,
+	domainType e2types.DomainType,
+	epoch phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+) phase0.BLSSignature {
+	t.Helper()
+
+	domain, err := tc.client.Domain(context.Background(), phase0.DomainType(domainType), epoch)
+	require.NoError(t, err)
+	signingRoot, err := (&phase0.SigningData{ObjectRoot: root, Domain: domain}).HashTreeRoot()
+	require.NoError(t, err)
+
+	signatures := make([]e2types.Signature, 0, len(indices))
+	for _, index := range indices {
+		signatures = append(signatures, tc.keys[index].Sign(signingRoot[:]))
+	}
+
+	return phase0.BLSSignature(e2types.AggregateSignatures(signatures).Marshal())
+}
+
+func (tc *testChain) header(t *testing.T, slot phase0.Slot, proposer phase0.ValidatorIndex, signer phase0.ValidatorIndex, bodyRoot byte) *message {
+	t.Helper()
+
+	header := &phase0.BeaconBlockHeader{
+		Slot:          slot,
+		ProposerIndex: proposer,
+		BodyRoot:      phase0.Root{bodyRoot},
+	}
+	root, err := header.HashTreeRoot()
+	require.NoError(t, err)
+
+	return &message{
+		header: &phase0.SignedBeaconBlockHeader{
+			Message:   header,
+			Signature: tc.sign(t, root, e2types.DomainBeaconProposer, phase0.Epoch(slot/32), []phase0.ValidatorIndex{signer}),
+		},
+	}
+}
+
+func (tc *testChain) attestation(t *testing.T, source phase0.Epoch, target phase0.Epoch, blockRoot byte, indices []phase0.ValidatorIndex) *message {
+	t.Helper()
+
+	data := &phase0.AttestationData{
+		Slot:            phase0.Slot(target * 32),
+		BeaconBlockRoot: phase0.Root{blockRoot},
+		Source:          &phase0.Checkpoint{Epoch: source},
+		Target:          &phase0.Checkpoint{Epoch: target},
+	}
+	root, err := data.HashTreeRoot()
+	require.NoError(t, err)
+
+	attestingIndices := make([]uint64, len(indices))
+	for i, index := range indices {
+		attestingIndices[i] = uint64(index)
+	}
+
+	return &message{
+		attestation: &electra.IndexedAttestation{
+			AttestingIndices: attestingIndices,
+			Data:             data,
+			Signature:        tc.sign(t, root, e2types.DomainBeaconAttester, target, indices),
+		},
+	}
+}
+
+func TestBuildProposerSlashing(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 3)
+
+	tests := []struct {
+		name   string
+		first  *message
+		second *message
+		err    string
+	}{
+		{
+			name:   "DifferentSlots",
+			first:  chain.header(t, 2000, 1, 1, 0x01),
+			second: chain.header(t, 2001, 1, 1, 0x02),
+			err:    "headers are for different slots (2000 and 2001)",
+		},
+		{
+			name:   "DifferentProposers",
+			first:  chain.header(t, 2000, 1, 1, 0x01),
+			second: chain.header(t, 2000, 2, 2, 0x02),
+			err:    "headers are from different proposers (1 and 2)",
+		},
+		{
+			name:   "Identical",
+			first:  chain.header(t, 2000, 1, 1, 0x01),
+			second: chain.header(t, 2000, 1, 1, 0x01),
+			err:    "headers are identical",
+		},
+		{
+			name:   "BadSignature",
+			first:  chain.header(t, 2000, 1, 1, 0x01),
+			second: chain.header(t, 2000, 1, 2, 0x02),
+			err:    "second header: signature is invalid",
+		},
+		{
+			name:   "UnknownValidator",
+			first:  chain.header(t, 2000, 9, 1, 0x01),
+			second: chain.header(t, 2000, 9, 1, 0x02),
+			err:    "validator 9 not known",
+		},
+		{
+			name:   "AlreadySlashed",
+			first:  chain.header(t, 2000, 3, 3, 0x01),
+			second: chain.header(t, 2000, 3, 3, 0x02),
+			err:    "validator 3 is not slashable",
+		},
+		{
+			// Slot 40000 is after the mock chain's fork at epoch 1024, so this requires the correct fork domain.
+			name:   "Good",
+			first:  chain.header(t, 40000, 1, 1, 0x01),
+			second: chain.header(t, 40000, 1, 1, 0x02),
+		},
+		{
+			name:   "GoodPreviousFork",
+			first:  chain.header(t, 20, 1, 1, 0x01),
+			second: chain.header(t, 20, 1, 1, 0x02),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := chain.command(test.first, test.second)
+			err := c.buildProposerSlashing(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.first.header, c.proposerSlashing.SignedHeader1)
+				require.Equal(t, test.second.header, c.proposerSlashing.SignedHeader2)
+				require.Equal(t, []phase0.ValidatorIndex{1}, c.slashableIndices)
+			}
+		})
+	}
+}
+
+func TestBuildAttesterSlashing(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 3)
+
+	tests := []struct {
+		name      string
+		first     *message
+		second    *message
+		err       string
+		swapped   bool
+		slashable []phase0.ValidatorIndex
+	}{
+		{
+			name:   "NotSlashable",
+			first:  chain.attestation(t, 10, 11, 0x01, []phase0.ValidatorIndex{0, 1}),
+			second: chain.attestation(t, 11, 12, 0x01, []phase0.ValidatorIndex{0, 1}),
+			err:    "attestations are neither a double vote nor a surround vote",
+		},
+		{
+			name:   "Identical",
+			first:  chain.attestation(t, 10, 11, 0x01, []phase0.ValidatorIndex{0, 1}),
+			second: chain.attestation(t, 10, 11, 0x01, []phase0.ValidatorIndex{0, 1}),
+			err:    "attestations are neither a double vote nor a surround vote",
+		},
+		{
+			name:   "Unsorted",
+			first:  chain.attestation(t, 10, 11, 0x01, []phase0.ValidatorIndex{1, 0}),
+			second: chain.attestation(t, 10, 11, 0x02, []phase0.ValidatorIndex{0, 1}),
+			err:    "attestation 1 is invalid: attesting indices are not sorted and unique",
+		},
+		{
+			name:   "NoCommonValidators",
+			first:  chain.attestation(t, 10, 11, 0x01, []phase0.ValidatorIndex{0}),
+			second: chain.attestation(t, 10, 11, 0x02, []phase0.ValidatorIndex{1}),
+			err:    "attestations have no attesting validators in common",
+		},
+		{
+			name:  "BadSignature",
+			first: chain.attestation(t, 10, 11, 0x01, []phase0.ValidatorIndex{0, 1}),
+			second: func() *message {
+				msg := chain.attestation(t, 10, 11, 0x02, []phase0.ValidatorIndex{0, 1})
+				msg.attestation.AttestingIndices = []uint64{0, 2}
+
+				return msg
+			}(),
+			err: "attestation 2: signature is invalid",
+		},
+		{
+			name:   "AlreadySlashed",
+			first:  chain.attestation(t, 10, 11, 0x01, []phase0.ValidatorIndex{3}),
+			second: chain.attestation(t, 10, 11, 0x02, []phase0.ValidatorIndex{3}),
+			err:    "none of the validators in common are slashable",
+		},
+		{
+			name:      "DoubleVote",
+			first:     chain.attestation(t, 1000, 1100, 0x01, []phase0.ValidatorIndex{0, 1, 3}),
+			second:    chain.attestation(t, 1000, 1100, 0x02, []phase0.ValidatorIndex{1, 2, 3}),
+			slashable: []phase0.ValidatorIndex{1},
+		},
+		{
+			name:      "SurroundVote",
+			first:     chain.attestation(t, 10, 13, 0x01, []phase0.ValidatorIndex{0, 1, 2}),
+			second:    chain.attestation(t, 11, 12, 0x01, []phase0.ValidatorIndex{0, 2}),
+			slashable: []phase0.ValidatorIndex{0, 2},
+		},
+		{
+			name:      "SurroundedVote",
+			first:     chain.attestation(t, 11, 12, 0x01, []phase0.ValidatorIndex{0, 2}),
+			second:    chain.attestation(t, 10, 13, 0x01, []phase0.ValidatorIndex{0, 1, 2}),
+			swapped:   true,
+			slashable: []phase0.ValidatorIndex{0, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := chain.command(test.first, test.second)
+			err := c.buildAttesterSlashing(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				if test.swapped {
+					require.Equal(t, test.second.attestation, c.attesterSlashing.Attestation1)
+					require.Equal(t, test.first.attestation, c.attesterSlashing.Attestation2)
+				} else {
+					require.Equal(t, test.first.attestation, c.attesterSlashing.Attestation1)
+					require.Equal(t, test.second.attestation, c.attesterSlashing.Attestation2)
+				}
+				require.Equal(t, test.slashable, c.slashableIndices)
+			}
+		})
+	}
+}
+
+func TestBroadcast(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 3)
+
+	c := chain.command(chain.header(t, 20, 1, 1, 0x01), chain.header(t, 20, 1, 1, 0x02))
+	require.NoError(t, c.buildProposerSlashing(ctx))
+	require.NoError(t, c.broadcastSlashing(ctx))
+
+	c = chain.command(chain.attestation(t, 10, 11, 0x01, []phase0.ValidatorIndex{0}), chain.attestation(t, 10, 11, 0x02, []phase0.ValidatorIndex{0}))
+	require.NoError(t, c.buildAttesterSlashing(ctx))
+	require.NoError(t, c.broadcastSlashing(ctx))
+}
+
+func TestParseMessage(t *testing.T) {
+	chain := newTestChain(t, 3)
+	header := chain.header(t, 20, 1, 1, 0x01).header
+	attestation := chain.attestation(t, 10, 11, 0x01, []phase0.ValidatorIndex{0, 1}).attestation
+	singleAttestation := &electra.SingleAttestation{
+		CommitteeIndex: 2,
+		AttesterIndex:  1,
+		Data:           attestation.Data,
+		Signature:      attestation.Signature,
+	}
+
+	headerJSON, err := json.Marshal(header)
+	require.NoError(t, err)
+	headerSSZ, err := header.MarshalSSZ()
+	require.NoError(t, err)
+	attestationJSON, err := json.Marshal(attestation)
+	require.NoError(t, err)
+	attestationSSZ, err := attestation.MarshalSSZ()
+	require.NoError(t, err)
+	singleAttestationJSON, err := json.Marshal(singleAttestation)
+	require.NoError(t, err)
+	singleAttestationSSZ, err := singleAttestation.MarshalSSZ()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	headerJSONFile := filepath.Join(dir, "header.json")
+	require.NoError(t, os.WriteFile(headerJSONFile, append(headerJSON, '\n'), 0o600))
+	attestationSSZFile := filepath.Join(dir, "attestation.ssz")
+	require.NoError(t, os.WriteFile(attestationSSZFile, attestationSSZ, 0o600))
+	attestationHexFile := filepath.Join(dir, "attestation.hex")
+	require.NoError(t, os.WriteFile(attestationHexFile, []byte(fmt.Sprintf("%#x\n", attestationSSZ)), 0o600))
+
+	indexedSingleAttestation := &electra.IndexedAttestation{
+		AttestingIndices: []uint64{1},
+		Data:             attestation.Data,
+		Signature:        attestation.Signature,
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		res   *message
+		err   string
+	}{
+		{
+			name:  "HeaderJSON",
+			input: string(headerJSON),
+			res:   &message{header: header},
+		},
+		{
+			name:  "HeaderHex",
+			input: fmt.Sprintf("%#x", headerSSZ),
+			res:   &message{header: header},
+		},
+		{
+			name:  "HeaderJSONFile",
+			input: headerJSONFile,
+			res:   &message{header: header},
+		},
+		{
+			name:  "AttestationJSON",
+			input: string(attestationJSON),
+			res:   &message{attestation: attestation},
+		},
+		{
+			name:  "AttestationSSZFile",
+			input: attestationSSZFile,
+			res:   &message{attestation: attestation},
+		},
+		{
+			name:  "AttestationHexFile",
+			input: attestationHexFile,
+			res:   &message{attestation: attestation},
+		},
+		{
+			name:  "SingleAttestationJSON",
+			input: string(singleAttestationJSON),
+			res:   &message{attestation: indexedSingleAttestation},
+		},
+		{
+			name:  "SingleAttestationHex",
+			input: fmt.Sprintf("%#x", singleAttestationSSZ),
+			res:   &message{attestation: indexedSingleAttestation},
+		},
+		{
+			name:  "UnknownJSON",
+			input: `{"slot":"1"}`,
+			err:   "data is not a signed beacon block header, indexed attestation or single attestation",
+		},
+		{
+			name:  "BadHex",
+			input: "0xzz",
+			err:   "invalid hex-encoded SSZ: encoding/hex: invalid byte: U+007A 'z'",
+		},
+		{
+			name:  "MissingFile",
+			input: filepath.Join(dir, "missing.json"),
+			err:   fmt.Sprintf("failed to read input file: open %s: no such file or directory", filepath.Join(dir, "missing.json")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseMessage(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestPhase0AttesterSlashing(t *testing.T) {
+	indices := func(count int) []uint64 {
+		res := make([]uint64, count)
+		for i := range res {
+			res[i] = uint64(i)
+		}
+
+		return res
+	}
+
+	tests := []struct {
+		name    string
+		indices int
+		err     string
+	}{
+		{
+			name:    "Single",
+			indices: 1,
+		},
+		{
+			name:    "Max",
+			indices: 2048,
+		},
+		{
+			name:    "TooMany",
+			indices: 2049,
+			err:     "attestation has 2049 attesting indices; pre-Electra slashings allow at most 2048",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			slashing := &electra.AttesterSlashing{
+				Attestation1: &electra.IndexedAttestation{AttestingIndices: indices(1)},
+				Attestation2: &electra.IndexedAttestation{AttestingIndices: indices(test.indices)},
+			}
+			res, err := phase0AttesterSlashing(slashing)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res.Attestation2.AttestingIndices, test.indices)
+		})
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright © 2021 Weald Technology Trading
+// Copyright © 2021, 2025 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -19,6 +19,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type dataIn struct {
@@ -31,6 +32,7 @@ type dataIn struct {
 	// Input
 	connection               string
 	allowInsecureConnections bool
+	networkConfig            *util.NetworkConfig
 	timestamp                string
 	slot                     string
 	epoch                    string
@@ -74,5 +76,11 @@ func input(_ context.Context) (*dataIn, error) {
 	data.connection = viper.GetString("connection")
 	data.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
 
+	var err error
+	data.networkConfig, err = util.NetworkConfigFromConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	return data, nil
 }
@@ -1,4 +1,4 @@
-// Copyright © 2021 Weald Technology Trading
+// Copyright © 2021, 2025 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -30,14 +30,21 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("no data")
 	}
 
-	eth2Client, err := util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
-		Address:       data.connection,
-		Timeout:       data.timeout,
-		AllowInsecure: data.allowInsecureConnections,
-		LogFallback:   !data.quiet,
-	})
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to connect to Ethereum 2 beacon node")
+	var eth2Client eth2client.Service
+	if data.networkConfig != nil {
+		// Use the network configuration in place of a beacon node.
+		eth2Client = data.networkConfig
+	} else {
+		var err error
+		eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+			Address:       data.connection,
+			Timeout:       data.timeout,
+			AllowInsecure: data.allowInsecureConnections,
+			LogFallback:   !data.quiet,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to connect to Ethereum 2 beacon node")
+		}
 	}
 
 	chainTime, err := standardchaintime.New(ctx,
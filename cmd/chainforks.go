@@ -0,0 +1,50 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainforks "github.com/wealdtech/ethdo/cmd/chain/forks"
+)
+
+var chainForksCmd = &cobra.Command{
+	Use:   "forks",
+	Short: "Show the fork schedule for a chain",
+	Long: `Show the fork schedule for a chain, including blob-parameter-only forks.  For example:
+
+    ethdo chain forks
+
+In quiet mode this will return 0 if the fork schedule can be obtained, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chainforks.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainForksCmd)
+	chainFlags(chainForksCmd)
+}
@@ -35,7 +35,7 @@ var chainInfoCmd = &cobra.Command{
 
     ethdo chain info
 
-In quiet mode this will return 0 if the chain information can be obtained, otherwise 1.`,
+In quiet mode this will return 0 if the chain information can be obtained, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx := context.Background()
 
@@ -1,4 +1,4 @@
-// Copyright © 2022 Weald Technology Trading
+// Copyright © 2022, 2025 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -24,11 +24,13 @@ import (
 var chainQueuesCmd = &cobra.Command{
 	Use:   "queues",
 	Short: "Show chain queues",
-	Long: `Show beacon chain activation and exit queues.  For example:
+	Long: `Show beacon chain queues.  For example:
 
     ethdo chain queues
 
-In quiet mode this will return 0 if the entry and exit queues are 0, otherwise 1.`,
+From the Electra fork onwards this includes the deposit, partial withdrawal and consolidation queues, and the current churn limits.
+
+In quiet mode this will return 0 if the entry and exit queues are 0, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := chainqueues.Run(cmd)
 		if err != nil {
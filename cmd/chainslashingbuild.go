@@ -0,0 +1,65 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainslashingbuild "github.com/wealdtech/ethdo/cmd/chain/slashing/build"
+)
+
+var chainSlashingBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build a slashing from two conflicting signed messages",
+	Long: `Build a proposer or attester slashing from two conflicting signed messages.  For example:
+
+    ethdo chain slashing build --first=header1.json --second=header2.json
+
+Each message can be a signed beacon block header, an indexed attestation or a single attestation, supplied as JSON, as hex-encoded SSZ, or as the path to a file containing either of those or raw SSZ.  The messages are checked to confirm that they are a double proposal, a double vote or a surround vote, and their signatures are verified against the chain.  The resultant slashing is output as JSON, and is broadcast if --broadcast is supplied.
+
+In quiet mode this will return 0 if the messages form a valid slashing, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chainslashingbuild.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainSlashingCmd.AddCommand(chainSlashingBuildCmd)
+	chainSlashingFlags(chainSlashingBuildCmd)
+	chainSlashingBuildCmd.Flags().String("first", "", "The first signed message, as JSON, hex-encoded SSZ or a path to a file")
+	chainSlashingBuildCmd.Flags().String("second", "", "The second signed message, as JSON, hex-encoded SSZ or a path to a file")
+	chainSlashingBuildCmd.Flags().Bool("broadcast", false, "Broadcast the slashing to the beacon node")
+}
+
+func chainSlashingBuildBindings(cmd *cobra.Command) {
+	chainSlashingBindings(cmd)
+	if err := viper.BindPFlag("first", cmd.Flags().Lookup("first")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("second", cmd.Flags().Lookup("second")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("broadcast", cmd.Flags().Lookup("broadcast")); err != nil {
+		panic(err)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/util"
@@ -36,7 +37,7 @@ var chainSpecCmd = &cobra.Command{
 
     ethdo chain spec
 
-In quiet mode this will return 0 if the chain specification can be obtained, otherwise 1.`,
+In quiet mode this will return 0 if the chain specification can be obtained, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx := context.Background()
 
@@ -73,10 +74,21 @@ In quiet mode this will return 0 if the chain specification can be obtained, oth
 			}
 		}
 
-		if viper.GetBool("json") {
-			data, err := json.Marshal(specResponse.Data)
-			errCheck(err, "Failed to marshal JSON")
-			fmt.Printf("%s\n", string(data))
+		if util.OutputFormat() != util.OutputFormatText || viper.GetString("template") != "" {
+			res, err := util.FormatOutput(ctx, &util.FormatOpts{
+				Format:   util.OutputFormat(),
+				Template: viper.GetString("template"),
+				JSON: func(_ context.Context) (string, error) {
+					data, err := json.Marshal(specResponse.Data)
+					if err != nil {
+						return "", errors.Wrap(err, "failed to marshal JSON")
+					}
+
+					return string(data), nil
+				},
+			})
+			errCheck(err, "Failed to generate output")
+			fmt.Printf("%s\n", res)
 		} else {
 			keys := make([]string, 0, len(specResponse.Data))
 			for k := range specResponse.Data {
@@ -1,4 +1,4 @@
-// Copyright © 2020, 2021 Weald Technology Trading.
+// Copyright © 2020, 2021, 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -39,7 +39,7 @@ var chainStatusCmd = &cobra.Command{
 
     ethdo chain status
 
-In quiet mode this will return 0 if the chain status can be obtained, otherwise 1.`,
+In quiet mode this will return 0 if the chain status can be obtained, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx := context.Background()
 
@@ -48,6 +48,7 @@ In quiet mode this will return 0 if the chain status can be obtained, otherwise
 			Timeout:       viper.GetDuration("timeout"),
 			AllowInsecure: viper.GetBool("allow-insecure-connections"),
 			LogFallback:   !viper.GetBool("quiet"),
+			Quorum:        viper.GetInt("connection-quorum"),
 		})
 		errCheck(err, "Failed to connect to Ethereum 2 beacon node")
 
@@ -57,13 +58,26 @@ In quiet mode this will return 0 if the chain status can be obtained, otherwise
 		)
 		errCheck(err, "Failed to configure chaintime service")
 
-		finalityProvider, isProvider := eth2Client.(eth2client.FinalityProvider)
+		_, isProvider := eth2Client.(eth2client.FinalityProvider)
 		assert(isProvider, "beacon node does not provide finality; cannot report on chain status")
-		finalityResponse, err := finalityProvider.Finality(ctx, &api.FinalityOpts{
-			State: "head",
-		})
+		finality, err := util.RequireQuorum(ctx, eth2Client, "finality",
+			func(ctx context.Context, client eth2client.Service) (*apiv1.Finality, error) {
+				finalityResponse, err := client.(eth2client.FinalityProvider).Finality(ctx, &api.FinalityOpts{
+					State: "head",
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				return finalityResponse.Data, nil
+			},
+			// Justification can differ between healthy nodes either side of an epoch
+			// boundary, so only finalization is required to match.
+			func(a *apiv1.Finality, b *apiv1.Finality) bool {
+				return *a.Finalized == *b.Finalized
+			},
+		)
 		errCheck(err, "Failed to obtain finality information")
-		finality := finalityResponse.Data
 
 		blockProvider, isProvider := eth2Client.(eth2client.SignedBeaconBlockProvider)
 		assert(isProvider, "beacon node does not provide signed beacon blocks; cannot report on chain status")
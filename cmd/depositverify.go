@@ -49,9 +49,9 @@ var depositVerifyCmd = &cobra.Command{
 
 The deposit data is compared to the supplied withdrawal account/public key, validator public key, and value to ensure they match.
 
-In quiet mode this will return 0 if the data is verified correctly, otherwise 1.`,
+In quiet mode this will return 0 if the data is verified correctly, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
-		assert(depositVerifyData != "", "--data is required")
+		assertInput(depositVerifyData != "", "--data is required")
 		var data []byte
 		var err error
 		// Input could be JSON or a path to JSON.
@@ -87,7 +87,7 @@ In quiet mode this will return 0 if the data is verified correctly, otherwise 1.
 		if depositVerifyWithdrawalPubKey != "" {
 			withdrawalPubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(depositVerifyWithdrawalPubKey, "0x"))
 			errCheck(err, "Invalid withdrawal public key")
-			assert(len(withdrawalPubKeyBytes) == 48, "Public key should be 48 bytes")
+			assertInput(len(withdrawalPubKeyBytes) == 48, "Public key should be 48 bytes")
 			withdrawalPubKey, err := e2types.BLSPublicKeyFromBytes(withdrawalPubKeyBytes)
 			errCheck(err, "Value supplied with --withdrawalpubkey is not a valid public key")
 			withdrawalCredentials = eth2util.SHA256(withdrawalPubKey.Marshal())
@@ -95,7 +95,7 @@ In quiet mode this will return 0 if the data is verified correctly, otherwise 1.
 		} else if depositVerifyWithdrawalAddress != "" {
 			withdrawalAddressBytes, err := hex.DecodeString(strings.TrimPrefix(depositVerifyWithdrawalAddress, "0x"))
 			errCheck(err, "Invalid withdrawal address")
-			assert(len(withdrawalAddressBytes) == 20, "address should be 20 bytes")
+			assertInput(len(withdrawalAddressBytes) == 20, "address should be 20 bytes")
 			withdrawalCredentials = make([]byte, 32)
 			withdrawalCredentials[0] = 0x01 // ETH1_ADDRESS_WITHDRAWAL_PREFIX
 			copy(withdrawalCredentials[12:], withdrawalAddressBytes)
@@ -106,7 +106,7 @@ In quiet mode this will return 0 if the data is verified correctly, otherwise 1.
 		if depositVerifyDepositAmount != "" {
 			depositAmount, err = string2eth.StringToGWei(depositVerifyDepositAmount)
 			errCheck(err, "Invalid value")
-			assert(depositAmount >= 1000000000, "deposit amount must be at least 1 Ether") // MIN_DEPOSIT_AMOUNT
+			assertInput(depositAmount >= 1000000000, "deposit amount must be at least 1 Ether") // MIN_DEPOSIT_AMOUNT
 		}
 
 		validatorPubKeys := make(map[[48]byte]bool)
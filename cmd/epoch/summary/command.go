@@ -15,7 +15,9 @@ package epochsummary
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"strings"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -25,8 +27,12 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
 )
 
+// defaultWorkers is the default number of epochs processed concurrently.
+const defaultWorkers = 8
+
 type command struct {
 	quiet   bool
 	verbose bool
@@ -39,10 +45,16 @@ type command struct {
 
 	// Operation.
 	epoch         string
+	fromEpoch     string
+	toEpoch       string
+	since         string
+	epochRange    bool
+	workers       int
 	validatorsStr []string
 	validators    map[phase0.ValidatorIndex]struct{}
 	stream        bool
-	jsonOutput    bool
+	format        string
+	template      string
 
 	// Data access.
 	eth2Client                 eth2client.Service
@@ -68,39 +80,63 @@ type command struct {
 	blocksCache map[string]*spec.VersionedSignedBeaconBlock
 
 	// Results.
-	summary *epochSummary
+	summary      *epochSummary
+	summaries    []*epochSummary
+	rangeSummary *epochRangeSummary
 }
 
 type epochSummary struct {
-	Epoch                      phase0.Epoch          `json:"epoch"`
-	FirstSlot                  phase0.Slot           `json:"first_slot"`
-	LastSlot                   phase0.Slot           `json:"last_slot"`
-	Blocks                     int                   `json:"blocks"`
-	Proposals                  []*epochProposal      `json:"proposals"`
-	SyncCommitteeValidators    int                   `json:"sync_committee_validators"`
-	SyncCommittee              []*epochSyncCommittee `json:"sync_committees"`
-	ActiveValidators           int                   `json:"active_validators"`
-	ActiveBalance              *big.Int              `json:"active_balance"`
-	ParticipatingValidators    int                   `json:"participating_validators"`
-	ParticipatingBalance       *big.Int              `json:"participating_balance"`
-	HeadCorrectValidators      int                   `json:"head_correct_validators"`
-	HeadCorrectBalance         *big.Int              `json:"head_correct_balance"`
-	HeadTimelyValidators       int                   `json:"head_timely_validators"`
-	HeadTimelyBalance          *big.Int              `json:"head_timely_balance"`
-	SourceTimelyValidators     int                   `json:"source_timely_validators"`
-	SourceTimelyBalance        *big.Int              `json:"source_timely_balance"`
-	TargetCorrectValidators    int                   `json:"target_correct_validators"`
-	TargetCorrectBalance       *big.Int              `json:"target_correct_balance"`
-	TargetTimelyValidators     int                   `json:"target_timely_validators"`
-	TargetTimelyBalance        *big.Int              `json:"target_timely_balance"`
+	Epoch                   phase0.Epoch          `json:"epoch"`
+	FirstSlot               phase0.Slot           `json:"first_slot"`
+	LastSlot                phase0.Slot           `json:"last_slot"`
+	Blocks                  int                   `json:"blocks"`
+	Proposals               []*epochProposal      `json:"proposals"`
+	SyncCommitteeValidators int                   `json:"sync_committee_validators"`
+	SyncCommittee           []*epochSyncCommittee `json:"sync_committees"`
+	attestationSummary
 	NonParticipatingValidators []*attestingValidator `json:"nonparticipating_validators"`
 	NonHeadCorrectValidators   []*attestingValidator `json:"nonheadcorrect_validators"`
 	NonHeadTimelyValidators    []*attestingValidator `json:"nonheadtimely_validators"`
 	NonTargetCorrectValidators []*attestingValidator `json:"nontargetcorrect_validators"`
 	NonSourceTimelyValidators  []*attestingValidator `json:"nonsourcetimely_validators"`
+	InclusionDelays            map[phase0.Slot]int   `json:"-"`
 	Blobs                      int                   `json:"blobs"`
 }
 
+// epochRangeSummary is the aggregate of the summaries for a range of epochs.
+type epochRangeSummary struct {
+	FromEpoch                  phase0.Epoch     `json:"from_epoch"`
+	ToEpoch                    phase0.Epoch     `json:"to_epoch"`
+	Blocks                     int              `json:"blocks"`
+	Proposals                  int              `json:"proposals"`
+	ProposedBlocks             int              `json:"proposed_blocks"`
+	MissedProposals            []*epochProposal `json:"missed_proposals"`
+	SyncCommitteeContributions int              `json:"sync_committee_contributions"`
+	SyncCommitteeMissed        int              `json:"sync_committee_missed"`
+	attestationSummary
+	InclusionDelays map[phase0.Slot]int `json:"inclusion_delays"`
+	Blobs           int                 `json:"blobs"`
+	Epochs          []*epochSummary     `json:"epochs,omitempty"`
+}
+
+// attestationSummary contains the attestation totals for one or more epochs.
+type attestationSummary struct {
+	ActiveValidators        int      `json:"active_validators"`
+	ActiveBalance           *big.Int `json:"active_balance"`
+	ParticipatingValidators int      `json:"participating_validators"`
+	ParticipatingBalance    *big.Int `json:"participating_balance"`
+	HeadCorrectValidators   int      `json:"head_correct_validators"`
+	HeadCorrectBalance      *big.Int `json:"head_correct_balance"`
+	HeadTimelyValidators    int      `json:"head_timely_validators"`
+	HeadTimelyBalance       *big.Int `json:"head_timely_balance"`
+	SourceTimelyValidators  int      `json:"source_timely_validators"`
+	SourceTimelyBalance     *big.Int `json:"source_timely_balance"`
+	TargetCorrectValidators int      `json:"target_correct_validators"`
+	TargetCorrectBalance    *big.Int `json:"target_correct_balance"`
+	TargetTimelyValidators  int      `json:"target_timely_validators"`
+	TargetTimelyBalance     *big.Int `json:"target_timely_balance"`
+}
+
 type epochProposal struct {
 	ValidatorIndex phase0.ValidatorIndex `json:"validator_index"`
 	Slot           phase0.Slot           `json:"slot"`
@@ -131,26 +167,9 @@ func newCommand(_ context.Context) (*command, error) {
 		verbose:       viper.GetBool("verbose"),
 		debug:         viper.GetBool("debug"),
 		validatorsStr: viper.GetStringSlice("validators"),
-		summary: &epochSummary{
-			Proposals:            make([]*epochProposal, 0),
-			ActiveBalance:        big.NewInt(0),
-			ParticipatingBalance: big.NewInt(0),
-			HeadCorrectBalance:   big.NewInt(0),
-			HeadTimelyBalance:    big.NewInt(0),
-			SourceTimelyBalance:  big.NewInt(0),
-			TargetCorrectBalance: big.NewInt(0),
-			TargetTimelyBalance:  big.NewInt(0),
-		},
-		validators:              make(map[phase0.ValidatorIndex]struct{}),
-		participatingValidators: make(map[phase0.ValidatorIndex]struct{}),
-		headCorrectValidators:   make(map[phase0.ValidatorIndex]struct{}),
-		headTimelyValidators:    make(map[phase0.ValidatorIndex]struct{}),
-		sourceTimelyValidators:  make(map[phase0.ValidatorIndex]struct{}),
-		targetCorrectValidators: make(map[phase0.ValidatorIndex]struct{}),
-		targetTimelyValidators:  make(map[phase0.ValidatorIndex]struct{}),
-		participations:          make(map[phase0.ValidatorIndex]*attestingValidator),
-		blocksCache:             make(map[string]*spec.VersionedSignedBeaconBlock),
+		validators:    make(map[phase0.ValidatorIndex]struct{}),
 	}
+	c.initEpochState()
 
 	// Timeout.
 	if viper.GetDuration("timeout") == 0 {
@@ -162,8 +181,86 @@ func newCommand(_ context.Context) (*command, error) {
 	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
 
 	c.epoch = viper.GetString("epoch")
+	c.fromEpoch = viper.GetString("from-epoch")
+	c.toEpoch = viper.GetString("to-epoch")
+	c.since = viper.GetString("since")
+	c.epochRange = c.fromEpoch != "" || c.toEpoch != "" || c.since != ""
+	if c.epoch != "" && c.epochRange {
+		return nil, errors.New("epoch cannot be supplied with an epoch range")
+	}
+	c.workers = viper.GetInt("workers")
+	if c.workers < 1 {
+		c.workers = defaultWorkers
+	}
 	c.stream = viper.GetBool("stream")
-	c.jsonOutput = viper.GetBool("json")
+	c.format = util.OutputFormat()
+	c.template = viper.GetString("template")
+	if viper.GetBool("csv") {
+		// --csv is an alias for --format=csv.
+		if c.format != util.OutputFormatText && c.format != util.OutputFormatCSV {
+			return nil, fmt.Errorf("only one of %s and CSV output can be selected", strings.ToUpper(c.format))
+		}
+		c.format = util.OutputFormatCSV
+	}
 
 	return c, nil
 }
+
+func newAttestationSummary() attestationSummary {
+	return attestationSummary{
+		ActiveBalance:        big.NewInt(0),
+		ParticipatingBalance: big.NewInt(0),
+		HeadCorrectBalance:   big.NewInt(0),
+		HeadTimelyBalance:    big.NewInt(0),
+		SourceTimelyBalance:  big.NewInt(0),
+		TargetCorrectBalance: big.NewInt(0),
+		TargetTimelyBalance:  big.NewInt(0),
+	}
+}
+
+// add adds the given attestation totals to these totals.
+func (s *attestationSummary) add(other *attestationSummary) {
+	s.ActiveValidators += other.ActiveValidators
+	s.ActiveBalance.Add(s.ActiveBalance, other.ActiveBalance)
+	s.ParticipatingValidators += other.ParticipatingValidators
+	s.ParticipatingBalance.Add(s.ParticipatingBalance, other.ParticipatingBalance)
+	s.HeadCorrectValidators += other.HeadCorrectValidators
+	s.HeadCorrectBalance.Add(s.HeadCorrectBalance, other.HeadCorrectBalance)
+	s.HeadTimelyValidators += other.HeadTimelyValidators
+	s.HeadTimelyBalance.Add(s.HeadTimelyBalance, other.HeadTimelyBalance)
+	s.SourceTimelyValidators += other.SourceTimelyValidators
+	s.SourceTimelyBalance.Add(s.SourceTimelyBalance, other.SourceTimelyBalance)
+	s.TargetCorrectValidators += other.TargetCorrectValidators
+	s.TargetCorrectBalance.Add(s.TargetCorrectBalance, other.TargetCorrectBalance)
+	s.TargetTimelyValidators += other.TargetTimelyValidators
+	s.TargetTimelyBalance.Add(s.TargetTimelyBalance, other.TargetTimelyBalance)
+}
+
+// initEpochState initialises the intermediate data and summary for processing an epoch.
+func (c *command) initEpochState() {
+	c.summary = &epochSummary{
+		Proposals:          make([]*epochProposal, 0),
+		attestationSummary: newAttestationSummary(),
+		InclusionDelays:    make(map[phase0.Slot]int),
+	}
+	c.validatorInfo = nil
+	c.participatingValidators = make(map[phase0.ValidatorIndex]struct{})
+	c.headCorrectValidators = make(map[phase0.ValidatorIndex]struct{})
+	c.headTimelyValidators = make(map[phase0.ValidatorIndex]struct{})
+	c.sourceTimelyValidators = make(map[phase0.ValidatorIndex]struct{})
+	c.targetCorrectValidators = make(map[phase0.ValidatorIndex]struct{})
+	c.targetTimelyValidators = make(map[phase0.ValidatorIndex]struct{})
+	c.participations = make(map[phase0.ValidatorIndex]*attestingValidator)
+	c.blocksCache = make(map[string]*spec.VersionedSignedBeaconBlock)
+}
+
+// epochCommand returns a copy of the command with its own state, allowing
+// multiple epochs to be processed concurrently.
+func (c *command) epochCommand() *command {
+	ec := *c
+	ec.summaries = nil
+	ec.rangeSummary = nil
+	ec.initEpochState()
+
+	return &ec
+}
@@ -62,3 +62,64 @@ func TestInput(t *testing.T) {
 		})
 	}
 }
+
+func TestInputEpochRange(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "EpochAndRange",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"epoch":      "10",
+				"from-epoch": "5",
+			},
+			err: "epoch cannot be supplied with an epoch range",
+		},
+		{
+			name: "JSONAndCSV",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"since":   "7d",
+				"json":    true,
+				"csv":     true,
+			},
+			err: "only one of JSON and CSV output can be selected",
+		},
+		{
+			name: "Range",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"from-epoch": "5",
+				"to-epoch":   "10",
+			},
+		},
+		{
+			name: "Since",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"since":   "7d",
+				"workers": 2,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.True(t, c.epochRange)
+			}
+		})
+	}
+}
@@ -18,7 +18,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
 )
 
 func (c *command) output(ctx context.Context) (string, error) {
@@ -26,11 +30,23 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
-	if c.jsonOutput {
-		return c.outputJSON(ctx)
+	if c.rangeSummary != nil {
+		return util.FormatOutput(ctx, &util.FormatOpts{
+			Format:   c.format,
+			Template: c.template,
+			JSON:     c.outputRangeJSON,
+			Text:     c.outputRangeTxt,
+			CSV:      c.outputCSV,
+		})
 	}
 
-	return c.outputTxt(ctx)
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputTxt,
+		CSV:      c.outputCSV,
+	})
 }
 
 func (c *command) outputJSON(_ context.Context) (string, error) {
@@ -41,6 +57,138 @@ func (c *command) outputJSON(_ context.Context) (string, error) {
 	return string(data), nil
 }
 
+func (c *command) outputRangeJSON(_ context.Context) (string, error) {
+	if c.verbose {
+		c.rangeSummary.Epochs = c.summaries
+	}
+	data, err := json.Marshal(c.rangeSummary)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// outputCSV outputs a row for each epoch.
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	summaries := c.summaries
+	if c.rangeSummary == nil {
+		summaries = []*epochSummary{c.summary}
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString("epoch,blocks,proposals,proposed_blocks,active_validators,participating_validators,head_correct_validators,head_timely_validators,source_timely_validators,target_correct_validators,target_timely_validators,sync_committee_contributions,sync_committee_missed,blobs")
+	for _, summary := range summaries {
+		proposedBlocks := 0
+		for _, proposal := range summary.Proposals {
+			if proposal.Block {
+				proposedBlocks++
+			}
+		}
+		syncCommitteeMissed := 0
+		for _, syncCommittee := range summary.SyncCommittee {
+			syncCommitteeMissed += syncCommittee.Missed
+		}
+		builder.WriteString(fmt.Sprintf("\n%d,%d,%d,%d,%d,%d,%d,%d,%d,%d,%d,%d,%d,%d",
+			summary.Epoch,
+			summary.Blocks,
+			len(summary.Proposals),
+			proposedBlocks,
+			summary.ActiveValidators,
+			summary.ParticipatingValidators,
+			summary.HeadCorrectValidators,
+			summary.HeadTimelyValidators,
+			summary.SourceTimelyValidators,
+			summary.TargetCorrectValidators,
+			summary.TargetTimelyValidators,
+			summary.SyncCommitteeValidators*summary.Blocks,
+			syncCommitteeMissed,
+			summary.Blobs,
+		))
+	}
+
+	return builder.String(), nil
+}
+
+func (c *command) outputRangeTxt(_ context.Context) (string, error) {
+	summary := c.rangeSummary
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Epochs %d-%d:\n", summary.FromEpoch, summary.ToEpoch))
+
+	builder.WriteString(fmt.Sprintf("  Proposals: %d/%d (%0.2f%%)", summary.ProposedBlocks, summary.Proposals, percentage(summary.ProposedBlocks, summary.Proposals)))
+	if c.verbose {
+		for _, proposal := range summary.MissedProposals {
+			builder.WriteString(fmt.Sprintf("\n    Slot %d validator %d not proposed or not included", proposal.Slot, proposal.ValidatorIndex))
+		}
+	}
+
+	outputAttestations(&builder, &summary.attestationSummary)
+
+	if len(summary.InclusionDelays) > 0 {
+		delays := make([]phase0.Slot, 0, len(summary.InclusionDelays))
+		for delay := range summary.InclusionDelays {
+			delays = append(delays, delay)
+		}
+		sort.Slice(delays, func(i int, j int) bool {
+			return delays[i] < delays[j]
+		})
+		builder.WriteString("\n  Inclusion delays:")
+		for _, delay := range delays {
+			builder.WriteString(fmt.Sprintf("\n    %d: %d (%0.2f%%)", delay, summary.InclusionDelays[delay], percentage(summary.InclusionDelays[delay], summary.ParticipatingValidators)))
+		}
+	}
+
+	if summary.SyncCommitteeContributions > 0 {
+		included := summary.SyncCommitteeContributions - summary.SyncCommitteeMissed
+		builder.WriteString(fmt.Sprintf("\n  Sync committees: %d/%d (%0.2f%%)", included, summary.SyncCommitteeContributions, percentage(included, summary.SyncCommitteeContributions)))
+	}
+
+	return builder.String(), nil
+}
+
+// outputAttestations outputs the attestation totals by balance and by validator.
+func outputAttestations(builder *strings.Builder, summary *attestationSummary) {
+	outputBalance(builder, "  Attesting balance", summary.ParticipatingBalance, summary.ActiveBalance)
+	outputBalance(builder, "    Source timely", summary.SourceTimelyBalance, summary.ActiveBalance)
+	outputBalance(builder, "    Target correct", summary.TargetCorrectBalance, summary.ActiveBalance)
+	outputBalance(builder, "    Target timely", summary.TargetTimelyBalance, summary.ActiveBalance)
+	outputBalance(builder, "    Head correct", summary.HeadCorrectBalance, summary.ActiveBalance)
+	outputBalance(builder, "    Head timely", summary.HeadTimelyBalance, summary.ActiveBalance)
+
+	outputValidators(builder, "  Attesting validators", summary.ParticipatingValidators, summary.ActiveValidators)
+	outputValidators(builder, "    Source timely", summary.SourceTimelyValidators, summary.ActiveValidators)
+	outputValidators(builder, "    Target correct", summary.TargetCorrectValidators, summary.ActiveValidators)
+	outputValidators(builder, "    Target timely", summary.TargetTimelyValidators, summary.ActiveValidators)
+	outputValidators(builder, "    Head correct", summary.HeadCorrectValidators, summary.ActiveValidators)
+	outputValidators(builder, "    Head timely", summary.HeadTimelyValidators, summary.ActiveValidators)
+}
+
+func outputBalance(builder *strings.Builder, label string, balance *big.Int, total *big.Int) {
+	gweiToEth := big.NewInt(1e9)
+	pct := 0.0
+	if total.Sign() > 0 {
+		pct = float64(new(big.Int).Div(new(big.Int).Mul(balance, big.NewInt(10000)), total).Uint64()) / 100.0
+	}
+	builder.WriteString(fmt.Sprintf("\n%s: %s/%s (%0.2f%%)",
+		label,
+		new(big.Int).Div(balance, gweiToEth).String(),
+		new(big.Int).Div(total, gweiToEth).String(),
+		pct,
+	))
+}
+
+func outputValidators(builder *strings.Builder, label string, validators int, total int) {
+	builder.WriteString(fmt.Sprintf("\n%s: %d/%d (%0.2f%%)", label, validators, total, percentage(validators, total)))
+}
+
+func percentage(value int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return 100.0 * float64(value) / float64(total)
+}
+
 func (c *command) outputTxt(_ context.Context) (string, error) {
 	builder := strings.Builder{}
 
@@ -70,80 +218,7 @@ func (c *command) outputTxt(_ context.Context) (string, error) {
 		}
 	}
 
-	gweiToEth := big.NewInt(1e9)
-	mul := big.NewInt(10000)
-	participatingBalancePct := new(big.Int).Div(new(big.Int).Mul(c.summary.ParticipatingBalance, mul), c.summary.ActiveBalance)
-	builder.WriteString(fmt.Sprintf("\n  Attesting balance: %s/%s (%0.2f%%)",
-		new(big.Int).Div(c.summary.ParticipatingBalance, gweiToEth).String(),
-		new(big.Int).Div(c.summary.ActiveBalance, gweiToEth).String(),
-		float64(participatingBalancePct.Uint64())/100.0,
-	))
-
-	sourceTimelyBalancePct := new(big.Int).Div(new(big.Int).Mul(c.summary.SourceTimelyBalance, mul), c.summary.ActiveBalance)
-	builder.WriteString(fmt.Sprintf("\n    Source timely: %s/%s (%0.2f%%)",
-		new(big.Int).Div(c.summary.SourceTimelyBalance, gweiToEth).String(),
-		new(big.Int).Div(c.summary.ActiveBalance, gweiToEth).String(),
-		float64(sourceTimelyBalancePct.Uint64())/100.0,
-	))
-
-	targetCorrectBalancePct := new(big.Int).Div(new(big.Int).Mul(c.summary.TargetCorrectBalance, mul), c.summary.ActiveBalance)
-	builder.WriteString(fmt.Sprintf("\n    Target correct: %s/%s (%0.2f%%)",
-		new(big.Int).Div(c.summary.TargetCorrectBalance, gweiToEth).String(),
-		new(big.Int).Div(c.summary.ActiveBalance, gweiToEth).String(),
-		float64(targetCorrectBalancePct.Uint64())/100.0,
-	))
-
-	targetTimelyBalancePct := new(big.Int).Div(new(big.Int).Mul(c.summary.TargetTimelyBalance, mul), c.summary.ActiveBalance)
-	builder.WriteString(fmt.Sprintf("\n    Target timely: %s/%s (%0.2f%%)",
-		new(big.Int).Div(c.summary.TargetTimelyBalance, gweiToEth).String(),
-		new(big.Int).Div(c.summary.ActiveBalance, gweiToEth).String(),
-		float64(targetTimelyBalancePct.Uint64())/100.0,
-	))
-
-	headCorrectBalancePct := new(big.Int).Div(new(big.Int).Mul(c.summary.HeadCorrectBalance, mul), c.summary.ActiveBalance)
-	builder.WriteString(fmt.Sprintf("\n    Head correct: %s/%s (%0.2f%%)",
-		new(big.Int).Div(c.summary.HeadCorrectBalance, gweiToEth).String(),
-		new(big.Int).Div(c.summary.ActiveBalance, gweiToEth).String(),
-		float64(headCorrectBalancePct.Uint64())/100.0,
-	))
-
-	headTimelyBalancePct := new(big.Int).Div(new(big.Int).Mul(c.summary.HeadTimelyBalance, mul), c.summary.ActiveBalance)
-	builder.WriteString(fmt.Sprintf("\n    Head timely: %s/%s (%0.2f%%)",
-		new(big.Int).Div(c.summary.HeadTimelyBalance, gweiToEth).String(),
-		new(big.Int).Div(c.summary.ActiveBalance, gweiToEth).String(),
-		float64(headTimelyBalancePct.Uint64())/100.0,
-	))
-
-	builder.WriteString(fmt.Sprintf("\n  Attesting validators: %d/%d (%0.2f%%)",
-		c.summary.ParticipatingValidators,
-		c.summary.ActiveValidators,
-		100.0*float64(c.summary.ParticipatingValidators)/float64(c.summary.ActiveValidators),
-	))
-	builder.WriteString(fmt.Sprintf("\n    Source timely: %d/%d (%0.2f%%)",
-		c.summary.SourceTimelyValidators,
-		c.summary.ActiveValidators,
-		100.0*float64(c.summary.SourceTimelyValidators)/float64(c.summary.ActiveValidators),
-	))
-	builder.WriteString(fmt.Sprintf("\n    Target correct: %d/%d (%0.2f%%)",
-		c.summary.TargetCorrectValidators,
-		c.summary.ActiveValidators,
-		100.0*float64(c.summary.TargetCorrectValidators)/float64(c.summary.ActiveValidators),
-	))
-	builder.WriteString(fmt.Sprintf("\n    Target timely: %d/%d (%0.2f%%)",
-		c.summary.TargetTimelyValidators,
-		c.summary.ActiveValidators,
-		100.0*float64(c.summary.TargetTimelyValidators)/float64(c.summary.ActiveValidators),
-	))
-	builder.WriteString(fmt.Sprintf("\n    Head correct: %d/%d (%0.2f%%)",
-		c.summary.HeadCorrectValidators,
-		c.summary.ActiveValidators,
-		100.0*float64(c.summary.HeadCorrectValidators)/float64(c.summary.ActiveValidators),
-	))
-	builder.WriteString(fmt.Sprintf("\n    Head timely: %d/%d (%0.2f%%)",
-		c.summary.HeadTimelyValidators,
-		c.summary.ActiveValidators,
-		100.0*float64(c.summary.HeadTimelyValidators)/float64(c.summary.ActiveValidators),
-	))
+	outputAttestations(&builder, &c.summary.attestationSummary)
 	if c.verbose {
 		// Sort list by validator index.
 		for _, validator := range c.summary.NonParticipatingValidators {
@@ -37,13 +37,6 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	c.summary.Epoch, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
-	if err != nil {
-		return errors.Wrap(err, "failed to parse epoch")
-	}
-	c.summary.FirstSlot = c.chainTime.FirstSlotOfEpoch(c.summary.Epoch)
-	c.summary.LastSlot = c.chainTime.FirstSlotOfEpoch(c.summary.Epoch+1) - 1
-
 	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validatorsStr, "head")
 	if err != nil {
 		return errors.Wrap(err, "failed to parse validators")
@@ -52,6 +45,88 @@ func (c *command) process(ctx context.Context) error {
 		c.validators[validator.Index] = struct{}{}
 	}
 
+	if c.epochRange {
+		return c.processEpochRange(ctx)
+	}
+
+	epoch, err := util.ParseEpoch(ctx, c.chainTime, c.epoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse epoch")
+	}
+
+	return c.processEpoch(ctx, epoch)
+}
+
+// processEpochRange processes each epoch in the range concurrently and
+// aggregates the results.
+func (c *command) processEpochRange(ctx context.Context) error {
+	fromEpoch, toEpoch, err := util.ParseEpochRange(ctx, c.chainTime, c.fromEpoch, c.toEpoch, c.since)
+	if err != nil {
+		return err
+	}
+
+	c.summaries = make([]*epochSummary, toEpoch-fromEpoch+1)
+	err = util.ForEachEpoch(ctx, fromEpoch, toEpoch, c.workers, func(ctx context.Context, epoch phase0.Epoch) error {
+		ec := c.epochCommand()
+		if err := ec.processEpoch(ctx, epoch); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to process epoch %d", epoch))
+		}
+		c.summaries[epoch-fromEpoch] = ec.summary
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.rangeSummary = summariseEpochs(fromEpoch, toEpoch, c.summaries)
+
+	return nil
+}
+
+// summariseEpochs aggregates the summaries for a range of epochs.
+func summariseEpochs(fromEpoch phase0.Epoch,
+	toEpoch phase0.Epoch,
+	summaries []*epochSummary,
+) *epochRangeSummary {
+	res := &epochRangeSummary{
+		FromEpoch:          fromEpoch,
+		ToEpoch:            toEpoch,
+		MissedProposals:    make([]*epochProposal, 0),
+		attestationSummary: newAttestationSummary(),
+		InclusionDelays:    make(map[phase0.Slot]int),
+	}
+
+	for _, summary := range summaries {
+		res.Blocks += summary.Blocks
+		for _, proposal := range summary.Proposals {
+			res.Proposals++
+			if proposal.Block {
+				res.ProposedBlocks++
+			} else {
+				res.MissedProposals = append(res.MissedProposals, proposal)
+			}
+		}
+		res.SyncCommitteeContributions += summary.SyncCommitteeValidators * summary.Blocks
+		for _, syncCommittee := range summary.SyncCommittee {
+			res.SyncCommitteeMissed += syncCommittee.Missed
+		}
+		res.add(&summary.attestationSummary)
+		for delay, count := range summary.InclusionDelays {
+			res.InclusionDelays[delay] += count
+		}
+		res.Blobs += summary.Blobs
+	}
+
+	return res
+}
+
+// processEpoch processes a single epoch, populating the summary.
+func (c *command) processEpoch(ctx context.Context, epoch phase0.Epoch) error {
+	c.summary.Epoch = epoch
+	c.summary.FirstSlot = c.chainTime.FirstSlotOfEpoch(c.summary.Epoch)
+	c.summary.LastSlot = c.chainTime.FirstSlotOfEpoch(c.summary.Epoch+1) - 1
+
 	if err := c.processProposerDuties(ctx); err != nil {
 		return err
 	}
@@ -162,6 +237,9 @@ func (c *command) processAttesterDuties(ctx context.Context) error {
 
 	c.summary.NonParticipatingValidators = make([]*attestingValidator, 0, len(activeValidators)-len(c.participatingValidators))
 	for activeValidatorIndex := range activeValidators {
+		if participation, exists := c.participations[activeValidatorIndex]; exists && participation.InclusionSlot != 0 {
+			c.summary.InclusionDelays[participation.InclusionSlot-participation.Slot]++
+		}
 		if _, exists := c.participatingValidators[activeValidatorIndex]; !exists {
 			if _, exists := c.participations[activeValidatorIndex]; exists {
 				c.summary.NonParticipatingValidators = append(c.summary.NonParticipatingValidators, c.participations[activeValidatorIndex])
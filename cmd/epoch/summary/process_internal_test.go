@@ -15,9 +15,12 @@ package epochsummary
 
 import (
 	"context"
+	"math/big"
 	"os"
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 )
@@ -60,3 +63,53 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestSummariseEpochs(t *testing.T) {
+	newSummary := func(epoch phase0.Epoch, active int, participating int, missedProposal bool) *epochSummary {
+		summary := &epochSummary{
+			Epoch:  epoch,
+			Blocks: 31,
+			Proposals: []*epochProposal{
+				{Slot: phase0.Slot(epoch * 32), ValidatorIndex: 1, Block: true},
+				{Slot: phase0.Slot(epoch*32 + 1), ValidatorIndex: 2, Block: !missedProposal},
+			},
+			SyncCommitteeValidators: 2,
+			SyncCommittee: []*epochSyncCommittee{
+				{ValidatorIndex: 1, Missed: 3},
+			},
+			attestationSummary: newAttestationSummary(),
+			InclusionDelays: map[phase0.Slot]int{
+				1: participating - 1,
+				2: 1,
+			},
+			Blobs: 6,
+		}
+		summary.ActiveValidators = active
+		summary.ActiveBalance = big.NewInt(int64(active) * 32000000000)
+		summary.ParticipatingValidators = participating
+		summary.ParticipatingBalance = big.NewInt(int64(participating) * 32000000000)
+		summary.HeadCorrectValidators = participating - 1
+
+		return summary
+	}
+
+	res := summariseEpochs(10, 11, []*epochSummary{
+		newSummary(10, 100, 90, false),
+		newSummary(11, 100, 95, true),
+	})
+	require.Equal(t, phase0.Epoch(10), res.FromEpoch)
+	require.Equal(t, phase0.Epoch(11), res.ToEpoch)
+	require.Equal(t, 62, res.Blocks)
+	require.Equal(t, 4, res.Proposals)
+	require.Equal(t, 3, res.ProposedBlocks)
+	require.Len(t, res.MissedProposals, 1)
+	require.Equal(t, phase0.Slot(353), res.MissedProposals[0].Slot)
+	require.Equal(t, 124, res.SyncCommitteeContributions)
+	require.Equal(t, 6, res.SyncCommitteeMissed)
+	require.Equal(t, 200, res.ActiveValidators)
+	require.Equal(t, big.NewInt(6400000000000), res.ActiveBalance)
+	require.Equal(t, 185, res.ParticipatingValidators)
+	require.Equal(t, 183, res.HeadCorrectValidators)
+	require.Equal(t, map[phase0.Slot]int{1: 183, 2: 2}, res.InclusionDelays)
+	require.Equal(t, 12, res.Blobs)
+}
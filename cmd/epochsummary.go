@@ -28,7 +28,11 @@ var epochSummaryCmd = &cobra.Command{
 
     ethdo epoch summary --epoch=12345
 
-In quiet mode this will return 0 if information for the epoch is found, otherwise 1.`,
+A range of epochs can be summarised with --from-epoch and --to-epoch, or with --since to cover a period of time up to the last complete epoch.  For example:
+
+    ethdo epoch summary --since=7d
+
+In quiet mode this will return 0 if information for the epoch is found, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := epochsummary.Run(cmd)
 		if err != nil {
@@ -52,6 +56,11 @@ func init() {
 func epochSummaryFlags(cmd *cobra.Command) {
 	epochFlags(cmd)
 	cmd.Flags().StringSlice("validators", nil, "the validators for which to obtain a summary")
+	cmd.Flags().String("from-epoch", "", "the first epoch of a range to summarise")
+	cmd.Flags().String("to-epoch", "", "the last epoch of a range to summarise (defaults to the last complete epoch)")
+	cmd.Flags().String("since", "", "the period of time to summarise, for example 12h or 7d")
+	cmd.Flags().Int("workers", 0, "the number of epochs in a range to process in parallel (defaults to 8)")
+	cmd.Flags().Bool("csv", false, "output per-epoch summaries as CSV")
 }
 
 func epochSummaryBindings(cmd *cobra.Command) {
@@ -59,4 +68,19 @@ func epochSummaryBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("since", cmd.Flags().Lookup("since")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("workers", cmd.Flags().Lookup("workers")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", cmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
 }
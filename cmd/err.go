@@ -17,20 +17,22 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 )
 
-// errCheck checks for an error and quits if it is present.
+// errCheck checks for an error and quits if it is present, with an
+// exit status based on the error's code.
 func errCheck(err error, msg string) {
 	if err != nil {
+		if msg != "" {
+			err = errors.Wrap(err, msg)
+		}
 		if !viper.GetBool("quiet") {
-			if msg == "" {
-				fmt.Fprintf(os.Stderr, "%s\n", err.Error())
-			} else {
-				fmt.Fprintf(os.Stderr, "%s: %s\n", msg, err.Error())
-			}
+			fmt.Fprintf(os.Stderr, "%s\n", util.FormatError(err))
 		}
-		os.Exit(1)
+		os.Exit(util.ExitCode(err))
 	}
 }
 
@@ -57,12 +59,26 @@ func assert(condition bool, msg string) {
 	}
 }
 
+// assertInput checks a condition on the user's input and quits if it is false,
+// with the exit status for invalid input.
+func assertInput(condition bool, msg string) {
+	if !condition {
+		dieWithError(util.NewError(util.ErrorCodeInvalidInput, msg))
+	}
+}
+
 // die prints an error and quits.
 func die(msg string) {
-	if msg != "" && !viper.GetBool("quiet") {
-		fmt.Fprintf(os.Stderr, "%s\n", msg)
+	dieWithError(util.NewError(util.ErrorCodeGeneral, msg))
+}
+
+// dieWithError prints an error and quits, with an exit status based on the
+// error's code.
+func dieWithError(err error) {
+	if err.Error() != "" && !viper.GetBool("quiet") {
+		fmt.Fprintf(os.Stderr, "%s\n", util.FormatError(err))
 	}
-	os.Exit(_exitFailure)
+	os.Exit(util.ExitCode(err))
 }
 
 // warnCheck checks for an error and warns if it is present
@@ -38,11 +38,11 @@ var exitVerifyCmd = &cobra.Command{
 
     ethdo exit verify --signed-operation=exitdata.json --validator=primary/current
 
-In quiet mode this will return 0 if the exit is verified correctly, otherwise 1.`,
+In quiet mode this will return 0 if the exit is verified correctly, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx := context.Background()
 
-		assert(viper.GetString("signed-operation") != "", "signed-operation is required")
+		assertInput(viper.GetString("signed-operation") != "", "signed-operation is required")
 		signedOp, err := obtainSignedOperation(viper.GetString("signed-operation"))
 		errCheck(err, "Failed to obtain signed operation")
 
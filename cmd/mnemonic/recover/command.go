@@ -0,0 +1,115 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mnemonicrecover
+
+import (
+	"context"
+	"encoding/hex"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	validatorkeycheck "github.com/wealdtech/ethdo/cmd/validator/keycheck"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	mnemonic              string
+	validator             string
+	withdrawalCredentials string
+	count                 int
+	workers               int
+
+	// Beacon node connection, only required to look up a validator by index.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Processing.
+	target *validatorkeycheck.Target
+
+	// Output.
+	recovered *candidate
+	path      string
+}
+
+// candidate is a candidate mnemonic.
+type candidate struct {
+	mnemonic string
+	// change describes how the candidate differs from the supplied mnemonic.
+	change string
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		mnemonic:                 viper.GetString("mnemonic"),
+		validator:                viper.GetString("validator"),
+		withdrawalCredentials:    viper.GetString("withdrawal-credentials"),
+		count:                    viper.GetInt("count"),
+		workers:                  viper.GetInt("workers"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.mnemonic == "" {
+		return nil, errors.New("mnemonic is required")
+	}
+
+	if c.validator == "" && c.withdrawalCredentials == "" {
+		return nil, errors.New("validator or withdrawal credentials are required")
+	}
+	if c.validator != "" && c.withdrawalCredentials != "" {
+		return nil, errors.New("only one of validator and withdrawal credentials can be supplied")
+	}
+	if c.withdrawalCredentials != "" {
+		withdrawalCredentials, err := hex.DecodeString(strings.TrimPrefix(c.withdrawalCredentials, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse withdrawal credentials")
+		}
+		if len(withdrawalCredentials) != 32 || withdrawalCredentials[0] != 0x00 {
+			return nil, errors.New("withdrawal credentials must be 32 bytes with the BLS (0x00) prefix")
+		}
+		c.target = &validatorkeycheck.Target{
+			WithdrawalCredentials: withdrawalCredentials,
+		}
+	}
+
+	if c.count <= 0 {
+		return nil, errors.New("count must be greater than 0")
+	}
+
+	if c.workers < 0 {
+		return nil, errors.New("workers cannot be negative")
+	}
+	if c.workers == 0 {
+		c.workers = runtime.NumCPU()
+	}
+
+	return c, nil
+}
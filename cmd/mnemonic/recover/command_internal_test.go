@@ -0,0 +1,134 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mnemonicrecover
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"mnemonic":  "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"validator": "1",
+				"count":     16,
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "MnemonicMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"validator": "1",
+				"count":     16,
+			},
+			err: "mnemonic is required",
+		},
+		{
+			name: "TargetMissing",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"count":    16,
+			},
+			err: "validator or withdrawal credentials are required",
+		},
+		{
+			name: "TargetMultiple",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"mnemonic":               "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"validator":              "1",
+				"withdrawal-credentials": "0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02",
+				"count":                  16,
+			},
+			err: "only one of validator and withdrawal credentials can be supplied",
+		},
+		{
+			name: "WithdrawalCredentialsInvalid",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"mnemonic":               "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"withdrawal-credentials": "invalid",
+				"count":                  16,
+			},
+			err: "failed to parse withdrawal credentials: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "WithdrawalCredentialsExecution",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"mnemonic":               "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"withdrawal-credentials": "0x010000000000000000000000d6cde2e1d6d2c0b5f3ba2ab00bf0bd7f2a98c4a3",
+				"count":                  16,
+			},
+			err: "withdrawal credentials must be 32 bytes with the BLS (0x00) prefix",
+		},
+		{
+			name: "CountZero",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"mnemonic":  "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"validator": "1",
+			},
+			err: "count must be greater than 0",
+		},
+		{
+			name: "WorkersNegative",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"mnemonic":  "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"validator": "1",
+				"count":     16,
+				"workers":   -1,
+			},
+			err: "workers cannot be negative",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"mnemonic":               "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"withdrawal-credentials": "0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02",
+				"count":                  16,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
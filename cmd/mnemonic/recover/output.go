@@ -0,0 +1,33 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mnemonicrecover
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Recovered mnemonic: %s\n", c.recovered.mnemonic))
+	builder.WriteString(fmt.Sprintf("Change: %s\n", c.recovered.change))
+	builder.WriteString(fmt.Sprintf("Matching key path: %s", c.path))
+
+	return builder.String(), nil
+}
@@ -0,0 +1,325 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mnemonicrecover
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+	validatorkeycheck "github.com/wealdtech/ethdo/cmd/validator/keycheck"
+	"github.com/wealdtech/ethdo/util"
+	"golang.org/x/text/unicode/norm"
+)
+
+// progressInterval is the interval between progress reports.
+const progressInterval = 10 * time.Second
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.obtainTarget(ctx); err != nil {
+		return err
+	}
+
+	// If there are more than 24 words we treat the additional words as the passphrase.
+	words := strings.Fields(string(norm.NFKD.Bytes([]byte(c.mnemonic))))
+	passphrase := ""
+	if len(words) > 24 {
+		passphrase = strings.Join(words[24:], " ")
+		words = words[:24]
+	}
+
+	// Candidate generation uses the global word list, so must complete before
+	// the candidates are checked.
+	bip39.SetWordList(bestWordList(words))
+	candidates, err := generateCandidates(words, bip39.GetWordList())
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return errors.New("no candidate mnemonics with a valid checksum")
+	}
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "Checking %d candidate mnemonics\n", len(candidates))
+	}
+
+	c.recovered, c.path, err = c.checkCandidates(ctx, candidates, passphrase)
+	if err != nil {
+		return err
+	}
+	if c.recovered == nil {
+		return errors.New("no candidate mnemonic matched the target")
+	}
+
+	return nil
+}
+
+// obtainTarget obtains the target for a validator.  A validator public key
+// can be used offline; other validator specifiers require a beacon node.
+func (c *command) obtainTarget(ctx context.Context) error {
+	if c.target != nil {
+		// Already have a target from the withdrawal credentials.
+		return nil
+	}
+
+	if strings.HasPrefix(c.validator, "0x") {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(c.validator, "0x"))
+		if err != nil {
+			return errors.Wrap(err, "failed to parse validator public key")
+		}
+		if len(pubKey) != 48 {
+			return errors.New("validator public key must be 48 bytes")
+		}
+		c.target = &validatorkeycheck.Target{
+			PubKey: pubKey,
+		}
+
+		return nil
+	}
+
+	consensusClient, err := util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return err
+	}
+	validator, err := util.ParseValidator(ctx, consensusClient.(consensusclient.ValidatorsProvider), c.validator, "head")
+	if err != nil {
+		return err
+	}
+	c.target = &validatorkeycheck.Target{
+		PubKey: validator.Validator.PublicKey[:],
+	}
+
+	return nil
+}
+
+// bestWordList returns the word list that contains the most of the given words.
+func bestWordList(words []string) []string {
+	best := util.MnemonicWordLists[0]
+	bestCount := 0
+	for _, wordList := range util.MnemonicWordLists {
+		known := make(map[string]bool, len(wordList))
+		for _, word := range wordList {
+			known[word] = true
+		}
+		count := 0
+		for _, word := range words {
+			if known[word] {
+				count++
+			}
+		}
+		if count > bestCount {
+			best = wordList
+			bestCount = count
+		}
+	}
+
+	return best
+}
+
+// validMnemonicLength returns true if the number of words is a valid BIP-39 mnemonic length.
+func validMnemonicLength(words int) bool {
+	return words >= 12 && words <= 24 && words%3 == 0
+}
+
+// generateCandidates generates the candidate mnemonics with a single substituted word,
+// a single missing word or a single pair of adjacent words swapped.  Only candidates
+// with a valid checksum are returned.  This uses the global BIP-39 word list, which
+// must be set to match the supplied word list.
+func generateCandidates(words []string, wordList []string) ([]*candidate, error) {
+	known := make(map[string]bool, len(wordList))
+	for _, word := range wordList {
+		known[word] = true
+	}
+	unknown := make([]int, 0)
+	for i, word := range words {
+		if !known[word] {
+			unknown = append(unknown, i)
+		}
+	}
+	if len(unknown) > 1 {
+		return nil, fmt.Errorf("%d words are not in the word list; only a single word can be recovered", len(unknown))
+	}
+
+	if !validMnemonicLength(len(words)) && !validMnemonicLength(len(words)+1) {
+		return nil, fmt.Errorf("mnemonic has %d words; expected 12, 15, 18, 21 or 24 words, or one fewer", len(words))
+	}
+
+	res := make([]*candidate, 0)
+	seen := make(map[string]bool)
+	add := func(candidateWords []string, change string) {
+		mnemonic := strings.Join(candidateWords, " ")
+		if seen[mnemonic] {
+			return
+		}
+		seen[mnemonic] = true
+		if bip39.IsMnemonicValid(mnemonic) {
+			res = append(res, &candidate{
+				mnemonic: mnemonic,
+				change:   change,
+			})
+		}
+	}
+
+	if validMnemonicLength(len(words)) {
+		if len(unknown) == 0 {
+			// The mnemonic itself may have a valid checksum but not match the target.
+			add(words, "none")
+
+			for i := range len(words) - 1 {
+				candidateWords := append([]string{}, words...)
+				candidateWords[i], candidateWords[i+1] = candidateWords[i+1], candidateWords[i]
+				add(candidateWords, fmt.Sprintf("words %d and %d swapped", i+1, i+2))
+			}
+		}
+
+		// If a word is not in the word list it is the only one that can be substituted.
+		positions := unknown
+		if len(positions) == 0 {
+			positions = make([]int, len(words))
+			for i := range words {
+				positions[i] = i
+			}
+		}
+		for _, i := range positions {
+			for _, word := range wordList {
+				if word == words[i] {
+					continue
+				}
+				candidateWords := append([]string{}, words...)
+				candidateWords[i] = word
+				add(candidateWords, fmt.Sprintf("word %d %q replaced with %q", i+1, words[i], word))
+			}
+		}
+	}
+
+	if validMnemonicLength(len(words)+1) && len(unknown) == 0 {
+		for i := range len(words) + 1 {
+			for _, word := range wordList {
+				candidateWords := make([]string, 0, len(words)+1)
+				candidateWords = append(candidateWords, words[:i]...)
+				candidateWords = append(candidateWords, word)
+				candidateWords = append(candidateWords, words[i:]...)
+				add(candidateWords, fmt.Sprintf("word %q inserted at position %d", word, i+1))
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// checkCandidates checks the candidates against the target in parallel,
+// returning the first candidate found to match.
+func (c *command) checkCandidates(ctx context.Context,
+	candidates []*candidate,
+	passphrase string,
+) (
+	*candidate,
+	string,
+	error,
+) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var checked atomic.Int64
+	var mu sync.Mutex
+	var match *candidate
+	var matchPath string
+	errs := make([]error, c.workers)
+
+	work := make(chan *candidate)
+	var wg sync.WaitGroup
+	for worker := range c.workers {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for candidate := range work {
+				if errs[worker] != nil {
+					continue
+				}
+				seed := bip39.NewSeed(candidate.mnemonic, passphrase)
+				found, path, err := validatorkeycheck.CheckSeed(ctx, c.debug, seed, c.target, c.count)
+				checked.Add(1)
+				switch {
+				case err != nil:
+					if ctx.Err() == nil {
+						errs[worker] = err
+					}
+				case found:
+					mu.Lock()
+					if match == nil {
+						match = candidate
+						matchPath = path
+					}
+					mu.Unlock()
+					cancel()
+				}
+			}
+		}(worker)
+	}
+
+	done := make(chan struct{})
+	if !c.quiet {
+		go func() {
+			ticker := time.NewTicker(progressInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					fmt.Fprintf(os.Stderr, "Checked %d of %d candidates\n", checked.Load(), len(candidates))
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, candidate := range candidates {
+		select {
+		case <-ctx.Done():
+			break feed
+		case work <- candidate:
+		}
+	}
+	close(work)
+	wg.Wait()
+	close(done)
+
+	if match != nil {
+		return match, matchPath, nil
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	// Context may have been cancelled by the caller.
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	return nil, "", nil
+}
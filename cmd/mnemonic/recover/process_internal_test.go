@@ -0,0 +1,117 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mnemonicrecover
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tyler-smith/go-bip39"
+	"github.com/tyler-smith/go-bip39/wordlists"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestGenerateCandidates(t *testing.T) {
+	bip39.SetWordList(wordlists.English)
+	original := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+
+	tests := []struct {
+		name     string
+		mnemonic string
+		change   string
+		err      string
+	}{
+		{
+			name:     "TooShort",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner",
+			err:      "mnemonic has 10 words; expected 12, 15, 18, 21 or 24 words, or one fewer",
+		},
+		{
+			name:     "UnknownWords",
+			mnemonic: "legal winnar thank year wave sausage worth useful legal winner thank yelow",
+			err:      "2 words are not in the word list; only a single word can be recovered",
+		},
+		{
+			name:     "Misspelled",
+			mnemonic: "legal winner thank year wave sausage worth usefull legal winner thank yellow",
+			change:   `word 8 "usefull" replaced with "useful"`,
+		},
+		{
+			name:     "Substituted",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yard",
+			change:   `word 12 "yard" replaced with "yellow"`,
+		},
+		{
+			name:     "Missing",
+			mnemonic: "legal winner thank year wave sausage worth useful legal thank yellow",
+			change:   `word "winner" inserted at position 10`,
+		},
+		{
+			name:     "Swapped",
+			mnemonic: "legal winner thank year sausage wave worth useful legal winner thank yellow",
+			change:   "words 5 and 6 swapped",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			candidates, err := generateCandidates(strings.Fields(test.mnemonic), wordlists.English)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			found := false
+			for _, candidate := range candidates {
+				require.True(t, bip39.IsMnemonicValid(candidate.mnemonic))
+				if candidate.mnemonic == original {
+					found = true
+					require.Equal(t, test.change, candidate.change)
+				}
+			}
+			require.True(t, found)
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	original := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	seed := bip39.NewSeed(original, "")
+	validatorKey, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/1/0/0")
+	require.NoError(t, err)
+
+	c := &command{
+		quiet:     true,
+		mnemonic:  "legal winner thank year wave sausage worth usefull legal winner thank yellow",
+		validator: fmt.Sprintf("%#x", validatorKey.PublicKey().Marshal()),
+		count:     2,
+		workers:   4,
+	}
+	require.NoError(t, c.process(ctx))
+	require.Equal(t, original, c.recovered.mnemonic)
+	require.Equal(t, "m/12381/3600/1/0/0", c.path)
+
+	// Target not derivable from any candidate.
+	c.recovered = nil
+	c.target = nil
+	c.validator = fmt.Sprintf("%#x", make([]byte, 48))
+	require.EqualError(t, c.process(ctx), "no candidate mnemonic matched the target")
+}
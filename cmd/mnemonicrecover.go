@@ -0,0 +1,71 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	mnemonicrecover "github.com/wealdtech/ethdo/cmd/mnemonic/recover"
+)
+
+var mnemonicRecoverCmd = &cobra.Command{
+	Use:   "recover",
+	Short: "Recover a mnemonic with a single incorrect word",
+	Long: `Recover a mnemonic with a single misspelled, incorrect or missing word, or a pair of adjacent words swapped.  For example:
+
+    ethdo mnemonic recover --mnemonic="..." --validator=0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c
+
+Each candidate mnemonic with a valid checksum is checked against the target, which is either a validator or BLS (0x00) withdrawal credentials.  A validator can be supplied as a public key, which can be checked offline, or as an index or account, which requires a connection to a beacon node.  The first --count indices of the standard EIP-2334 paths are checked for each candidate.
+
+In quiet mode this will return 0 if the mnemonic has been recovered, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := mnemonicrecover.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	mnemonicCmd.AddCommand(mnemonicRecoverCmd)
+	mnemonicFlags(mnemonicRecoverCmd)
+	mnemonicRecoverCmd.Flags().String("validator", "", "The validator, as a public key, index or account, whose key the mnemonic should generate")
+	mnemonicRecoverCmd.Flags().String("withdrawal-credentials", "", "The BLS (0x00) withdrawal credentials whose key the mnemonic should generate")
+	mnemonicRecoverCmd.Flags().Int("count", 16, "The number of indices to check for each candidate mnemonic")
+	mnemonicRecoverCmd.Flags().Int("workers", 0, "The number of workers checking candidates in parallel (defaults to the number of CPUs)")
+}
+
+func mnemonicRecoverBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawal-credentials", cmd.Flags().Lookup("withdrawal-credentials")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("count", cmd.Flags().Lookup("count")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("workers", cmd.Flags().Lookup("workers")); err != nil {
+		panic(err)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"errors"
 
 	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethdo/util"
 )
 
 // Run runs the wallet create data command.
@@ -25,7 +26,7 @@ func Run(cmd *cobra.Command) (string, error) {
 	ctx := context.Background()
 	dataIn, err := input(ctx)
 	if err != nil {
-		return "", errors.Join(errors.New("failed to set up command"), err)
+		return "", util.ErrorWithCode(errors.Join(errors.New("failed to set up command"), err), util.ErrorCodeInvalidInput, nil)
 	}
 
 	// Further errors do not need a usage report.
@@ -34,7 +35,7 @@ func Run(cmd *cobra.Command) (string, error) {
 	if err := process(ctx, dataIn); err != nil {
 		switch {
 		case errors.Is(err, context.DeadlineExceeded):
-			return "", errors.New("operation timed out; try increasing with --timeout option")
+			return "", util.NewError(util.ErrorCodeTimeout, "operation timed out; try increasing with --timeout option")
 		default:
 			return "", errors.Join(errors.New("failed to process"), err)
 		}
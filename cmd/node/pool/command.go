@@ -0,0 +1,152 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+const (
+	poolExits             = "exits"
+	poolCredentialChanges = "credential-changes"
+	poolAttesterSlashings = "attester-slashings"
+	poolProposerSlashings = "proposer-slashings"
+	poolAttestations      = "attestations"
+)
+
+// poolTypes are the supported pool types, in the order in which they are output.
+var poolTypes = []string{
+	poolExits,
+	poolCredentialChanges,
+	poolAttesterSlashings,
+	poolProposerSlashings,
+	poolAttestations,
+}
+
+// defaultPoolTypes are the pool types obtained if none are specified.  The
+// attestation pool can be very large, so must be requested explicitly.
+var defaultPoolTypes = []string{
+	poolExits,
+	poolCredentialChanges,
+	poolAttesterSlashings,
+	poolProposerSlashings,
+}
+
+type command struct {
+	quiet    bool
+	verbose  bool
+	debug    bool
+	format   string
+	template string
+
+	// Input.
+	types     map[string]bool
+	validator string
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Processing.
+	consensusClient consensusclient.Service
+	chainTime       chaintime.Service
+	electra         bool
+	validatorIndex  *phase0.ValidatorIndex
+
+	// Output.
+	exits                    []*phase0.SignedVoluntaryExit
+	credentialChanges        []*capella.SignedBLSToExecutionChange
+	attesterSlashings        []*phase0.AttesterSlashing
+	electraAttesterSlashings []*electra.AttesterSlashing
+	proposerSlashings        []*phase0.ProposerSlashing
+	attestations             []*phase0.Attestation
+	electraAttestations      []*electra.Attestation
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		format:                   util.OutputFormat(),
+		template:                 viper.GetString("template"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		validator:                viper.GetString("validator"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	var err error
+	c.types, err = parseTypes(viper.GetStringSlice("type"))
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// parseTypes parses the requested pool types.
+func parseTypes(input []string) (map[string]bool, error) {
+	if len(input) == 0 {
+		input = defaultPoolTypes
+	}
+
+	types := make(map[string]bool)
+	for _, poolType := range input {
+		poolType = strings.ToLower(strings.TrimSpace(poolType))
+		if poolType == "" {
+			continue
+		}
+		if poolType == "all" {
+			for _, t := range poolTypes {
+				types[t] = true
+			}
+			continue
+		}
+		supported := false
+		for _, t := range poolTypes {
+			if poolType == t {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return nil, fmt.Errorf("unsupported pool type %q; supported types are %s", poolType, strings.Join(poolTypes, ", "))
+		}
+		types[poolType] = true
+	}
+	if len(types) == 0 {
+		return nil, errors.New("no pool types specified")
+	}
+
+	return types, nil
+}
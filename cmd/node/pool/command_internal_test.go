@@ -0,0 +1,100 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		vars  map[string]interface{}
+		types map[string]bool
+		err   string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{},
+			err:  "timeout is required",
+		},
+		{
+			name: "TypeInvalid",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"type":    []string{"exits", "deposits"},
+			},
+			err: `unsupported pool type "deposits"; supported types are exits, credential-changes, attester-slashings, proposer-slashings, attestations`,
+		},
+		{
+			name: "Default",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			types: map[string]bool{
+				"exits":              true,
+				"credential-changes": true,
+				"attester-slashings": true,
+				"proposer-slashings": true,
+			},
+		},
+		{
+			name: "Specified",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"type":      []string{"Attestations", " exits"},
+				"validator": "12345",
+			},
+			types: map[string]bool{
+				"attestations": true,
+				"exits":        true,
+			},
+		},
+		{
+			name: "All",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"type":    []string{"all"},
+			},
+			types: map[string]bool{
+				"exits":              true,
+				"credential-changes": true,
+				"attester-slashings": true,
+				"proposer-slashings": true,
+				"attestations":       true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.types, c.types)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepool
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	blockinfo "github.com/wealdtech/ethdo/cmd/block/info"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputText,
+	})
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	// Only requested pools are included in the output.
+	res := make(map[string]any)
+	if c.types[poolExits] {
+		res["voluntary_exits"] = c.exits
+	}
+	if c.types[poolCredentialChanges] {
+		res["bls_to_execution_changes"] = c.credentialChanges
+	}
+	if c.types[poolAttesterSlashings] {
+		if c.electra {
+			res["attester_slashings"] = c.electraAttesterSlashings
+		} else {
+			res["attester_slashings"] = c.attesterSlashings
+		}
+	}
+	if c.types[poolProposerSlashings] {
+		res["proposer_slashings"] = c.proposerSlashings
+	}
+	if c.types[poolAttestations] {
+		// The pool can contain attestations from either side of the Electra fork.
+		attestations := make([]any, 0, len(c.attestations)+len(c.electraAttestations))
+		for _, attestation := range c.attestations {
+			attestations = append(attestations, attestation)
+		}
+		for _, attestation := range c.electraAttestations {
+			attestations = append(attestations, attestation)
+		}
+		res["attestations"] = attestations
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(ctx context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	for _, poolType := range poolTypes {
+		if !c.types[poolType] {
+			continue
+		}
+
+		var res string
+		var err error
+		switch poolType {
+		case poolExits:
+			res, err = blockinfo.OutputVoluntaryExits(ctx, c.consensusClient, true, c.exits)
+		case poolCredentialChanges:
+			res, err = blockinfo.OutputBLSToExecutionChanges(ctx, c.consensusClient, true, c.credentialChanges)
+		case poolAttesterSlashings:
+			if c.electra {
+				res, err = blockinfo.OutputElectraAttesterSlashings(ctx, c.consensusClient, true, c.electraAttesterSlashings)
+			} else {
+				res, err = blockinfo.OutputAttesterSlashings(ctx, c.consensusClient, true, c.attesterSlashings)
+			}
+		case poolProposerSlashings:
+			res, err = blockinfo.OutputProposerSlashings(ctx, c.consensusClient, true, c.proposerSlashings)
+		case poolAttestations:
+			res, err = c.outputAttestationsText(ctx)
+		}
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(res)
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// outputAttestationsText outputs the attestation pool.  Attestations are numerous,
+// so are only detailed in verbose mode.
+func (c *command) outputAttestationsText(ctx context.Context) (string, error) {
+	// Around the Electra fork the pool can contain attestations in both formats.
+	if len(c.electraAttestations) == 0 {
+		return blockinfo.OutputAttestations(ctx, c.consensusClient, c.verbose, c.attestations)
+	}
+	if len(c.attestations) == 0 {
+		return blockinfo.OutputElectraAttestations(ctx, c.consensusClient, c.verbose, c.electraAttestations)
+	}
+
+	res, err := blockinfo.OutputAttestations(ctx, c.consensusClient, c.verbose, c.attestations)
+	if err != nil {
+		return "", err
+	}
+	electraRes, err := blockinfo.OutputElectraAttestations(ctx, c.consensusClient, c.verbose, c.electraAttestations)
+	if err != nil {
+		return "", err
+	}
+
+	return res + electraRes, nil
+}
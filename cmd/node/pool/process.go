@@ -0,0 +1,386 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepool
+
+import (
+	"context"
+	"fmt"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// committees are beacon committees, indexed by slot and committee index.
+type committees map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if c.validator != "" {
+		validator, err := util.ParseValidator(ctx, c.consensusClient.(consensusclient.ValidatorsProvider), c.validator, "head")
+		if err != nil {
+			return err
+		}
+		c.validatorIndex = &validator.Index
+	}
+
+	if c.types[poolExits] {
+		if err := c.obtainExits(ctx); err != nil {
+			return err
+		}
+	}
+	if c.types[poolCredentialChanges] {
+		if err := c.obtainCredentialChanges(ctx); err != nil {
+			return err
+		}
+	}
+	if c.types[poolAttesterSlashings] {
+		if err := c.obtainAttesterSlashings(ctx); err != nil {
+			return err
+		}
+	}
+	if c.types[poolProposerSlashings] {
+		if err := c.obtainProposerSlashings(ctx); err != nil {
+			return err
+		}
+	}
+	if c.types[poolAttestations] {
+		if err := c.obtainAttestations(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisProvider(c.consensusClient.(consensusclient.GenesisProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	// Attester slashings change format with Electra.
+	for _, fork := range c.chainTime.Forks() {
+		if fork.Name == "electra" && fork.Epoch <= c.chainTime.CurrentEpoch() {
+			c.electra = true
+		}
+	}
+
+	return nil
+}
+
+func (c *command) obtainExits(ctx context.Context) error {
+	provider, isProvider := c.consensusClient.(consensusclient.VoluntaryExitPoolProvider)
+	if !isProvider {
+		return errors.New("connection does not provide the voluntary exit pool")
+	}
+	response, err := provider.VoluntaryExitPool(ctx, &api.VoluntaryExitPoolOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain voluntary exit pool")
+	}
+	c.exits = filterExits(response.Data, c.validatorIndex)
+
+	return nil
+}
+
+func (c *command) obtainCredentialChanges(ctx context.Context) error {
+	changes := make([]*capella.SignedBLSToExecutionChange, 0)
+	if err := util.ObtainBeaconNodePool(ctx, c.consensusClient, c.timeout, "/eth/v1/beacon/pool/bls_to_execution_changes", &changes); err != nil {
+		return errors.Wrap(err, "failed to obtain BLS to execution change pool")
+	}
+	c.credentialChanges = filterCredentialChanges(changes, c.validatorIndex)
+
+	return nil
+}
+
+func (c *command) obtainAttesterSlashings(ctx context.Context) error {
+	if c.electra {
+		slashings := make([]*electra.AttesterSlashing, 0)
+		if err := util.ObtainBeaconNodePool(ctx, c.consensusClient, c.timeout, "/eth/v2/beacon/pool/attester_slashings", &slashings); err != nil {
+			return errors.Wrap(err, "failed to obtain attester slashing pool")
+		}
+		c.electraAttesterSlashings = filterElectraAttesterSlashings(slashings, c.validatorIndex)
+
+		return nil
+	}
+
+	slashings := make([]*phase0.AttesterSlashing, 0)
+	if err := util.ObtainBeaconNodePool(ctx, c.consensusClient, c.timeout, "/eth/v1/beacon/pool/attester_slashings", &slashings); err != nil {
+		return errors.Wrap(err, "failed to obtain attester slashing pool")
+	}
+	c.attesterSlashings = filterAttesterSlashings(slashings, c.validatorIndex)
+
+	return nil
+}
+
+func (c *command) obtainProposerSlashings(ctx context.Context) error {
+	slashings := make([]*phase0.ProposerSlashing, 0)
+	if err := util.ObtainBeaconNodePool(ctx, c.consensusClient, c.timeout, "/eth/v1/beacon/pool/proposer_slashings", &slashings); err != nil {
+		return errors.Wrap(err, "failed to obtain proposer slashing pool")
+	}
+	c.proposerSlashings = filterProposerSlashings(slashings, c.validatorIndex)
+
+	return nil
+}
+
+func (c *command) obtainAttestations(ctx context.Context) error {
+	provider, isProvider := c.consensusClient.(consensusclient.AttestationPoolProvider)
+	if !isProvider {
+		return errors.New("connection does not provide the attestation pool")
+	}
+	response, err := provider.AttestationPool(ctx, &api.AttestationPoolOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain attestation pool")
+	}
+
+	c.attestations = make([]*phase0.Attestation, 0)
+	c.electraAttestations = make([]*electra.Attestation, 0)
+	beaconCommittees := make(committees)
+	for _, attestation := range response.Data {
+		switch {
+		case attestation.Version >= spec.DataVersionFulu && attestation.Fulu != nil:
+			c.electraAttestations = append(c.electraAttestations, attestation.Fulu)
+		case attestation.Version >= spec.DataVersionElectra && attestation.Electra != nil:
+			c.electraAttestations = append(c.electraAttestations, attestation.Electra)
+		default:
+			phase0Attestation, err := versionedPhase0Attestation(attestation)
+			if err != nil {
+				return err
+			}
+			c.attestations = append(c.attestations, phase0Attestation)
+		}
+	}
+
+	if c.validatorIndex == nil {
+		return nil
+	}
+
+	// Filtering attestations requires the committees to which they refer.
+	attestations := make([]*phase0.Attestation, 0)
+	for _, attestation := range c.attestations {
+		if err := c.obtainCommittees(ctx, beaconCommittees, attestation.Data.Slot); err != nil {
+			return err
+		}
+		if attested(beaconCommittees[attestation.Data.Slot], []phase0.CommitteeIndex{attestation.Data.Index}, attestation.AggregationBits, *c.validatorIndex) {
+			attestations = append(attestations, attestation)
+		}
+	}
+	c.attestations = attestations
+
+	electraAttestations := make([]*electra.Attestation, 0)
+	for _, attestation := range c.electraAttestations {
+		if err := c.obtainCommittees(ctx, beaconCommittees, attestation.Data.Slot); err != nil {
+			return err
+		}
+		committeeIndices := make([]phase0.CommitteeIndex, 0)
+		for _, committeeIndex := range attestation.CommitteeBits.BitIndices() {
+			committeeIndices = append(committeeIndices, phase0.CommitteeIndex(committeeIndex))
+		}
+		if attested(beaconCommittees[attestation.Data.Slot], committeeIndices, attestation.AggregationBits, *c.validatorIndex) {
+			electraAttestations = append(electraAttestations, attestation)
+		}
+	}
+	c.electraAttestations = electraAttestations
+
+	return nil
+}
+
+// versionedPhase0Attestation returns the pre-Electra attestation from a versioned attestation.
+func versionedPhase0Attestation(attestation *spec.VersionedAttestation) (*phase0.Attestation, error) {
+	var res *phase0.Attestation
+	switch attestation.Version {
+	case spec.DataVersionPhase0:
+		res = attestation.Phase0
+	case spec.DataVersionAltair:
+		res = attestation.Altair
+	case spec.DataVersionBellatrix:
+		res = attestation.Bellatrix
+	case spec.DataVersionCapella:
+		res = attestation.Capella
+	case spec.DataVersionDeneb:
+		res = attestation.Deneb
+	}
+	if res == nil {
+		return nil, fmt.Errorf("unhandled attestation version %v", attestation.Version)
+	}
+
+	return res, nil
+}
+
+// obtainCommittees obtains the beacon committees for the epoch of the given slot, if not already present.
+func (c *command) obtainCommittees(ctx context.Context, beaconCommittees committees, slot phase0.Slot) error {
+	if _, exists := beaconCommittees[slot]; exists {
+		return nil
+	}
+
+	response, err := c.consensusClient.(consensusclient.BeaconCommitteesProvider).BeaconCommittees(ctx, &api.BeaconCommitteesOpts{
+		State: fmt.Sprintf("%d", slot),
+	})
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain beacon committees for slot %d", slot))
+	}
+	// Ensure that the slot is present even if the response does not contain it, to avoid re-fetching.
+	beaconCommittees[slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+	for _, beaconCommittee := range response.Data {
+		if _, exists := beaconCommittees[beaconCommittee.Slot]; !exists {
+			beaconCommittees[beaconCommittee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+		}
+		beaconCommittees[beaconCommittee.Slot][beaconCommittee.Index] = beaconCommittee.Validators
+	}
+
+	return nil
+}
+
+// attested returns true if the validator is one of the attesters in the aggregation bits.
+// The aggregation bits cover the given committees in order.
+func attested(slotCommittees map[phase0.CommitteeIndex][]phase0.ValidatorIndex,
+	committeeIndices []phase0.CommitteeIndex,
+	aggregationBits bitfield.Bitlist,
+	index phase0.ValidatorIndex,
+) bool {
+	offset := uint64(0)
+	for _, committeeIndex := range committeeIndices {
+		committee, exists := slotCommittees[committeeIndex]
+		if !exists {
+			return false
+		}
+		for i, validatorIndex := range committee {
+			if validatorIndex == index {
+				position := offset + uint64(i)
+				return position < aggregationBits.Len() && aggregationBits.BitAt(position)
+			}
+		}
+		offset += uint64(len(committee))
+	}
+
+	return false
+}
+
+func filterExits(exits []*phase0.SignedVoluntaryExit, index *phase0.ValidatorIndex) []*phase0.SignedVoluntaryExit {
+	if index == nil {
+		return exits
+	}
+
+	res := make([]*phase0.SignedVoluntaryExit, 0)
+	for _, exit := range exits {
+		if exit.Message.ValidatorIndex == *index {
+			res = append(res, exit)
+		}
+	}
+
+	return res
+}
+
+func filterCredentialChanges(changes []*capella.SignedBLSToExecutionChange, index *phase0.ValidatorIndex) []*capella.SignedBLSToExecutionChange {
+	if index == nil {
+		return changes
+	}
+
+	res := make([]*capella.SignedBLSToExecutionChange, 0)
+	for _, change := range changes {
+		if change.Message.ValidatorIndex == *index {
+			res = append(res, change)
+		}
+	}
+
+	return res
+}
+
+func filterAttesterSlashings(slashings []*phase0.AttesterSlashing, index *phase0.ValidatorIndex) []*phase0.AttesterSlashing {
+	if index == nil {
+		return slashings
+	}
+
+	res := make([]*phase0.AttesterSlashing, 0)
+	for _, slashing := range slashings {
+		if slashes(slashing.Attestation1.AttestingIndices, slashing.Attestation2.AttestingIndices, *index) {
+			res = append(res, slashing)
+		}
+	}
+
+	return res
+}
+
+func filterElectraAttesterSlashings(slashings []*electra.AttesterSlashing, index *phase0.ValidatorIndex) []*electra.AttesterSlashing {
+	if index == nil {
+		return slashings
+	}
+
+	res := make([]*electra.AttesterSlashing, 0)
+	for _, slashing := range slashings {
+		if slashes(slashing.Attestation1.AttestingIndices, slashing.Attestation2.AttestingIndices, *index) {
+			res = append(res, slashing)
+		}
+	}
+
+	return res
+}
+
+// slashes returns true if the validator is present in both sets of attesting indices,
+// and hence would be slashed.
+func slashes(indices1 []uint64, indices2 []uint64, index phase0.ValidatorIndex) bool {
+	present := func(indices []uint64) bool {
+		for _, i := range indices {
+			if i == uint64(index) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return present(indices1) && present(indices2)
+}
+
+func filterProposerSlashings(slashings []*phase0.ProposerSlashing, index *phase0.ValidatorIndex) []*phase0.ProposerSlashing {
+	if index == nil {
+		return slashings
+	}
+
+	res := make([]*phase0.ProposerSlashing, 0)
+	for _, slashing := range slashings {
+		if slashing.SignedHeader1.Message.ProposerIndex == *index {
+			res = append(res, slashing)
+		}
+	}
+
+	return res
+}
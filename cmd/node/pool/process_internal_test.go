@@ -0,0 +1,138 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepool
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttested(t *testing.T) {
+	slotCommittees := map[phase0.CommitteeIndex][]phase0.ValidatorIndex{
+		0: {10, 11, 12},
+		1: {20, 21},
+	}
+
+	// Bits are for committees 0 and 1 in order, with a trailing length bit.
+	bits := bitfield.NewBitlist(5)
+	bits.SetBitAt(1, true)
+	bits.SetBitAt(3, true)
+
+	tests := []struct {
+		name             string
+		committeeIndices []phase0.CommitteeIndex
+		index            phase0.ValidatorIndex
+		res              bool
+	}{
+		{
+			name:             "FirstCommittee",
+			committeeIndices: []phase0.CommitteeIndex{0, 1},
+			index:            11,
+			res:              true,
+		},
+		{
+			name:             "FirstCommitteeNotAttested",
+			committeeIndices: []phase0.CommitteeIndex{0, 1},
+			index:            10,
+		},
+		{
+			name:             "SecondCommittee",
+			committeeIndices: []phase0.CommitteeIndex{0, 1},
+			index:            20,
+			res:              true,
+		},
+		{
+			name:             "SecondCommitteeNotAttested",
+			committeeIndices: []phase0.CommitteeIndex{0, 1},
+			index:            21,
+		},
+		{
+			name:             "NotInCommittees",
+			committeeIndices: []phase0.CommitteeIndex{0, 1},
+			index:            30,
+		},
+		{
+			name:             "CommitteeUnknown",
+			committeeIndices: []phase0.CommitteeIndex{2},
+			index:            11,
+		},
+		{
+			name:             "SingleCommittee",
+			committeeIndices: []phase0.CommitteeIndex{1},
+			index:            21,
+			res:              true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, attested(slotCommittees, test.committeeIndices, bits, test.index))
+		})
+	}
+}
+
+func TestFilters(t *testing.T) {
+	index := phase0.ValidatorIndex(2)
+
+	exits := []*phase0.SignedVoluntaryExit{
+		{Message: &phase0.VoluntaryExit{ValidatorIndex: 1}},
+		{Message: &phase0.VoluntaryExit{ValidatorIndex: 2}},
+	}
+	require.Len(t, filterExits(exits, nil), 2)
+	require.Equal(t, exits[1:], filterExits(exits, &index))
+
+	changes := []*capella.SignedBLSToExecutionChange{
+		{Message: &capella.BLSToExecutionChange{ValidatorIndex: 2}},
+		{Message: &capella.BLSToExecutionChange{ValidatorIndex: 3}},
+	}
+	require.Len(t, filterCredentialChanges(changes, nil), 2)
+	require.Equal(t, changes[:1], filterCredentialChanges(changes, &index))
+
+	attesterSlashings := []*phase0.AttesterSlashing{
+		{
+			Attestation1: &phase0.IndexedAttestation{AttestingIndices: []uint64{1, 2}},
+			Attestation2: &phase0.IndexedAttestation{AttestingIndices: []uint64{1}},
+		},
+		{
+			Attestation1: &phase0.IndexedAttestation{AttestingIndices: []uint64{2, 3}},
+			Attestation2: &phase0.IndexedAttestation{AttestingIndices: []uint64{2}},
+		},
+	}
+	require.Len(t, filterAttesterSlashings(attesterSlashings, nil), 2)
+	require.Equal(t, attesterSlashings[1:], filterAttesterSlashings(attesterSlashings, &index))
+
+	electraAttesterSlashings := []*electra.AttesterSlashing{
+		{
+			Attestation1: &electra.IndexedAttestation{AttestingIndices: []uint64{2}},
+			Attestation2: &electra.IndexedAttestation{AttestingIndices: []uint64{2, 4}},
+		},
+		{
+			Attestation1: &electra.IndexedAttestation{AttestingIndices: []uint64{2}},
+			Attestation2: &electra.IndexedAttestation{AttestingIndices: []uint64{4}},
+		},
+	}
+	require.Equal(t, electraAttesterSlashings[:1], filterElectraAttesterSlashings(electraAttesterSlashings, &index))
+
+	proposerSlashings := []*phase0.ProposerSlashing{
+		{SignedHeader1: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{ProposerIndex: 2}}},
+		{SignedHeader1: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{ProposerIndex: 5}}},
+	}
+	require.Len(t, filterProposerSlashings(proposerSlashings, nil), 2)
+	require.Equal(t, proposerSlashings[:1], filterProposerSlashings(proposerSlashings, &index))
+}
@@ -32,7 +32,7 @@ var nodeInfoCmd = &cobra.Command{
 
     ethdo node info
 
-In quiet mode this will return 0 if the node information can be obtained, otherwise 1.`,
+In quiet mode this will return 0 if the node information can be obtained, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx := context.Background()
 
@@ -0,0 +1,63 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	nodepool "github.com/wealdtech/ethdo/cmd/node/pool"
+)
+
+var nodePoolCmd = &cobra.Command{
+	Use:   "pool",
+	Short: "Obtain the contents of a node's operation pools",
+	Long: `Obtain the contents of a node's operation pools.  For example:
+
+    ethdo node pool --type=exits,credential-changes --validator=12345
+
+Supported pool types are exits, credential-changes, attester-slashings, proposer-slashings and attestations.  If no type is supplied all pools other than attestations are shown; "all" shows every pool.  Attestations are only detailed with --verbose.
+
+In quiet mode this will return 0 if the pools can be obtained, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := nodepool.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	nodeCmd.AddCommand(nodePoolCmd)
+	nodeFlags(nodePoolCmd)
+	nodePoolCmd.Flags().StringSlice("type", nil, "The types of pool to obtain (exits,credential-changes,attester-slashings,proposer-slashings,attestations,all)")
+	nodePoolCmd.Flags().String("validator", "", "Only show pool entries for this validator, as an index, public key or account")
+}
+
+func nodePoolBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("type", cmd.Flags().Lookup("type")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type command struct {
@@ -36,9 +37,10 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Operation.
-	epoch      string
-	slot       string
-	jsonOutput bool
+	epoch    string
+	slot     string
+	format   string
+	template string
 
 	// Data access.
 	eth2Client             eth2client.Service
@@ -64,7 +66,8 @@ func newCommand(_ context.Context) (*command, error) {
 		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
 		epoch:                    viper.GetString("epoch"),
 		slot:                     viper.GetString("slot"),
-		jsonOutput:               viper.GetBool("json"),
+		format:                   util.OutputFormat(),
+		template:                 viper.GetString("template"),
 		results:                  &results{},
 	}
 
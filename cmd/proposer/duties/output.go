@@ -18,6 +18,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/wealdtech/ethdo/util"
 )
 
 func (c *command) output(ctx context.Context) (string, error) {
@@ -25,11 +27,12 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
-	if c.jsonOutput {
-		return c.outputJSON(ctx)
-	}
-
-	return c.outputTxt(ctx)
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputTxt,
+	})
 }
 
 func (c *command) outputJSON(_ context.Context) (string, error) {
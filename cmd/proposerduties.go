@@ -28,7 +28,7 @@ var proposerDutiesCmd = &cobra.Command{
 
     ethdo proposer duties --epoch=12345
 
-In quiet mode this will return 0 if duties can be obtained, otherwise 1.`,
+In quiet mode this will return 0 if duties can be obtained, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := proposerduties.Run(cmd)
 		if err != nil {
@@ -1,4 +1,4 @@
-// Copyright © 2019 - 2023 Weald Technology Trading.
+// Copyright © 2019 - 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -41,47 +41,117 @@ var RootCmd = &cobra.Command{
 	Short:             "Ethereum consensus layer CLI",
 	Long:              `Manage common Ethereum consensus layer tasks from the command line.`,
 	PersistentPreRunE: persistentPreRunE,
+	// Errors and usage are output by Execute, to allow for structured output.
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
 // bindings are the command-specific bindings.
 var bindings = map[string]func(cmd *cobra.Command){
-	"account/create":     accountCreateBindings,
-	"account/derive":     accountDeriveBindings,
-	"account/import":     accountImportBindings,
-	"attester/duties":    attesterDutiesBindings,
-	"attester/inclusion": attesterInclusionBindings,
-	"block/analyze":      blockAnalyzeBindings,
-	"block/info":         blockInfoBindings,
-	"block/trail":        blockTrailBindings,
-	"chain/eth1votes":    chainEth1VotesBindings,
-	"chain/info":         chainInfoBindings,
-	"chain/queues":       chainQueuesBindings,
-	"chain/spec":         chainSpecBindings,
-	"chain/time":         chainTimeBindings,
+	"account/create":       accountCreateBindings,
+	"account/derive":       accountDeriveBindings,
+	"account/import":       accountImportBindings,
+	"attester/duties":      attesterDutiesBindings,
+	"attester/inclusion":   attesterInclusionBindings,
+	"block/analyze":        blockAnalyzeBindings,
+	"block/info":           blockInfoBindings,
+	"block/trail":          blockTrailBindings,
+	"cache/prune":          cachePruneBindings,
+	"chain/eth1votes":      chainEth1VotesBindings,
+	"chain/info":           chainInfoBindings,
+	"chain/queues":         chainQueuesBindings,
+	"chain/spec":           chainSpecBindings,
+	"chain/slashing/build": chainSlashingBuildBindings,
+	"chain/time":           chainTimeBindings,
 	"chain/verify/signedcontributionandproof": chainVerifySignedContributionAndProofBindings,
-	"epoch/summary":             epochSummaryBindings,
-	"exit/verify":               exitVerifyBindings,
-	"node/events":               nodeEventsBindings,
-	"proposer/duties":           proposerDutiesBindings,
-	"slot/time":                 slotTimeBindings,
-	"synccommittee/inclusion":   synccommitteeInclusionBindings,
-	"synccommittee/members":     synccommitteeMembersBindings,
-	"validator/credentials/get": validatorCredentialsGetBindings,
-	"validator/credentials/set": validatorCredentialsSetBindings,
-	"validator/depositdata":     validatorDepositdataBindings,
-	"validator/duties":          validatorDutiesBindings,
-	"validator/exit":            validatorExitBindings,
-	"validator/info":            validatorInfoBindings,
-	"validator/keycheck":        validatorKeycheckBindings,
-	"validator/summary":         validatorSummaryBindings,
-	"validator/yield":           validatorYieldBindings,
-	"validator/expectation":     validatorExpectationBindings,
-	"validator/withdrawal":      validatorWithdrawalBindings,
-	"wallet/batch":              walletBatchBindings,
-	"wallet/create":             walletCreateBindings,
-	"wallet/import":             walletImportBindings,
-	"wallet/sharedexport":       walletSharedExportBindings,
-	"wallet/sharedimport":       walletSharedImportBindings,
+	"epoch/summary":                epochSummaryBindings,
+	"exit/verify":                  exitVerifyBindings,
+	"mnemonic/recover":             mnemonicRecoverBindings,
+	"node/events":                  nodeEventsBindings,
+	"node/pool":                    nodePoolBindings,
+	"proposer/duties":              proposerDutiesBindings,
+	"serve/metrics":                serveMetricsBindings,
+	"slashing-protection/check":    slashingProtectionCheckBindings,
+	"slashing-protection/diff":     slashingProtectionDiffBindings,
+	"slashing-protection/merge":    slashingProtectionMergeBindings,
+	"slashing-protection/minify":   slashingProtectionMinifyBindings,
+	"slashing-protection/validate": slashingProtectionValidateBindings,
+	"slot/time":                    slotTimeBindings,
+	"synccommittee/inclusion":      synccommitteeInclusionBindings,
+	"synccommittee/members":        synccommitteeMembersBindings,
+	"validator/consolidate":        validatorConsolidateBindings,
+	"validator/credentials/get":    validatorCredentialsGetBindings,
+	"validator/credentials/set":    validatorCredentialsSetBindings,
+	"validator/depositdata":        validatorDepositdataBindings,
+	"validator/discover":           validatorDiscoverBindings,
+	"validator/doppelganger":       validatorDoppelgangerBindings,
+	"validator/duties":             validatorDutiesBindings,
+	"validator/exit":               validatorExitBindings,
+	"validator/info":               validatorInfoBindings,
+	"validator/monitor":            validatorMonitorBindings,
+	"validator/operation/status":   validatorOperationStatusBindings,
+	"validator/keycheck":           validatorKeycheckBindings,
+	"validator/rewards":            validatorRewardsBindings,
+	"validator/summary":            validatorSummaryBindings,
+	"validator/yield":              validatorYieldBindings,
+	"validator/expectation":        validatorExpectationBindings,
+	"validator/withdrawal":         validatorWithdrawalBindings,
+	"validator/withdrawal-request": validatorWithdrawalRequestBindings,
+	"validator/queue-position":     validatorQueuePositionBindings,
+	"wallet/batch":                 walletBatchBindings,
+	"wallet/create":                walletCreateBindings,
+	"wallet/import":                walletImportBindings,
+	"wallet/sharedexport":          walletSharedExportBindings,
+	"wallet/sharedimport":          walletSharedImportBindings,
+}
+
+// formatCommands are the commands that generate their output with
+// util.FormatOutput, and so support --format and --template.
+var formatCommands = map[string]bool{
+	"attester/duties":              true,
+	"block/analyze":                true,
+	"block/info":                   true,
+	"block/trail":                  true,
+	"cache/info":                   true,
+	"cache/prune":                  true,
+	"chain/eth1votes":              true,
+	"chain/forks":                  true,
+	"chain/queues":                 true,
+	"chain/spec":                   true,
+	"epoch/summary":                true,
+	"node/pool":                    true,
+	"proposer/duties":              true,
+	"validator/discover":           true,
+	"validator/doppelganger":       true,
+	"validator/expectation":        true,
+	"validator/info":               true,
+	"validator/operation/status":   true,
+	"validator/queue-position":     true,
+	"validator/rewards":            true,
+	"validator/summary":            true,
+	"validator/withdrawal":         true,
+	"validator/withdrawal-request": true,
+	"validator/yield":              true,
+}
+
+// quorumCommands are the commands that check their critical information
+// with util.RequireQuorum, and so support --connection-quorum.
+var quorumCommands = map[string]bool{
+	"chain/status":              true,
+	"validator/consolidate":     true,
+	"validator/credentials/set": true,
+	"validator/exit":            true,
+}
+
+// networkConfigCommands are the commands that obtain their chain configuration
+// with util.NetworkConfigFromConfig, and so support --network and --network-config.
+var networkConfigCommands = map[string]bool{
+	"chain/forks":           true,
+	"chain/time":            true,
+	"slot/time":             true,
+	"validator/consolidate": true,
+	"validator/depositdata": true,
+	"validator/exit":        true,
 }
 
 func persistentPreRunE(cmd *cobra.Command, _ []string) error {
@@ -115,14 +185,35 @@ func persistentPreRunE(cmd *cobra.Command, _ []string) error {
 		fmt.Println("Cannot supply both quiet and debug flags")
 	}
 
+	if err := util.SetupOutputFormat(formatCommands[commandPath(cmd)]); err != nil {
+		return util.ErrorWithCode(err, util.ErrorCodeInvalidInput, nil)
+	}
+
+	if err := util.SetupConnectionQuorum(quorumCommands[commandPath(cmd)]); err != nil {
+		return util.ErrorWithCode(err, util.ErrorCodeInvalidInput, nil)
+	}
+
+	if err := util.SetupNetworkConfig(networkConfigCommands[commandPath(cmd)]); err != nil {
+		return util.ErrorWithCode(err, util.ErrorCodeInvalidInput, nil)
+	}
+
 	return util.SetupStore()
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := RootCmd.Execute(); err != nil {
-		os.Exit(_exitFailure)
+	cmd, err := RootCmd.ExecuteC()
+	if err != nil {
+		if util.OutputFormat() == util.OutputFormatJSON {
+			fmt.Fprintln(os.Stderr, util.FormatError(err))
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+			if !cmd.SilenceUsage {
+				fmt.Fprintln(os.Stderr, cmd.UsageString())
+			}
+		}
+		os.Exit(util.ExitCode(err))
 	}
 }
 
@@ -135,6 +226,9 @@ func init() {
 
 	cobra.OnInitialize(initConfig)
 	addPersistentFlags()
+	RootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return util.ErrorWithCode(err, util.ErrorCodeInvalidInput, nil)
+	})
 }
 
 func addPersistentFlags() {
@@ -217,14 +311,74 @@ func addPersistentFlags() {
 	if err := viper.BindPFlag("json", RootCmd.PersistentFlags().Lookup("json")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().String("format", "", "output format for commands that generate structured data: text, json, yaml or csv (default text)")
+	if err := viper.BindPFlag("format", RootCmd.PersistentFlags().Lookup("format")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("template", "", "Go text/template with which to format output for commands that generate structured data, referencing fields by their JSON names")
+	if err := viper.BindPFlag("template", RootCmd.PersistentFlags().Lookup("template")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().Bool("debug", false, "generate debug output")
 	if err := viper.BindPFlag("debug", RootCmd.PersistentFlags().Lookup("debug")); err != nil {
 		panic(err)
 	}
-	RootCmd.PersistentFlags().String("connection", "", "URL to an Ethereum 2 node's REST API endpoint")
+	RootCmd.PersistentFlags().String("connection", "", "URL to an Ethereum 2 node's REST API endpoint.  Multiple comma-separated URLs can be supplied for failover")
 	if err := viper.BindPFlag("connection", RootCmd.PersistentFlags().Lookup("connection")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().Int("connection-quorum", 0, "the number of beacon nodes that must return matching data for critical information when multiple connections are supplied (0 to disable)")
+	if err := viper.BindPFlag("connection-quorum", RootCmd.PersistentFlags().Lookup("connection-quorum")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().StringArray("connection-headers", nil, `additional HTTP header to send to beacon nodes supplied with --connection, in the form "name: value".  Can be supplied multiple times`)
+	if err := viper.BindPFlag("connection-headers", RootCmd.PersistentFlags().Lookup("connection-headers")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("connection-client-cert", "", "location of a client certificate file when connecting to beacon nodes supplied with --connection")
+	if err := viper.BindPFlag("connection-client-cert", RootCmd.PersistentFlags().Lookup("connection-client-cert")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("connection-client-key", "", "location of a client key file when connecting to beacon nodes supplied with --connection")
+	if err := viper.BindPFlag("connection-client-key", RootCmd.PersistentFlags().Lookup("connection-client-key")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("connection-ca-cert", "", "location of the certificate authority certificate bundle when connecting to beacon nodes supplied with --connection")
+	if err := viper.BindPFlag("connection-ca-cert", RootCmd.PersistentFlags().Lookup("connection-ca-cert")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Bool("cache", false, "cache finalized beacon node data on disk, to speed up repeated historical queries")
+	if err := viper.BindPFlag("cache", RootCmd.PersistentFlags().Lookup("cache")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Int64("cache-max-size", 1024, "maximum size of the on-disk cache, in MiB")
+	if err := viper.BindPFlag("cache-max-size", RootCmd.PersistentFlags().Lookup("cache-max-size")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("record", "", "directory in which to record all beacon node responses")
+	if err := viper.BindPFlag("record", RootCmd.PersistentFlags().Lookup("record")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("replay", "", "directory from which to replay beacon node responses previously stored with --record, rather than contacting a beacon node")
+	if err := viper.BindPFlag("replay", RootCmd.PersistentFlags().Lookup("replay")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("network", "", "name of a built-in network (mainnet, sepolia, holesky, hoodi) to use for chain configuration rather than contacting a beacon node, where supported")
+	if err := viper.BindPFlag("network", RootCmd.PersistentFlags().Lookup("network")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("network-config", "", "path to a consensus network config.yaml to use for chain configuration rather than contacting a beacon node, where supported")
+	if err := viper.BindPFlag("network-config", RootCmd.PersistentFlags().Lookup("network-config")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("network-genesis-time", "", "genesis time of the network, as a Unix timestamp (required with --network-config)")
+	if err := viper.BindPFlag("network-genesis-time", RootCmd.PersistentFlags().Lookup("network-genesis-time")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("network-genesis-validators-root", "", "genesis validators root of the network (required with --network-config)")
+	if err := viper.BindPFlag("network-genesis-validators-root", RootCmd.PersistentFlags().Lookup("network-genesis-validators-root")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().Duration("timeout", 30*time.Second, "the time after which a network request will be considered failed.  Increase this if you are running on an error-prone, high-latency or low-bandwidth connection")
 	if err := viper.BindPFlag("timeout", RootCmd.PersistentFlags().Lookup("timeout")); err != nil {
 		panic(err)
@@ -307,8 +461,8 @@ func walletFromPath(ctx context.Context, path string) (e2wtypes.Wallet, error) {
 		return nil, err
 	}
 	if viper.GetString("remote") != "" {
-		assert(viper.GetString("client-cert") != "", "remote connections require client-cert")
-		assert(viper.GetString("client-key") != "", "remote connections require client-key")
+		assertInput(viper.GetString("client-cert") != "", "remote connections require client-cert")
+		assertInput(viper.GetString("client-key") != "", "remote connections require client-key")
 		credentials, err := dirk.ComposeCredentials(ctx, viper.GetString("client-cert"), viper.GetString("client-key"), viper.GetString("server-ca-cert"))
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to build dirk credentials")
@@ -356,13 +510,13 @@ func walletAndAccountFromPath(ctx context.Context, path string) (e2wtypes.Wallet
 	}
 
 	if wallet.Type() == "hierarchical deterministic" && strings.HasPrefix(accountName, "m/") {
-		assert(getWalletPassphrase() != "", "--walletpassphrase is required for direct path derivations")
+		assertInput(getWalletPassphrase() != "", "--walletpassphrase is required for direct path derivations")
 
 		locker, isLocker := wallet.(e2wtypes.WalletLocker)
 		if isLocker {
 			err = locker.Unlock(ctx, []byte(util.GetWalletPassphrase()))
 			if err != nil {
-				return nil, nil, errors.New("failed to unlock wallet")
+				return nil, nil, util.NewError(util.ErrorCodeInvalidPassphrase, "failed to unlock wallet")
 			}
 			defer relockAccount(locker)
 		}
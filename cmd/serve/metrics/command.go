@@ -0,0 +1,90 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servemetrics
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+	validatorsummary "github.com/wealdtech/ethdo/cmd/validator/summary"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	validators    []string
+	listenAddress string
+
+	// Data access.
+	eth2Client         eth2client.Service
+	chainTime          chaintime.Service
+	validatorsProvider eth2client.ValidatorsProvider
+	spec               map[string]any
+	// summarise obtains the summary for an epoch; replaceable for testing.
+	summarise func(ctx context.Context, epoch phase0.Epoch) (*validatorsummary.EpochSummary, error)
+
+	// Metrics.
+	registry *prometheus.Registry
+	metrics  *metrics
+
+	// Processing.
+	maxSeedLookahead           phase0.Epoch
+	shardCommitteePeriod       phase0.Epoch
+	maxPendingDepositsPerEpoch uint64
+	minActivationBalance       phase0.Gwei
+	summarised                 bool
+	summarisedEpoch            phase0.Epoch
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		validators:               viper.GetStringSlice("validators"),
+		listenAddress:            viper.GetString("listen-address"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if len(c.validators) == 0 {
+		return nil, errors.New("validators are required")
+	}
+
+	if c.listenAddress == "" {
+		return nil, errors.New("listen address is required")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,194 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servemetrics
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "ethdo"
+	metricsSubsystem = "validator"
+)
+
+// metrics are the metrics exposed by the command.
+type metrics struct {
+	// Per-validator state, updated every epoch.
+	balance                    *prometheus.GaugeVec
+	effectiveBalance           *prometheus.GaugeVec
+	state                      *prometheus.GaugeVec
+	activationEligibilityEpoch *prometheus.GaugeVec
+	activationEpoch            *prometheus.GaugeVec
+	exitEpoch                  *prometheus.GaugeVec
+	withdrawableEpoch          *prometheus.GaugeVec
+
+	// Estimated queue positions, updated every epoch.
+	activationQueueEpoch *prometheus.GaugeVec
+	exitQueueEpoch       *prometheus.GaugeVec
+
+	// Performance in the most recently summarised epoch.
+	summaryEpoch            prometheus.Gauge
+	activeValidators        prometheus.Gauge
+	participatingValidators prometheus.Gauge
+	attestations            *prometheus.GaugeVec
+
+	// Performance over all summarised epochs.
+	inclusionDelay prometheus.Histogram
+	faults         *prometheus.CounterVec
+	proposals      *prometheus.CounterVec
+	syncCommittee  *prometheus.CounterVec
+
+	updateErrors prometheus.Counter
+}
+
+func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		balance: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "balance_gwei",
+			Help:      "The balance of the validator, in Gwei.",
+		}, []string{"validator"}),
+		effectiveBalance: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "effective_balance_gwei",
+			Help:      "The effective balance of the validator, in Gwei.",
+		}, []string{"validator"}),
+		state: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "state",
+			Help:      "The state of the validator; 1 for its current state.",
+		}, []string{"validator", "state"}),
+		activationEligibilityEpoch: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "activation_eligibility_epoch",
+			Help:      "The epoch at which the validator became eligible for activation.",
+		}, []string{"validator"}),
+		activationEpoch: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "activation_epoch",
+			Help:      "The epoch at which the validator is activated, once it has passed through the activation queue.",
+		}, []string{"validator"}),
+		exitEpoch: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "exit_epoch",
+			Help:      "The epoch at which the validator exits, once it has entered the exit queue.",
+		}, []string{"validator"}),
+		withdrawableEpoch: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "withdrawable_epoch",
+			Help:      "The epoch at which the validator's balance becomes withdrawable, once it has entered the exit queue.",
+		}, []string{"validator"}),
+		activationQueueEpoch: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "activation_queue_epoch",
+			Help:      "The estimated epoch at which the validator will be activated, whilst it is waiting to be scheduled for activation.",
+		}, []string{"validator"}),
+		exitQueueEpoch: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "exit_queue_epoch",
+			Help:      "The estimated epoch at which the validator would exit if it requested an exit now.",
+		}, []string{"validator"}),
+		summaryEpoch: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "summary_epoch",
+			Help:      "The most recently summarised epoch.",
+		}),
+		activeValidators: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "active",
+			Help:      "The number of active validators in the most recently summarised epoch.",
+		}),
+		participatingValidators: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "participating",
+			Help:      "The number of participating validators in the most recently summarised epoch.",
+		}),
+		attestations: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "attestations",
+			Help:      "The number of attestations in the most recently summarised epoch, by result.",
+		}, []string{"result"}),
+		inclusionDelay: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "attestation_inclusion_delay_slots",
+			Help:      "The number of slots between an attestation's slot and its inclusion on chain.",
+			Buckets:   []float64{1, 2, 3, 4, 5, 8, 16, 32},
+		}),
+		faults: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "faults_total",
+			Help:      "The number of faults for the validator, by type.",
+		}, []string{"validator", "type"}),
+		proposals: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "proposals_total",
+			Help:      "The number of block proposal duties for the validator, by result.",
+		}, []string{"validator", "result"}),
+		syncCommittee: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "sync_committee_contributions_total",
+			Help:      "The number of sync committee contributions expected from the validator, by result.",
+		}, []string{"validator", "result"}),
+		updateErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "metrics_update_errors_total",
+			Help:      "The number of failed attempts to update metrics.",
+		}),
+	}
+
+	for _, collector := range []prometheus.Collector{
+		m.balance,
+		m.effectiveBalance,
+		m.state,
+		m.activationEligibilityEpoch,
+		m.activationEpoch,
+		m.exitEpoch,
+		m.withdrawableEpoch,
+		m.activationQueueEpoch,
+		m.exitQueueEpoch,
+		m.summaryEpoch,
+		m.activeValidators,
+		m.participatingValidators,
+		m.attestations,
+		m.inclusionDelay,
+		m.faults,
+		m.proposals,
+		m.syncCommittee,
+		m.updateErrors,
+	} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, errors.Wrap(err, "failed to register metric")
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,428 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servemetrics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	validatorsummary "github.com/wealdtech/ethdo/cmd/validator/summary"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", c.listenAddress)
+	if err != nil {
+		return errors.Wrap(err, "failed to listen for metrics requests")
+	}
+	server := &http.Server{
+		Handler:           c.handler(),
+		ReadHeaderTimeout: c.timeout,
+	}
+	defer server.Close()
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.Serve(listener)
+	}()
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "Serving metrics on http://%s/metrics\n", listener.Addr())
+	}
+
+	for {
+		c.update(ctx)
+
+		// Update again at the start of the next epoch.
+		nextUpdate := c.chainTime.StartOfEpoch(c.chainTime.CurrentEpoch() + 1)
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-serverErr:
+			return errors.Wrap(err, "metrics server failed")
+		case <-time.After(time.Until(nextUpdate)):
+		}
+	}
+}
+
+// handler returns the HTTP handler for metrics requests.
+func (c *command) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(c.registry, promhttp.HandlerOpts{}))
+
+	return mux
+}
+
+// update updates the metrics.  Failures are reported but do not stop the
+// command, as the beacon node may be temporarily unavailable.
+func (c *command) update(ctx context.Context) {
+	validators, err := c.updateValidators(ctx)
+	if err != nil {
+		c.updateFailed(errors.Wrap(err, "failed to update validators"))
+	} else if err := c.updateQueues(ctx, validators); err != nil {
+		c.updateFailed(errors.Wrap(err, "failed to update queues"))
+	}
+	if err := c.updateSummaries(ctx); err != nil {
+		c.updateFailed(errors.Wrap(err, "failed to update summaries"))
+	}
+}
+
+func (c *command) updateFailed(err error) {
+	c.metrics.updateErrors.Inc()
+	if !c.quiet {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+	}
+}
+
+// updateValidators updates the metrics for the current state of the validators,
+// returning the validators.
+func (c *command) updateValidators(ctx context.Context) ([]*apiv1.Validator, error) {
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+	if err != nil {
+		return nil, err
+	}
+
+	// Reset the state metrics so that those no longer applicable, for example
+	// for validators that can no longer be found, are removed.
+	c.metrics.balance.Reset()
+	c.metrics.effectiveBalance.Reset()
+	c.metrics.state.Reset()
+	c.metrics.activationEligibilityEpoch.Reset()
+	c.metrics.activationEpoch.Reset()
+	c.metrics.exitEpoch.Reset()
+	c.metrics.withdrawableEpoch.Reset()
+
+	for _, validator := range validators {
+		label := validatorLabel(validator.Index)
+		c.metrics.balance.WithLabelValues(label).Set(float64(validator.Balance))
+		c.metrics.state.WithLabelValues(label, validator.Status.String()).Set(1)
+		if validator.Validator == nil {
+			continue
+		}
+		c.metrics.effectiveBalance.WithLabelValues(label).Set(float64(validator.Validator.EffectiveBalance))
+		for gauge, epoch := range map[*prometheus.GaugeVec]phase0.Epoch{
+			c.metrics.activationEligibilityEpoch: validator.Validator.ActivationEligibilityEpoch,
+			c.metrics.activationEpoch:            validator.Validator.ActivationEpoch,
+			c.metrics.exitEpoch:                  validator.Validator.ExitEpoch,
+			c.metrics.withdrawableEpoch:          validator.Validator.WithdrawableEpoch,
+		} {
+			if epoch != farFutureEpoch {
+				gauge.WithLabelValues(label).Set(float64(epoch))
+			}
+		}
+	}
+
+	return validators, nil
+}
+
+// updateQueues updates the estimated queue positions of the validators that
+// have not yet been scheduled for activation or exit.
+func (c *command) updateQueues(ctx context.Context, validators []*apiv1.Validator) error {
+	// Reset the queue metrics so that validators that have left the queues are removed.
+	c.metrics.activationQueueEpoch.Reset()
+	c.metrics.exitQueueEpoch.Reset()
+
+	// Queue positions follow the balance-based churn introduced in Electra.
+	currentEpoch := c.chainTime.CurrentEpoch()
+	electraActive := false
+	for _, fork := range c.chainTime.Forks() {
+		if fork.Name == "electra" && fork.Epoch <= currentEpoch {
+			electraActive = true
+		}
+	}
+	if !electraActive {
+		return nil
+	}
+
+	response, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{
+		State: "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+	totalActiveBalance := phase0.Gwei(0)
+	for _, validator := range response.Data {
+		if validator.Validator == nil {
+			continue
+		}
+		if validator.Validator.ActivationEpoch <= currentEpoch && validator.Validator.ExitEpoch > currentEpoch {
+			totalActiveBalance += validator.Validator.EffectiveBalance
+		}
+	}
+	churn := util.CalculateChurnLimits(c.spec, totalActiveBalance).ActivationExit
+	exitQueue := util.NewExitQueue(response.Data, churn)
+
+	var depositBalances map[phase0.BLSPubKey]map[phase0.Epoch]phase0.Gwei
+	for _, validator := range validators {
+		if validator.Validator == nil {
+			continue
+		}
+		label := validatorLabel(validator.Index)
+		switch {
+		case validator.Validator.ExitEpoch != farFutureEpoch:
+			// Already scheduled to exit.
+		case validator.Validator.ActivationEpoch != farFutureEpoch:
+			// Each estimate is for an exit requested on its own.
+			queue := *exitQueue
+			exitEpoch := queue.Exit(validator.Validator.EffectiveBalance, currentEpoch+1+c.maxSeedLookahead)
+			exitEpoch = max(exitEpoch, validator.Validator.ActivationEpoch+c.shardCommitteePeriod)
+			c.metrics.exitQueueEpoch.WithLabelValues(label).Set(float64(exitEpoch))
+		case validator.Validator.ActivationEligibilityEpoch != farFutureEpoch:
+			// Activated once its eligibility has finalized.
+			activationEpoch := max(validator.Validator.ActivationEligibilityEpoch+util.FinalityDelay, currentEpoch) + 1 + c.maxSeedLookahead
+			c.metrics.activationQueueEpoch.WithLabelValues(label).Set(float64(activationEpoch))
+		default:
+			// Eligible once its pending deposits bring it up to the activation balance.
+			if depositBalances == nil {
+				depositBalances, err = c.pendingDepositBalances(ctx, currentEpoch, churn)
+				if err != nil {
+					return err
+				}
+			}
+			if epoch, found := c.eligibilityEpoch(validator.Validator.EffectiveBalance, depositBalances[validator.Validator.PublicKey]); found {
+				c.metrics.activationQueueEpoch.WithLabelValues(label).Set(float64(epoch + util.FinalityDelay + 1 + c.maxSeedLookahead))
+			}
+		}
+	}
+
+	return nil
+}
+
+// pendingDepositBalances obtains the balance of pending deposits for each
+// public key, by the epoch in which they are expected to be processed.
+func (c *command) pendingDepositBalances(ctx context.Context,
+	currentEpoch phase0.Epoch,
+	churn phase0.Gwei,
+) (
+	map[phase0.BLSPubKey]map[phase0.Epoch]phase0.Gwei,
+	error,
+) {
+	provider, isProvider := c.eth2Client.(eth2client.PendingDepositProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide pending deposits")
+	}
+	response, err := provider.PendingDeposits(ctx, &api.PendingDepositsOpts{
+		State: "head",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain pending deposits")
+	}
+
+	res := make(map[phase0.BLSPubKey]map[phase0.Epoch]phase0.Gwei)
+	epochs := util.PendingDepositEpochs(response.Data, currentEpoch, churn, c.maxPendingDepositsPerEpoch, c.chainTime.SlotToEpoch)
+	for i, epoch := range epochs {
+		deposit := response.Data[i]
+		if _, exists := res[deposit.Pubkey]; !exists {
+			res[deposit.Pubkey] = make(map[phase0.Epoch]phase0.Gwei)
+		}
+		res[deposit.Pubkey][epoch] += deposit.Amount
+	}
+
+	return res, nil
+}
+
+// eligibilityEpoch returns the epoch after which a validator's pending
+// deposits bring its balance up to the activation balance, if they do.
+func (c *command) eligibilityEpoch(balance phase0.Gwei, deposits map[phase0.Epoch]phase0.Gwei) (phase0.Epoch, bool) {
+	epochs := make([]phase0.Epoch, 0, len(deposits))
+	for epoch := range deposits {
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+
+	for _, epoch := range epochs {
+		balance += deposits[epoch]
+		if balance >= c.minActivationBalance {
+			// Eligibility is set in the epoch after the deposit is processed.
+			return epoch + 1, true
+		}
+	}
+
+	return 0, false
+}
+
+// updateSummaries updates the metrics for all epochs that can be summarised
+// but have not yet been.
+func (c *command) updateSummaries(ctx context.Context) error {
+	// Attestations for an epoch can be included up to the first slot of the
+	// next-but-one epoch, so that is the point at which it can be summarised.
+	currentEpoch := c.chainTime.CurrentEpoch()
+	if currentEpoch < 2 {
+		return nil
+	}
+	toEpoch := currentEpoch - 2
+
+	fromEpoch := toEpoch
+	if c.summarised {
+		fromEpoch = c.summarisedEpoch + 1
+	}
+
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Summarising epoch %d\n", epoch)
+		}
+		summary, err := c.summarise(ctx, epoch)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to summarise epoch %d", epoch))
+		}
+		c.recordSummary(summary)
+		c.summarised = true
+		c.summarisedEpoch = epoch
+	}
+
+	return nil
+}
+
+// recordSummary records the metrics for an epoch summary.
+func (c *command) recordSummary(summary *validatorsummary.EpochSummary) {
+	c.metrics.summaryEpoch.Set(float64(summary.Epoch))
+	c.metrics.activeValidators.Set(float64(summary.ActiveValidators))
+	c.metrics.participatingValidators.Set(float64(summary.ParticipatingValidators))
+	if summary.Attestations != nil {
+		c.metrics.attestations.WithLabelValues("expected").Set(float64(summary.Attestations.Expected))
+		c.metrics.attestations.WithLabelValues("included").Set(float64(summary.Attestations.Included))
+		c.metrics.attestations.WithLabelValues("correct_head").Set(float64(summary.Attestations.CorrectHead))
+		c.metrics.attestations.WithLabelValues("timely_head").Set(float64(summary.Attestations.TimelyHead))
+		c.metrics.attestations.WithLabelValues("correct_target").Set(float64(summary.Attestations.CorrectTarget))
+		c.metrics.attestations.WithLabelValues("timely_target").Set(float64(summary.Attestations.TimelyTarget))
+		c.metrics.attestations.WithLabelValues("timely_source").Set(float64(summary.Attestations.TimelySource))
+	}
+
+	for delay, count := range summary.InclusionDelays {
+		for range count {
+			c.metrics.inclusionDelay.Observe(float64(delay))
+		}
+	}
+
+	for _, fault := range summary.Faults {
+		c.metrics.faults.WithLabelValues(validatorLabel(fault.ValidatorIndex), fault.Type).Inc()
+	}
+
+	for _, proposal := range summary.Proposals {
+		result := "proposed"
+		if !proposal.Proposed {
+			result = "missed"
+		}
+		c.metrics.proposals.WithLabelValues(validatorLabel(proposal.ValidatorIndex), result).Inc()
+	}
+
+	for _, syncCommittee := range summary.SyncCommittee {
+		label := validatorLabel(syncCommittee.ValidatorIndex)
+		c.metrics.syncCommittee.WithLabelValues(label, "included").Add(float64(syncCommittee.Expected - syncCommittee.Missed))
+		c.metrics.syncCommittee.WithLabelValues(label, "missed").Add(float64(syncCommittee.Missed))
+	}
+}
+
+func validatorLabel(index phase0.ValidatorIndex) string {
+	return fmt.Sprintf("%d", index)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	if err := c.obtainSpecValues(ctx); err != nil {
+		return err
+	}
+
+	c.summarise = func(ctx context.Context, epoch phase0.Epoch) (*validatorsummary.EpochSummary, error) {
+		return validatorsummary.Summarise(ctx, c.eth2Client, c.chainTime, c.validators, epoch)
+	}
+
+	return c.setupMetrics()
+}
+
+// obtainSpecValues obtains the values from the spec required to estimate
+// queue positions, falling back to mainnet values if they are unavailable.
+func (c *command) obtainSpecValues(ctx context.Context) error {
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	c.spec = specResponse.Data
+
+	c.maxSeedLookahead = 4
+	if val, exists := c.spec["MAX_SEED_LOOKAHEAD"].(uint64); exists {
+		c.maxSeedLookahead = phase0.Epoch(val)
+	}
+	c.shardCommitteePeriod = 256
+	if val, exists := c.spec["SHARD_COMMITTEE_PERIOD"].(uint64); exists {
+		c.shardCommitteePeriod = phase0.Epoch(val)
+	}
+	c.maxPendingDepositsPerEpoch = 16
+	if val, exists := c.spec["MAX_PENDING_DEPOSITS_PER_EPOCH"].(uint64); exists {
+		c.maxPendingDepositsPerEpoch = val
+	}
+	c.minActivationBalance = 32000000000
+	if val, exists := c.spec["MIN_ACTIVATION_BALANCE"].(uint64); exists {
+		c.minActivationBalance = phase0.Gwei(val)
+	}
+
+	return nil
+}
+
+// setupMetrics sets up the metrics registry.
+func (c *command) setupMetrics() error {
+	var err error
+
+	c.registry = prometheus.NewRegistry()
+	c.metrics, err = newMetrics(c.registry)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,308 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servemetrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/mock"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	validatorsummary "github.com/wealdtech/ethdo/cmd/validator/summary"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+)
+
+// newTestCommand creates a command backed by a mock beacon node whose chain
+// is in epoch 12, with Electra active.
+func newTestCommand(t *testing.T) *command {
+	t.Helper()
+	ctx := context.Background()
+
+	client, err := mock.New(ctx)
+	require.NoError(t, err)
+	genesisTime := time.Now().Add(-12*32*12*time.Second - 6*time.Second)
+	client.GenesisFunc = func(context.Context, *api.GenesisOpts) (*api.Response[*apiv1.Genesis], error) {
+		return &api.Response[*apiv1.Genesis]{
+			Data: &apiv1.Genesis{
+				GenesisTime: genesisTime,
+			},
+			Metadata: make(map[string]any),
+		}, nil
+	}
+	spec := map[string]any{
+		"SECONDS_PER_SLOT":     time.Second * 12,
+		"SLOTS_PER_EPOCH":      uint64(32),
+		"ELECTRA_FORK_EPOCH":   uint64(0),
+		"ELECTRA_FORK_VERSION": phase0.Version{0x05, 0x00, 0x00, 0x00},
+	}
+	client.SpecFunc = func(context.Context, *api.SpecOpts) (*api.Response[map[string]any], error) {
+		return &api.Response[map[string]any]{
+			Data:     spec,
+			Metadata: make(map[string]any),
+		}, nil
+	}
+	client.PendingDepositsFunc = func(context.Context, *api.PendingDepositsOpts) (*api.Response[[]*electra.PendingDeposit], error) {
+		return &api.Response[[]*electra.PendingDeposit]{
+			Data: []*electra.PendingDeposit{
+				{Pubkey: phase0.BLSPubKey{0x04}, Amount: 16000000000},
+				{Pubkey: phase0.BLSPubKey{0x05}, Amount: 32000000000},
+				{Pubkey: phase0.BLSPubKey{0x04}, Amount: 16000000000},
+			},
+			Metadata: make(map[string]any),
+		}, nil
+	}
+	client.ValidatorsFunc = func(_ context.Context, opts *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator], error) {
+		validators := map[phase0.ValidatorIndex]*apiv1.Validator{
+			1: {
+				Index:   1,
+				Balance: 32001000000,
+				Status:  apiv1.ValidatorStateActiveOngoing,
+				Validator: &phase0.Validator{
+					EffectiveBalance:           32000000000,
+					ActivationEligibilityEpoch: 0,
+					ActivationEpoch:            0,
+					ExitEpoch:                  farFutureEpoch,
+					WithdrawableEpoch:          farFutureEpoch,
+				},
+			},
+			2: {
+				Index:   2,
+				Balance: 32000000000,
+				Status:  apiv1.ValidatorStatePendingQueued,
+				Validator: &phase0.Validator{
+					EffectiveBalance:           32000000000,
+					ActivationEligibilityEpoch: 10,
+					ActivationEpoch:            15,
+					ExitEpoch:                  farFutureEpoch,
+					WithdrawableEpoch:          farFutureEpoch,
+				},
+			},
+			3: {
+				Index:   3,
+				Balance: 32000000000,
+				Status:  apiv1.ValidatorStatePendingQueued,
+				Validator: &phase0.Validator{
+					EffectiveBalance:           32000000000,
+					ActivationEligibilityEpoch: 11,
+					ActivationEpoch:            farFutureEpoch,
+					ExitEpoch:                  farFutureEpoch,
+					WithdrawableEpoch:          farFutureEpoch,
+				},
+			},
+			4: {
+				Index:   4,
+				Balance: 0,
+				Status:  apiv1.ValidatorStatePendingInitialized,
+				Validator: &phase0.Validator{
+					PublicKey:                  phase0.BLSPubKey{0x04},
+					ActivationEligibilityEpoch: farFutureEpoch,
+					ActivationEpoch:            farFutureEpoch,
+					ExitEpoch:                  farFutureEpoch,
+					WithdrawableEpoch:          farFutureEpoch,
+				},
+			},
+			// Not monitored, but consumes the exit churn for epoch 20.
+			5: {
+				Index:   5,
+				Balance: 128000000000,
+				Status:  apiv1.ValidatorStateActiveExiting,
+				Validator: &phase0.Validator{
+					EffectiveBalance:           128000000000,
+					ActivationEligibilityEpoch: 0,
+					ActivationEpoch:            0,
+					ExitEpoch:                  20,
+					WithdrawableEpoch:          276,
+				},
+			},
+		}
+		if len(opts.Indices) > 0 {
+			res := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+			for _, index := range opts.Indices {
+				if validator, exists := validators[index]; exists {
+					res[index] = validator
+				}
+			}
+			validators = res
+		}
+
+		return &api.Response[map[phase0.ValidatorIndex]*apiv1.Validator]{
+			Data:     validators,
+			Metadata: make(map[string]any),
+		}, nil
+	}
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(client),
+		standardchaintime.WithSpecProvider(client),
+	)
+	require.NoError(t, err)
+
+	c := &command{
+		quiet:                      true,
+		validators:                 []string{"1-4"},
+		eth2Client:                 client,
+		chainTime:                  chainTime,
+		validatorsProvider:         client,
+		spec:                       spec,
+		maxSeedLookahead:           4,
+		shardCommitteePeriod:       1,
+		maxPendingDepositsPerEpoch: 16,
+		minActivationBalance:       32000000000,
+	}
+	require.NoError(t, c.setupMetrics())
+
+	return c
+}
+
+// scrape fetches the metrics from the command's handler.
+func scrape(t *testing.T, c *command) string {
+	t.Helper()
+
+	server := httptest.NewServer(c.handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	c := newTestCommand(t)
+	summarised := make([]phase0.Epoch, 0)
+	c.summarise = func(_ context.Context, epoch phase0.Epoch) (*validatorsummary.EpochSummary, error) {
+		summarised = append(summarised, epoch)
+
+		return &validatorsummary.EpochSummary{
+			Epoch:                   epoch,
+			ActiveValidators:        1,
+			ParticipatingValidators: 1,
+			Attestations: &validatorsummary.AttestationTotals{
+				Expected:      1,
+				Included:      1,
+				CorrectHead:   1,
+				TimelyHead:    0,
+				CorrectTarget: 1,
+				TimelyTarget:  1,
+				TimelySource:  1,
+			},
+			InclusionDelays: map[phase0.Slot]int{2: 1},
+			Proposals: []*validatorsummary.Proposal{
+				{Slot: phase0.Slot(epoch * 32), ValidatorIndex: 1, Proposed: false},
+			},
+			SyncCommittee: []*validatorsummary.SyncCommitteeParticipation{
+				{ValidatorIndex: 1, Expected: 32, Missed: 2},
+			},
+			Faults: []*validatorsummary.Fault{
+				{Epoch: epoch, ValidatorIndex: 1, Type: validatorsummary.FaultUntimelyHead},
+				{Epoch: epoch, ValidatorIndex: 1, Type: validatorsummary.FaultMissedProposal},
+			},
+		}, nil
+	}
+
+	c.update(ctx)
+	require.Equal(t, []phase0.Epoch{10}, summarised)
+
+	res := scrape(t, c)
+	require.Contains(t, res, `ethdo_validator_balance_gwei{validator="1"} 3.2001e+10`)
+	require.Contains(t, res, `ethdo_validator_effective_balance_gwei{validator="2"} 3.2e+10`)
+	require.Contains(t, res, `ethdo_validator_state{state="active_ongoing",validator="1"} 1`)
+	require.Contains(t, res, `ethdo_validator_state{state="pending_queued",validator="2"} 1`)
+	require.Contains(t, res, `ethdo_validator_activation_epoch{validator="2"} 15`)
+	require.NotContains(t, res, `ethdo_validator_exit_epoch{`)
+	// Exits are queued behind the exit of validator 5 in epoch 20.
+	require.Contains(t, res, `ethdo_validator_exit_queue_epoch{validator="1"} 21`)
+	require.Contains(t, res, `ethdo_validator_exit_queue_epoch{validator="2"} 21`)
+	require.NotContains(t, res, `ethdo_validator_exit_queue_epoch{validator="3"}`)
+	// Validator 3 is eligible; validator 4 becomes eligible once its second deposit is processed in epoch 13.
+	require.Contains(t, res, `ethdo_validator_activation_queue_epoch{validator="3"} 18`)
+	require.Contains(t, res, `ethdo_validator_activation_queue_epoch{validator="4"} 21`)
+	require.NotContains(t, res, `ethdo_validator_activation_queue_epoch{validator="1"}`)
+	require.Contains(t, res, `ethdo_validator_summary_epoch 10`)
+	require.Contains(t, res, `ethdo_validator_attestations{result="timely_head"} 0`)
+	require.Contains(t, res, `ethdo_validator_attestations{result="correct_target"} 1`)
+	require.Contains(t, res, `ethdo_validator_attestation_inclusion_delay_slots_bucket{le="2"} 1`)
+	require.Contains(t, res, `ethdo_validator_faults_total{type="untimely_head",validator="1"} 1`)
+	require.Contains(t, res, `ethdo_validator_proposals_total{result="missed",validator="1"} 1`)
+	require.Contains(t, res, `ethdo_validator_sync_committee_contributions_total{result="included",validator="1"} 30`)
+	require.Contains(t, res, `ethdo_validator_sync_committee_contributions_total{result="missed",validator="1"} 2`)
+
+	// Summarised epochs are not summarised again.
+	c.update(ctx)
+	require.Equal(t, []phase0.Epoch{10}, summarised)
+
+	// Epochs missed whilst summarisation was failing are caught up.
+	c.summarisedEpoch = 8
+	c.update(ctx)
+	require.Equal(t, []phase0.Epoch{10, 9, 10}, summarised)
+	require.Contains(t, scrape(t, c), `ethdo_validator_faults_total{type="untimely_head",validator="1"} 3`)
+}
+
+func TestUpdateFailure(t *testing.T) {
+	ctx := context.Background()
+
+	c := newTestCommand(t)
+	c.summarise = func(context.Context, phase0.Epoch) (*validatorsummary.EpochSummary, error) {
+		return nil, errors.New("unavailable")
+	}
+
+	c.update(ctx)
+	require.False(t, c.summarised)
+
+	res := scrape(t, c)
+	require.Contains(t, res, "ethdo_metrics_update_errors_total 1")
+	// Validator metrics are still available.
+	require.Contains(t, res, `ethdo_validator_balance_gwei{validator="1"} 3.2001e+10`)
+}
+
+func TestUpdateRemovesValidators(t *testing.T) {
+	ctx := context.Background()
+
+	c := newTestCommand(t)
+	c.summarise = func(_ context.Context, epoch phase0.Epoch) (*validatorsummary.EpochSummary, error) {
+		return &validatorsummary.EpochSummary{Epoch: epoch}, nil
+	}
+
+	c.update(ctx)
+	res := scrape(t, c)
+	require.Contains(t, res, `ethdo_validator_balance_gwei{validator="2"}`)
+	require.Contains(t, res, `ethdo_validator_effective_balance_gwei{validator="2"}`)
+	require.Contains(t, res, `ethdo_validator_exit_queue_epoch{validator="2"}`)
+
+	// Metrics for validators that are no longer found are removed.
+	c.validators = []string{"1"}
+	c.update(ctx)
+	res = scrape(t, c)
+	require.Contains(t, res, `ethdo_validator_balance_gwei{validator="1"}`)
+	require.NotContains(t, res, `ethdo_validator_balance_gwei{validator="2"}`)
+	require.NotContains(t, res, `ethdo_validator_effective_balance_gwei{validator="2"}`)
+	require.NotContains(t, res, `ethdo_validator_exit_queue_epoch{validator="2"}`)
+}
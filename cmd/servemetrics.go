@@ -0,0 +1,64 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	servemetrics "github.com/wealdtech/ethdo/cmd/serve/metrics"
+)
+
+var serveMetricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Serve Prometheus metrics for validator(s)",
+	Long: `Serve Prometheus metrics for one or more validators on a local HTTP port.  For example:
+
+    ethdo serve metrics --validators=1,2,3 --listen-address=localhost:8484
+
+Metrics are available at /metrics, and are updated at the start of each epoch.  They cover the balance, effective balance, state and scheduled activation and exit epochs of each validator, along with attestation correctness, inclusion delays, proposals and sync committee participation as calculated by "validator summary".
+
+The command runs until it is stopped.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := servemetrics.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	serveCmd.AddCommand(serveMetricsCmd)
+	serveFlags(serveMetricsCmd)
+	serveMetricsCmd.Flags().StringSlice("validators", nil, "the list of validators for which to serve metrics")
+	serveMetricsCmd.Flags().String("listen-address", "localhost:8484", "the address on which to serve metrics")
+}
+
+func serveMetricsBindings(cmd *cobra.Command) {
+	serveBindings()
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("listen-address", cmd.Flags().Lookup("listen-address")); err != nil {
+		panic(err)
+	}
+}
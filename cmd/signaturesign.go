@@ -36,21 +36,21 @@ var signatureSignCmd = &cobra.Command{
 
     ethdo signature sign --data=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7 --account="Personal wallet/Operations" --passphrase="my account passphrase"
 
-In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
+In quiet mode this will return 0 if the data can be signed, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 		defer cancel()
 
-		assert(viper.GetString("signature-data") != "", "--data is required")
+		assertInput(viper.GetString("signature-data") != "", "--data is required")
 		data, err := bytesutil.FromHexString(viper.GetString("signature-data"))
 		errCheck(err, "Failed to parse data")
-		assert(len(data) == 32, "data to sign must be 32 bytes")
+		assertInput(len(data) == 32, "data to sign must be 32 bytes")
 
 		domain := e2types.Domain(e2types.DomainType([4]byte{0, 0, 0, 0}), e2types.ZeroForkVersion, e2types.ZeroGenesisValidatorsRoot)
 		if viper.GetString("signature-domain") != "" {
 			domain, err = bytesutil.FromHexString(viper.GetString("signature-domain"))
 			errCheck(err, "Failed to parse domain")
-			assert(len(domain) == 32, "Domain data invalid")
+			assertInput(len(domain) == 32, "Domain data invalid")
 		}
 		outputIf(viper.GetBool("debug"), fmt.Sprintf("Domain is %#x", domain))
 
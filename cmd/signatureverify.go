@@ -41,17 +41,17 @@ var signatureVerifyCmd = &cobra.Command{
 
     ethdo signature verify --data=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7 --signature=0x8888... --account="Personal wallet/Operations"
 
-In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
+In quiet mode this will return 0 if the data can be signed, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 		defer cancel()
 
-		assert(viper.GetString("signature-data") != "", "--data is required")
+		assertInput(viper.GetString("signature-data") != "", "--data is required")
 		data, err := bytesutil.FromHexString(viper.GetString("signature-data"))
 		errCheck(err, "Failed to parse data")
-		assert(len(data) == 32, "data to verify must be 32 bytes")
+		assertInput(len(data) == 32, "data to verify must be 32 bytes")
 
-		assert(signatureVerifySignature != "", "--signature is required")
+		assertInput(signatureVerifySignature != "", "--signature is required")
 		signatureBytes, err := bytesutil.FromHexString(signatureVerifySignature)
 		errCheck(err, "Failed to parse signature")
 		signature, err := e2types.BLSSignatureFromBytes(signatureBytes)
@@ -61,7 +61,7 @@ In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 		if viper.GetString("signature-domain") != "" {
 			domain, err = bytesutil.FromHexString(viper.GetString("signature-domain"))
 			errCheck(err, "Failed to parse domain")
-			assert(len(domain) == 32, "Domain data invalid")
+			assertInput(len(domain) == 32, "Domain data invalid")
 		}
 
 		var account e2wtypes.Account
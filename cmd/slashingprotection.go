@@ -0,0 +1,35 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// slashingProtectionCmd represents the slashing protection command.
+var slashingProtectionCmd = &cobra.Command{
+	Use:   "slashing-protection",
+	Short: "Manage EIP-3076 slashing protection interchange files",
+	Long:  "Manage EIP-3076 slashing protection interchange files, as used to move slashing protection data between validator clients",
+}
+
+func init() {
+	RootCmd.AddCommand(slashingProtectionCmd)
+}
+
+func slashingProtectionFlags(_ *cobra.Command) {
+}
+
+func slashingProtectionBindings() {
+}
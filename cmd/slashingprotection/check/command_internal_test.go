@@ -0,0 +1,79 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectioncheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"file":     "slashing-protection.json",
+				"accounts": []string{"Validators/"},
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "FileMissing",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"accounts": []string{"Validators/"},
+			},
+			err: "file is required",
+		},
+		{
+			name: "AccountsMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    "slashing-protection.json",
+			},
+			err: "accounts are required",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"file":     "slashing-protection.json",
+				"accounts": []string{"Validators/", "0x99b1f1d84d76185466d86c34bde1101316afddae76217aa86cd066979b19858c2c9d9e56eebc1e067ac54277a61790db"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectioncheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+	if c.verbose {
+		for _, account := range c.checked {
+			if account.present {
+				builder.WriteString(fmt.Sprintf("%s: present in slashing protection data\n", account.name))
+			}
+		}
+	}
+
+	if len(c.problems) == 0 {
+		builder.WriteString(fmt.Sprintf("Slashing protection data covers all %d accounts", len(c.checked)))
+
+		return builder.String(), nil
+	}
+
+	builder.WriteString("Slashing protection data has problems:")
+	for _, problem := range c.problems {
+		builder.WriteString("\n  ")
+		builder.WriteString(problem)
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,145 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectioncheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	var err error
+	c.interchange, err = util.ReadInterchange(c.file)
+	if err != nil {
+		return err
+	}
+	c.problems = c.interchange.Validate()
+
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	genesisResponse, err := c.eth2Client.(eth2client.GenesisProvider).Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain genesis")
+	}
+	c.checkGenesisValidatorsRoot(genesisResponse.Data.GenesisValidatorsRoot)
+
+	return c.checkAccounts(ctx)
+}
+
+// checkGenesisValidatorsRoot checks the genesis validators root of the interchange.
+func (c *command) checkGenesisValidatorsRoot(root phase0.Root) {
+	if c.interchange.Metadata.GenesisValidatorsRoot != root {
+		c.problems = append(c.problems, fmt.Sprintf("genesis validators root %#x does not match that of the chain %#x", c.interchange.Metadata.GenesisValidatorsRoot, root))
+	}
+}
+
+// checkAccounts checks that the public key of each account is present in the interchange.
+func (c *command) checkAccounts(ctx context.Context) error {
+	pubkeys := make(map[phase0.BLSPubKey]bool)
+	for _, data := range c.interchange.Data {
+		pubkeys[data.Pubkey] = true
+	}
+
+	c.checked = make([]*checkedAccount, 0)
+	for _, accountStr := range c.accounts {
+		accounts, err := c.parseAccounts(ctx, accountStr)
+		if err != nil {
+			return err
+		}
+		for _, account := range accounts {
+			pubkey, err := util.BestPublicKey(account.account)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to obtain public key for %s", account.name))
+			}
+			checked := &checkedAccount{
+				name:    fmt.Sprintf("%#x", pubkey.Marshal()),
+				present: pubkeys[phase0.BLSPubKey(pubkey.Marshal())],
+			}
+			if account.name != "" {
+				checked.name = fmt.Sprintf("%s (%#x)", account.name, pubkey.Marshal())
+			}
+			c.checked = append(c.checked, checked)
+			if !checked.present {
+				c.problems = append(c.problems, fmt.Sprintf("%s: not present in slashing protection data", checked.name))
+			}
+		}
+	}
+
+	return nil
+}
+
+// namedAccount is an account with the name by which it is reported.
+type namedAccount struct {
+	name    string
+	account e2wtypes.Account
+}
+
+// parseAccounts parses an account string, returning the matching accounts.
+func (c *command) parseAccounts(ctx context.Context, accountStr string) ([]*namedAccount, error) {
+	res := make([]*namedAccount, 0)
+
+	if strings.Contains(accountStr, "/") && !strings.HasPrefix(accountStr, "{") {
+		// Could be a wallet and account path, in which case the account name can be a regular expression.
+		wallet, accounts, err := util.WalletAndAccountsFromPath(ctx, accountStr)
+		if err == nil {
+			if len(accounts) == 0 {
+				return nil, fmt.Errorf("no accounts match %s", accountStr)
+			}
+			for _, account := range accounts {
+				res = append(res, &namedAccount{
+					name:    fmt.Sprintf("%s/%s", wallet.Name(), account.Name()),
+					account: account,
+				})
+			}
+
+			return res, nil
+		}
+	}
+
+	account, err := util.ParseAccount(ctx, accountStr, nil, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain account")
+	}
+	// Accounts obtained from keys, keystores and mnemonics do not have useful names.
+	res = append(res, &namedAccount{account: account})
+
+	return res, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectioncheck
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func hexToBytes(input string) []byte {
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func TestCheckAccounts(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	store := scratch.New()
+	require.NoError(t, e2wallet.UseStore(store))
+	testWallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, testWallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	_, err = testWallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx,
+		"Interop 0",
+		hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+	_, err = testWallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx,
+		"Interop 1",
+		hexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+
+	c := &command{
+		accounts: []string{
+			"Test wallet/Interop.*",
+			"0x99b1f1d84d76185466d86c34bde1101316afddae76217aa86cd066979b19858c2c9d9e56eebc1e067ac54277a61790db",
+		},
+		interchange: &util.Interchange{
+			Metadata: &util.InterchangeMetadata{
+				InterchangeFormatVersion: util.InterchangeFormatVersion,
+				GenesisValidatorsRoot:    phase0.Root{0x01},
+			},
+			Data: []*util.InterchangeData{
+				{Pubkey: phase0.BLSPubKey(hexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"))},
+			},
+		},
+		problems: make([]string, 0),
+	}
+
+	require.NoError(t, c.checkAccounts(ctx))
+	require.Len(t, c.checked, 3)
+	require.True(t, c.checked[0].present)
+	require.Equal(t, []string{
+		"Test wallet/Interop 1 (0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b): not present in slashing protection data",
+		"0x99b1f1d84d76185466d86c34bde1101316afddae76217aa86cd066979b19858c2c9d9e56eebc1e067ac54277a61790db: not present in slashing protection data",
+	}, c.problems)
+
+	c.checkGenesisValidatorsRoot(phase0.Root{0x01})
+	require.Len(t, c.problems, 2)
+	c.checkGenesisValidatorsRoot(phase0.Root{0x02})
+	require.Len(t, c.problems, 3)
+	require.Contains(t, c.problems[2], "does not match that of the chain")
+
+	c.accounts = []string{"Test wallet/Unknown.*"}
+	require.EqualError(t, c.checkAccounts(ctx), "no accounts match Test wallet/Unknown.*")
+}
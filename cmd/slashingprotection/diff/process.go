@@ -0,0 +1,141 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectiondiff
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(_ context.Context) error {
+	first, err := util.ReadInterchange(c.files[0])
+	if err != nil {
+		return err
+	}
+	second, err := util.ReadInterchange(c.files[1])
+	if err != nil {
+		return err
+	}
+
+	c.differences = c.diff(first, second)
+
+	return nil
+}
+
+// diff returns the differences between two interchanges.
+func (c *command) diff(first *util.Interchange, second *util.Interchange) []string {
+	differences := make([]string, 0)
+
+	if first.Metadata.InterchangeFormatVersion != second.Metadata.InterchangeFormatVersion {
+		differences = append(differences, fmt.Sprintf("interchange format versions differ: %q and %q", first.Metadata.InterchangeFormatVersion, second.Metadata.InterchangeFormatVersion))
+	}
+	if first.Metadata.GenesisValidatorsRoot != second.Metadata.GenesisValidatorsRoot {
+		differences = append(differences, fmt.Sprintf("genesis validators roots differ: %#x and %#x", first.Metadata.GenesisValidatorsRoot, second.Metadata.GenesisValidatorsRoot))
+	}
+
+	// ByPubkey orders the data, so iterate over the slices for stable output.
+	firstByPubkey := first.ByPubkey()
+	secondByPubkey := second.ByPubkey()
+	firstData := make(map[phase0.BLSPubKey]*util.InterchangeData)
+	for _, data := range firstByPubkey {
+		firstData[data.Pubkey] = data
+	}
+	secondData := make(map[phase0.BLSPubKey]*util.InterchangeData)
+	for _, data := range secondByPubkey {
+		secondData[data.Pubkey] = data
+	}
+
+	for _, data := range firstByPubkey {
+		other, exists := secondData[data.Pubkey]
+		if !exists {
+			differences = append(differences, fmt.Sprintf("%#x: only in %s", data.Pubkey, c.files[0]))
+
+			continue
+		}
+		differences = append(differences, c.diffData(data, other)...)
+	}
+	for _, data := range secondByPubkey {
+		if _, exists := firstData[data.Pubkey]; !exists {
+			differences = append(differences, fmt.Sprintf("%#x: only in %s", data.Pubkey, c.files[1]))
+		}
+	}
+
+	return differences
+}
+
+// diffData returns the differences between the data for a single public key.
+func (c *command) diffData(first *util.InterchangeData, second *util.InterchangeData) []string {
+	differences := make([]string, 0)
+
+	firstBlocks := blockKeys(first)
+	secondBlocks := blockKeys(second)
+	if count := missing(firstBlocks, secondBlocks); count > 0 {
+		differences = append(differences, fmt.Sprintf("%#x: %d block(s) only in %s", first.Pubkey, count, c.files[0]))
+	}
+	if count := missing(secondBlocks, firstBlocks); count > 0 {
+		differences = append(differences, fmt.Sprintf("%#x: %d block(s) only in %s", first.Pubkey, count, c.files[1]))
+	}
+
+	firstAttestations := attestationKeys(first)
+	secondAttestations := attestationKeys(second)
+	if count := missing(firstAttestations, secondAttestations); count > 0 {
+		differences = append(differences, fmt.Sprintf("%#x: %d attestation(s) only in %s", first.Pubkey, count, c.files[0]))
+	}
+	if count := missing(secondAttestations, firstAttestations); count > 0 {
+		differences = append(differences, fmt.Sprintf("%#x: %d attestation(s) only in %s", first.Pubkey, count, c.files[1]))
+	}
+
+	return differences
+}
+
+func blockKeys(data *util.InterchangeData) map[string]bool {
+	res := make(map[string]bool)
+	for _, block := range data.SignedBlocks {
+		res[fmt.Sprintf("%d/%s", block.Slot, signingRoot(block.SigningRoot))] = true
+	}
+
+	return res
+}
+
+func attestationKeys(data *util.InterchangeData) map[string]bool {
+	res := make(map[string]bool)
+	for _, attestation := range data.SignedAttestations {
+		res[fmt.Sprintf("%d/%d/%s", attestation.SourceEpoch, attestation.TargetEpoch, signingRoot(attestation.SigningRoot))] = true
+	}
+
+	return res
+}
+
+func signingRoot(root *phase0.Root) string {
+	if root == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%#x", *root)
+}
+
+// missing returns the number of keys in the first set that are not in the second.
+func missing(first map[string]bool, second map[string]bool) int {
+	count := 0
+	for key := range first {
+		if !second[key] {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,60 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectiondiff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+const (
+	gvr     = "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+	pubkey1 = "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	pubkey2 = "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"
+	pubkey3 = "0xa3a32b0f8b4ddb83f1a0a853d81dd725dfe577d4f4c3db8ece52ce2b026eca84815c1a7e8e92a4de3d755733bf7e4a9b"
+)
+
+func parseInterchange(t *testing.T, input string) *util.Interchange {
+	t.Helper()
+
+	interchange := &util.Interchange{}
+	require.NoError(t, json.Unmarshal([]byte(input), interchange))
+
+	return interchange
+}
+
+func TestDiff(t *testing.T) {
+	c := &command{
+		files: []string{"first.json", "second.json"},
+	}
+
+	first := parseInterchange(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+gvr+`"},"data":[{"pubkey":"`+pubkey1+`","signed_blocks":[{"slot":"10"},{"slot":"20"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"}]},{"pubkey":"`+pubkey2+`","signed_blocks":[],"signed_attestations":[]}]}`)
+	require.Empty(t, c.diff(first, first))
+
+	second := parseInterchange(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+gvr+`"},"data":[{"pubkey":"`+pubkey1+`","signed_blocks":[{"slot":"10"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"},{"source_epoch":"2","target_epoch":"3"},{"source_epoch":"3","target_epoch":"4"}]},{"pubkey":"`+pubkey3+`","signed_blocks":[],"signed_attestations":[]}]}`)
+	require.Equal(t, []string{
+		pubkey1 + ": 1 block(s) only in first.json",
+		pubkey1 + ": 2 attestation(s) only in second.json",
+		pubkey2 + ": only in first.json",
+		pubkey3 + ": only in second.json",
+	}, c.diff(first, second))
+
+	other := parseInterchange(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x0000000000000000000000000000000000000000000000000000000000000001"},"data":[]}`)
+	differences := c.diff(first, other)
+	require.Equal(t, "genesis validators roots differ: "+gvr+" and 0x0000000000000000000000000000000000000000000000000000000000000001", differences[0])
+	require.Len(t, differences, 3)
+}
@@ -0,0 +1,39 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectionmerge
+
+import (
+	"context"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(_ context.Context) error {
+	interchanges := make([]*util.Interchange, 0, len(c.files))
+	for _, file := range c.files {
+		interchange, err := util.ReadInterchange(file)
+		if err != nil {
+			return err
+		}
+		interchanges = append(interchanges, interchange)
+	}
+
+	var err error
+	c.merged, err = util.MergeInterchanges(interchanges...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectionvalidate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+	if len(c.problems) == 0 {
+		builder.WriteString("Slashing protection data is valid")
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("\nGenesis validators root: %#x\nPublic keys: %d", c.interchange.Metadata.GenesisValidatorsRoot, len(c.interchange.ByPubkey())))
+		}
+
+		return builder.String(), nil
+	}
+
+	builder.WriteString("Slashing protection data has problems:")
+	for _, problem := range c.problems {
+		builder.WriteString("\n  ")
+		builder.WriteString(problem)
+	}
+
+	return builder.String(), nil
+}
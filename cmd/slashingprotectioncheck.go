@@ -0,0 +1,59 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	slashingprotectioncheck "github.com/wealdtech/ethdo/cmd/slashingprotection/check"
+)
+
+var slashingProtectionCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check a slashing protection interchange file against accounts",
+	Long: `Check an EIP-3076 slashing protection interchange file against a set of accounts and the connected chain.  For example:
+
+    ethdo slashing-protection check --file=slashing-protection.json --accounts=Validators/
+
+Accounts can be supplied as wallet and account paths, where the account name can be a regular expression, or in any format accepted by --account elsewhere, such as public keys.  The file is validated, its genesis validators root is checked against that of the chain, and any accounts whose public keys are not present in the file are reported.
+
+In quiet mode this will return 0 if the check passes, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := slashingprotectioncheck.Run(cmd)
+		// The results are output even if there is an error, as they explain the error.
+		if res != "" && !viper.GetBool("quiet") {
+			fmt.Println(res)
+		}
+		return err
+	},
+}
+
+func init() {
+	slashingProtectionCmd.AddCommand(slashingProtectionCheckCmd)
+	slashingProtectionFlags(slashingProtectionCheckCmd)
+	slashingProtectionCheckCmd.Flags().String("file", "", "the interchange file to check")
+	slashingProtectionCheckCmd.Flags().StringSlice("accounts", nil, "the accounts that should be present in the interchange file")
+}
+
+func slashingProtectionCheckBindings(cmd *cobra.Command) {
+	slashingProtectionBindings()
+	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("accounts", cmd.Flags().Lookup("accounts")); err != nil {
+		panic(err)
+	}
+}
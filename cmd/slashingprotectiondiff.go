@@ -0,0 +1,53 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	slashingprotectiondiff "github.com/wealdtech/ethdo/cmd/slashingprotection/diff"
+)
+
+var slashingProtectionDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show the differences between two slashing protection interchange files",
+	Long: `Show the differences between two EIP-3076 slashing protection interchange files.  For example:
+
+    ethdo slashing-protection diff --files=before.json,after.json
+
+In quiet mode this will return 0 if the files contain the same data, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := slashingprotectiondiff.Run(cmd)
+		// The results are output even if there is an error, as they explain the error.
+		if res != "" && !viper.GetBool("quiet") {
+			fmt.Println(res)
+		}
+		return err
+	},
+}
+
+func init() {
+	slashingProtectionCmd.AddCommand(slashingProtectionDiffCmd)
+	slashingProtectionFlags(slashingProtectionDiffCmd)
+	slashingProtectionDiffCmd.Flags().StringSlice("files", nil, "the two interchange files to compare")
+}
+
+func slashingProtectionDiffBindings(cmd *cobra.Command) {
+	slashingProtectionBindings()
+	if err := viper.BindPFlag("files", cmd.Flags().Lookup("files")); err != nil {
+		panic(err)
+	}
+}
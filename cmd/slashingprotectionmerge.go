@@ -0,0 +1,58 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	slashingprotectionmerge "github.com/wealdtech/ethdo/cmd/slashingprotection/merge"
+)
+
+var slashingProtectionMergeCmd = &cobra.Command{
+	Use:   "merge",
+	Short: "Merge slashing protection interchange files",
+	Long: `Merge multiple EIP-3076 slashing protection interchange files in to a single file.  For example:
+
+    ethdo slashing-protection merge --files=client1.json,client2.json > merged.json
+
+All files must have the same genesis validators root.  The merged file contains the data for all public keys in all files, with duplicate entries removed.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := slashingprotectionmerge.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	slashingProtectionCmd.AddCommand(slashingProtectionMergeCmd)
+	slashingProtectionFlags(slashingProtectionMergeCmd)
+	slashingProtectionMergeCmd.Flags().StringSlice("files", nil, "the interchange files to merge")
+}
+
+func slashingProtectionMergeBindings(cmd *cobra.Command) {
+	slashingProtectionBindings()
+	if err := viper.BindPFlag("files", cmd.Flags().Lookup("files")); err != nil {
+		panic(err)
+	}
+}
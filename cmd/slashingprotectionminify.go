@@ -0,0 +1,58 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	slashingprotectionminify "github.com/wealdtech/ethdo/cmd/slashingprotection/minify"
+)
+
+var slashingProtectionMinifyCmd = &cobra.Command{
+	Use:   "minify",
+	Short: "Minify a slashing protection interchange file",
+	Long: `Minify an EIP-3076 slashing protection interchange file.  For example:
+
+    ethdo slashing-protection minify --file=slashing-protection.json > minified.json
+
+The minified file contains, for each public key, only the highest signed block slot and an attestation with the highest signed source and target epochs.  This provides the same protection as the full file.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := slashingprotectionminify.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	slashingProtectionCmd.AddCommand(slashingProtectionMinifyCmd)
+	slashingProtectionFlags(slashingProtectionMinifyCmd)
+	slashingProtectionMinifyCmd.Flags().String("file", "", "the interchange file to minify")
+}
+
+func slashingProtectionMinifyBindings(cmd *cobra.Command) {
+	slashingProtectionBindings()
+	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
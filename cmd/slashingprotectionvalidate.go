@@ -0,0 +1,55 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	slashingprotectionvalidate "github.com/wealdtech/ethdo/cmd/slashingprotection/validate"
+)
+
+var slashingProtectionValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate a slashing protection interchange file",
+	Long: `Validate an EIP-3076 slashing protection interchange file.  For example:
+
+    ethdo slashing-protection validate --file=slashing-protection.json
+
+The file is checked for an unsupported format version, a missing genesis validators root, conflicting blocks and attestations, and surround votes.
+
+In quiet mode this will return 0 if the file is valid, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := slashingprotectionvalidate.Run(cmd)
+		// The results are output even if there is an error, as they explain the error.
+		if res != "" && !viper.GetBool("quiet") {
+			fmt.Println(res)
+		}
+		return err
+	},
+}
+
+func init() {
+	slashingProtectionCmd.AddCommand(slashingProtectionValidateCmd)
+	slashingProtectionFlags(slashingProtectionValidateCmd)
+	slashingProtectionValidateCmd.Flags().String("file", "", "the interchange file to validate")
+}
+
+func slashingProtectionValidateBindings(cmd *cobra.Command) {
+	slashingProtectionBindings()
+	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
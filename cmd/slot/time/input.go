@@ -1,4 +1,4 @@
-// Copyright © 2021 Weald Technology Trading
+// Copyright © 2021, 2025 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -50,8 +50,18 @@ func input(ctx context.Context) (*dataIn, error) {
 	}
 	data.slot = viper.GetString("slot")
 
+	// Use a network configuration in place of a beacon node if supplied.
+	networkConfig, err := util.NetworkConfigFromConfig()
+	if err != nil {
+		return nil, err
+	}
+	if networkConfig != nil {
+		data.eth2Client = networkConfig
+
+		return data, nil
+	}
+
 	// Ethereum 2 client.
-	var err error
 	data.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
 		Address:       viper.GetString("connection"),
 		Timeout:       viper.GetDuration("timeout"),
@@ -28,7 +28,7 @@ var synccommitteeInclusionCmd = &cobra.Command{
 
     ethdo synccommittee inclusion --epoch=12345 --index=11111
 
-In quiet mode this will return 0 if the validator was in the sync committee, otherwise 1.
+In quiet mode this will return 0 if the validator was in the sync committee, otherwise non-zero.
 
 epoch can be a specific epoch; If not supplied all slots for the current sync committee period will be provided`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
@@ -28,7 +28,7 @@ var synccommitteeMembersCmd = &cobra.Command{
 
     ethdo synccommittee members --epoch=12345
 
-In quiet mode this will return 0 if the synccommittee members are found, otherwise 1.
+In quiet mode this will return 0 if the synccommittee members are found, otherwise non-zero.
 
 epoch can be a specific epoch.  period can be 'current' for the current sync period or 'next' for the next sync period`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
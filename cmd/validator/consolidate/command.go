@@ -0,0 +1,150 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	offline bool
+	json    bool
+
+	// Input.
+	sourceValidator string
+	targetValidator string
+	feeStr          string
+	prepareOffline  bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+	connectionQuorum         int
+
+	// Network configuration for offline use.
+	networkConfig *util.NetworkConfig
+
+	// Information required to generate the requests.
+	chainInfo *beacon.ChainInfo
+	fee       *big.Int
+
+	// Processing.
+	consensusClient            consensusclient.Service
+	chainTime                  chaintime.Service
+	source                     *beacon.ValidatorInfo
+	target                     *beacon.ValidatorInfo
+	maxEffectiveBalance        phase0.Gwei
+	shardCommitteePeriod       phase0.Epoch
+	pendingConsolidationsLimit uint64
+	// Pending operations are only available when online.
+	pendingConsolidations []*electra.PendingConsolidation
+	pendingWithdrawals    phase0.Gwei
+
+	// Output.
+	requests              []*consolidationRequest
+	consolidationDeferred bool
+}
+
+// consolidationRequest is an EIP-7251 consolidation request.
+type consolidationRequest struct {
+	description  string
+	sourcePubkey phase0.BLSPubKey
+	targetPubkey phase0.BLSPubKey
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		offline:                  viper.GetBool("offline"),
+		json:                     viper.GetBool("json"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		connectionQuorum:         viper.GetInt("connection-quorum"),
+		prepareOffline:           viper.GetBool("prepare-offline"),
+		sourceValidator:          viper.GetString("source-validator"),
+		targetValidator:          viper.GetString("target-validator"),
+		feeStr:                   viper.GetString("fee"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	var err error
+	c.networkConfig, err = util.NetworkConfigFromConfig()
+	if err != nil {
+		return nil, err
+	}
+	if c.networkConfig != nil && !c.offline {
+		return nil, errors.New("network configuration can only be used with offline")
+	}
+
+	// We are generating information for offline use, we don't need any information
+	// related to the validators.
+	if c.prepareOffline {
+		return c, nil
+	}
+
+	if c.sourceValidator == "" {
+		return nil, errors.New("source validator is required")
+	}
+
+	if c.targetValidator == "" {
+		return nil, errors.New("target validator is required")
+	}
+
+	if err := c.parseFee(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *command) parseFee() error {
+	if c.feeStr == "" {
+		c.fee = big.NewInt(minConsolidationRequestFee)
+		return nil
+	}
+	fee, err := string2eth.StringToWei(c.feeStr)
+	if err != nil {
+		return errors.Wrap(err, "invalid fee")
+	}
+	if fee.Cmp(big.NewInt(minConsolidationRequestFee)) < 0 {
+		return fmt.Errorf("fee must be at least %d wei", minConsolidationRequestFee)
+	}
+	c.fee = fee
+
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type transactionJSON struct {
+	To      string `json:"to"`
+	Data    string `json:"data"`
+	Value   string `json:"value"`
+	FeeHint string `json:"fee_hint"`
+	Note    string `json:"note,omitempty"`
+}
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.prepareOffline {
+		return fmt.Sprintf("%s generated", offlinePreparationFilename), nil
+	}
+
+	if c.json {
+		return c.outputJSON()
+	}
+
+	return c.outputText(), nil
+}
+
+func (c *command) outputJSON() (string, error) {
+	transactions := make([]*transactionJSON, 0, len(c.requests))
+	for _, request := range c.requests {
+		transaction := &transactionJSON{
+			To:      consolidationRequestPredeployAddress,
+			Data:    fmt.Sprintf("%#x", consolidationRequestCalldata(request)),
+			Value:   c.fee.String(),
+			FeeHint: feeHint(),
+		}
+		if c.consolidationDeferred {
+			transaction.Note = c.deferredNote()
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	data, err := json.Marshal(transactions)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal transactions")
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText() string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("From: %s\n", util.AddressBytesToEIP55(c.source.WithdrawalCredentials[12:])))
+	for i, request := range c.requests {
+		builder.WriteString("\n")
+		if c.consolidationDeferred || c.verbose {
+			builder.WriteString(fmt.Sprintf("%d: %s\n", i+1, request.description))
+		}
+		builder.WriteString(fmt.Sprintf("To: %s\n", consolidationRequestPredeployAddress))
+		builder.WriteString(fmt.Sprintf("Data: %#x\n", consolidationRequestCalldata(request)))
+		builder.WriteString(fmt.Sprintf("Value: %s\n", string2eth.WeiToString(c.fee, true)))
+	}
+	builder.WriteString("\n")
+	if c.consolidationDeferred {
+		builder.WriteString(fmt.Sprintf("Note: %s\n", c.deferredNote()))
+	}
+	builder.WriteString(fmt.Sprintf("Note: %s", feeHint()))
+
+	return builder.String()
+}
+
+// deferredNote explains how to obtain a consolidation request that has been
+// deferred until the target has compounding credentials.
+func (c *command) deferredNote() string {
+	return fmt.Sprintf("validator %d must have compounding withdrawal credentials before it can be the target of a consolidation; once this transaction has been included in a block run this command again to generate the consolidation request", c.target.Index)
+}
+
+func feeHint() string {
+	return fmt.Sprintf("the request fee varies with demand and is at least %d wei; the current fee can be obtained by calling %s with empty data, and any value above the fee is not refunded", minConsolidationRequestFee, consolidationRequestPredeployAddress)
+}
@@ -0,0 +1,364 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// minTimeout is the minimum timeout for this command, as checking the source
+// and target validators requires the full validator set and the pending
+// consolidation queue to be fetched from the beacon node.
+var minTimeout = 5 * time.Minute
+
+const (
+	// offlinePreparationFilename is the name of the file containing chain information for offline use.
+	offlinePreparationFilename = "offline-preparation.json"
+
+	// consolidationRequestPredeployAddress is the address of the EIP-7251 consolidation request contract.
+	consolidationRequestPredeployAddress = "0x0000BBdDc7CE488642fb579F8B00f3a590007251"
+	// minConsolidationRequestFee is the minimum fee, in wei, for a consolidation request.
+	minConsolidationRequestFee = 1
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.obtainChainInfo(ctx); err != nil {
+		return err
+	}
+
+	if c.prepareOffline {
+		return c.writeChainInfoToFile(ctx)
+	}
+
+	if c.chainInfo.Version < 4 {
+		return errors.New("chain information does not contain validator balances; please regenerate your offline data")
+	}
+
+	var err error
+	c.source, err = c.chainInfo.FetchValidatorInfo(ctx, c.sourceValidator)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain source validator")
+	}
+	c.target, err = c.chainInfo.FetchValidatorInfo(ctx, c.targetValidator)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain target validator")
+	}
+
+	if err := c.obtainSpecValues(ctx); err != nil {
+		return err
+	}
+
+	if c.offline {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Offline; cannot check pending consolidations or withdrawals\n")
+		}
+	} else {
+		if err := c.obtainPendingOperations(ctx); err != nil {
+			return err
+		}
+	}
+
+	return c.generateRequests()
+}
+
+// generateRequests checks that the consolidation will be acted upon by the
+// consensus layer, and generates the requests required to carry it out.
+func (c *command) generateRequests() error {
+	if c.source.Index == c.target.Index {
+		if err := c.checkSwitch(); err != nil {
+			return err
+		}
+		c.requests = append(c.requests, &consolidationRequest{
+			description:  fmt.Sprintf("Switch validator %d to compounding withdrawal credentials", c.source.Index),
+			sourcePubkey: c.source.Pubkey,
+			targetPubkey: c.source.Pubkey,
+		})
+
+		return nil
+	}
+
+	if err := c.checkConsolidation(); err != nil {
+		return err
+	}
+
+	if c.target.WithdrawalCredentials[0] == util.EthWithdrawalPrefix {
+		// The target needs to switch to compounding credentials before it can
+		// be the target of a consolidation.  Requests in separate transactions
+		// have no ordering guarantee, and a consolidation processed before the
+		// switch fails silently, so only the switch is generated here.  The
+		// consolidation is generated when the command is run again, after the
+		// switch has been included and the target's credentials checked.
+		c.requests = append(c.requests, &consolidationRequest{
+			description:  fmt.Sprintf("Switch validator %d to compounding withdrawal credentials", c.target.Index),
+			sourcePubkey: c.target.Pubkey,
+			targetPubkey: c.target.Pubkey,
+		})
+		c.consolidationDeferred = true
+
+		return nil
+	}
+	c.requests = append(c.requests, &consolidationRequest{
+		description:  fmt.Sprintf("Consolidate validator %d in to validator %d", c.source.Index, c.target.Index),
+		sourcePubkey: c.source.Pubkey,
+		targetPubkey: c.target.Pubkey,
+	})
+
+	return nil
+}
+
+// checkSwitch checks that a validator can switch to compounding credentials.
+func (c *command) checkSwitch() error {
+	switch c.source.WithdrawalCredentials[0] {
+	case util.EthWithdrawalPrefix:
+	case util.CompoundingWithdrawalPrefix:
+		return util.ErrorWithCode(errors.New("validator already has compounding withdrawal credentials"),
+			util.ErrorCodeWrongCredentialsType,
+			map[string]any{"index": c.source.Index},
+		)
+	default:
+		return util.ErrorWithCode(errors.New("validator does not have execution withdrawal credentials"),
+			util.ErrorCodeWrongCredentialsType,
+			map[string]any{"index": c.source.Index},
+		)
+	}
+
+	if c.source.State != apiv1.ValidatorStateActiveOngoing {
+		return fmt.Errorf("validator is in state %v, not suitable to switch to compounding withdrawal credentials", c.source.State)
+	}
+
+	return nil
+}
+
+// checkConsolidation checks that the source validator can be consolidated in to the target validator.
+func (c *command) checkConsolidation() error {
+	if !hasExecutionCredentials(c.source) {
+		return util.ErrorWithCode(errors.New("source validator does not have execution withdrawal credentials"),
+			util.ErrorCodeWrongCredentialsType,
+			map[string]any{"index": c.source.Index},
+		)
+	}
+	if !hasExecutionCredentials(c.target) {
+		return util.ErrorWithCode(errors.New("target validator does not have execution withdrawal credentials"),
+			util.ErrorCodeWrongCredentialsType,
+			map[string]any{"index": c.target.Index},
+		)
+	}
+	if !bytes.Equal(c.source.WithdrawalCredentials[12:], c.target.WithdrawalCredentials[12:]) {
+		return fmt.Errorf("source and target validators have different withdrawal addresses (%s and %s)",
+			util.AddressBytesToEIP55(c.source.WithdrawalCredentials[12:]),
+			util.AddressBytesToEIP55(c.target.WithdrawalCredentials[12:]),
+		)
+	}
+
+	if c.source.State != apiv1.ValidatorStateActiveOngoing {
+		return fmt.Errorf("source validator is in state %v, not suitable for consolidation", c.source.State)
+	}
+	if c.target.State != apiv1.ValidatorStateActiveOngoing {
+		return fmt.Errorf("target validator is in state %v, not suitable for consolidation", c.target.State)
+	}
+	if c.chainInfo.Epoch < c.source.ActivationEpoch+c.shardCommitteePeriod {
+		return fmt.Errorf("source validator cannot be consolidated until epoch %d", c.source.ActivationEpoch+c.shardCommitteePeriod)
+	}
+	if c.pendingWithdrawals != 0 {
+		return errors.New("source validator has pending partial withdrawals")
+	}
+
+	// Work out the effective balance of the target once this and any pending
+	// consolidations have completed.
+	effectiveBalance := c.target.EffectiveBalance + c.source.EffectiveBalance
+	for _, consolidation := range c.pendingConsolidations {
+		if consolidation.SourceIndex == c.source.Index {
+			return errors.New("source validator is already being consolidated")
+		}
+		if consolidation.TargetIndex != c.target.Index {
+			continue
+		}
+		for _, validator := range c.chainInfo.Validators {
+			if validator.Index == consolidation.SourceIndex {
+				effectiveBalance += validator.EffectiveBalance
+				break
+			}
+		}
+	}
+	if effectiveBalance > c.maxEffectiveBalance {
+		return fmt.Errorf("target validator would have an effective balance of %s, more than the maximum of %s",
+			string2eth.GWeiToString(uint64(effectiveBalance), true),
+			string2eth.GWeiToString(uint64(c.maxEffectiveBalance), true),
+		)
+	}
+
+	if c.pendingConsolidationsLimit != 0 && uint64(len(c.pendingConsolidations)) >= c.pendingConsolidationsLimit {
+		return errors.New("pending consolidation queue is full")
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	if c.offline {
+		if c.networkConfig == nil {
+			return nil
+		}
+
+		// Set up chaintime from the network configuration.
+		var err error
+		c.chainTime, err = standardchaintime.New(ctx,
+			standardchaintime.WithGenesisProvider(c.networkConfig),
+			standardchaintime.WithSpecProvider(c.networkConfig),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to create chaintime service")
+		}
+
+		return nil
+	}
+
+	// Ensure timeout is at least the minimum.
+	if c.timeout < minTimeout {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Increasing timeout to %v\n", minTimeout)
+		}
+		c.timeout = minTimeout
+	}
+
+	// Connect to the consensus node.
+	var err error
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+		Quorum:        c.connectionQuorum,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisProvider(c.consensusClient.(consensusclient.GenesisProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return nil
+}
+
+// obtainSpecValues obtains the values from the spec required to check the requests,
+// falling back to mainnet values if they are unavailable.
+func (c *command) obtainSpecValues(ctx context.Context) error {
+	c.maxEffectiveBalance = 2048000000000
+	c.shardCommitteePeriod = 256
+	c.pendingConsolidationsLimit = 262144
+
+	var specProvider consensusclient.SpecProvider
+	switch {
+	case c.consensusClient != nil:
+		specProvider = c.consensusClient.(consensusclient.SpecProvider)
+	case c.networkConfig != nil:
+		specProvider = c.networkConfig
+	default:
+		return nil
+	}
+
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+
+	if val, exists := specResponse.Data["MAX_EFFECTIVE_BALANCE_ELECTRA"].(uint64); exists {
+		c.maxEffectiveBalance = phase0.Gwei(val)
+	}
+	if val, exists := specResponse.Data["SHARD_COMMITTEE_PERIOD"].(uint64); exists {
+		c.shardCommitteePeriod = phase0.Epoch(val)
+	}
+	if val, exists := specResponse.Data["PENDING_CONSOLIDATIONS_LIMIT"].(uint64); exists {
+		c.pendingConsolidationsLimit = val
+	}
+
+	return nil
+}
+
+// obtainPendingOperations obtains the pending operations that affect the requests.
+func (c *command) obtainPendingOperations(ctx context.Context) error {
+	consolidationsProvider, isProvider := c.consensusClient.(consensusclient.PendingConsolidationsProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide pending consolidations")
+	}
+	consolidationsResponse, err := consolidationsProvider.PendingConsolidations(ctx, &api.PendingConsolidationsOpts{
+		State: "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain pending consolidations")
+	}
+	c.pendingConsolidations = consolidationsResponse.Data
+
+	withdrawalsProvider, isProvider := c.consensusClient.(consensusclient.PendingPartialWithdrawalsProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide pending partial withdrawals")
+	}
+	withdrawalsResponse, err := withdrawalsProvider.PendingPartialWithdrawals(ctx, &api.PendingPartialWithdrawalsOpts{
+		State: "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain pending partial withdrawals")
+	}
+	for _, withdrawal := range withdrawalsResponse.Data {
+		if withdrawal.ValidatorIndex == c.source.Index {
+			c.pendingWithdrawals += withdrawal.Amount
+		}
+	}
+
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "%d pending consolidations; source validator has %d pending withdrawals\n", len(c.pendingConsolidations), c.pendingWithdrawals)
+	}
+
+	return nil
+}
+
+// consolidationRequestCalldata generates the calldata for a consolidation request,
+// which is the source public key followed by the target public key.
+func consolidationRequestCalldata(request *consolidationRequest) []byte {
+	data := make([]byte, 0, 2*phase0.PublicKeyLength)
+	data = append(data, request.sourcePubkey[:]...)
+	data = append(data, request.targetPubkey[:]...)
+
+	return data
+}
+
+// hasExecutionCredentials returns true if the validator has execution withdrawal credentials.
+func hasExecutionCredentials(validator *beacon.ValidatorInfo) bool {
+	return validator.WithdrawalCredentials[0] == util.EthWithdrawalPrefix ||
+		validator.WithdrawalCredentials[0] == util.CompoundingWithdrawalPrefix
+}
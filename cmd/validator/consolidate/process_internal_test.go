@@ -0,0 +1,204 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"encoding/hex"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+)
+
+func validatorInfo(index phase0.ValidatorIndex, prefix byte, address byte, state apiv1.ValidatorState, effectiveBalance phase0.Gwei) *beacon.ValidatorInfo {
+	credentials := make([]byte, 32)
+	credentials[0] = prefix
+	credentials[31] = address
+
+	return &beacon.ValidatorInfo{
+		Index:                 index,
+		Pubkey:                phase0.BLSPubKey{byte(index)},
+		State:                 state,
+		WithdrawalCredentials: credentials,
+		EffectiveBalance:      effectiveBalance,
+	}
+}
+
+func TestGenerateRequests(t *testing.T) {
+	tests := []struct {
+		name                  string
+		source                *beacon.ValidatorInfo
+		target                *beacon.ValidatorInfo
+		pendingConsolidations []*electra.PendingConsolidation
+		pendingWithdrawals    phase0.Gwei
+		requests              []string
+		deferred              bool
+		err                   string
+	}{
+		{
+			name:     "Switch",
+			source:   validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			target:   validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			requests: []string{"Switch validator 1 to compounding withdrawal credentials"},
+		},
+		{
+			name:   "SwitchAlreadyCompounding",
+			source: validatorInfo(1, 0x02, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			target: validatorInfo(1, 0x02, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			err:    "validator already has compounding withdrawal credentials",
+		},
+		{
+			name:   "SwitchBLS",
+			source: validatorInfo(1, 0x00, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			target: validatorInfo(1, 0x00, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			err:    "validator does not have execution withdrawal credentials",
+		},
+		{
+			name:   "SwitchExiting",
+			source: validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveExiting, 32000000000),
+			target: validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveExiting, 32000000000),
+			err:    "validator is in state active_exiting, not suitable to switch to compounding withdrawal credentials",
+		},
+		{
+			name:   "SourceBLS",
+			source: validatorInfo(1, 0x00, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			target: validatorInfo(2, 0x02, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			err:    "source validator does not have execution withdrawal credentials",
+		},
+		{
+			name:   "TargetBLS",
+			source: validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			target: validatorInfo(2, 0x00, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			err:    "target validator does not have execution withdrawal credentials",
+		},
+		{
+			name:   "AddressMismatch",
+			source: validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			target: validatorInfo(2, 0x02, 0x02, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			err:    "source and target validators have different withdrawal addresses (0x0000000000000000000000000000000000000001 and 0x0000000000000000000000000000000000000002)",
+		},
+		{
+			name:   "SourceSlashed",
+			source: validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveSlashed, 32000000000),
+			target: validatorInfo(2, 0x02, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			err:    "source validator is in state active_slashed, not suitable for consolidation",
+		},
+		{
+			name:   "TargetExiting",
+			source: validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			target: validatorInfo(2, 0x02, 0x01, apiv1.ValidatorStateActiveExiting, 32000000000),
+			err:    "target validator is in state active_exiting, not suitable for consolidation",
+		},
+		{
+			name:               "SourcePendingWithdrawals",
+			source:             validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			target:             validatorInfo(2, 0x02, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			pendingWithdrawals: 1000000000,
+			err:                "source validator has pending partial withdrawals",
+		},
+		{
+			name:   "SourcePendingConsolidation",
+			source: validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			target: validatorInfo(2, 0x02, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			pendingConsolidations: []*electra.PendingConsolidation{
+				{SourceIndex: 1, TargetIndex: 3},
+			},
+			err: "source validator is already being consolidated",
+		},
+		{
+			name:   "TargetTooLarge",
+			source: validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			target: validatorInfo(2, 0x02, 0x01, apiv1.ValidatorStateActiveOngoing, 2020000000000),
+			err:    "target validator would have an effective balance of 2052 Ether, more than the maximum of 2048 Ether",
+		},
+		{
+			name:   "TargetTooLargeWithPending",
+			source: validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			target: validatorInfo(2, 0x02, 0x01, apiv1.ValidatorStateActiveOngoing, 2000000000000),
+			pendingConsolidations: []*electra.PendingConsolidation{
+				{SourceIndex: 3, TargetIndex: 2},
+			},
+			err: "target validator would have an effective balance of 2064 Ether, more than the maximum of 2048 Ether",
+		},
+		{
+			name:   "QueueFull",
+			source: validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			target: validatorInfo(2, 0x02, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			pendingConsolidations: []*electra.PendingConsolidation{
+				{SourceIndex: 5, TargetIndex: 6},
+				{SourceIndex: 7, TargetIndex: 6},
+			},
+			err: "pending consolidation queue is full",
+		},
+		{
+			name:     "Consolidate",
+			source:   validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			target:   validatorInfo(2, 0x02, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			requests: []string{"Consolidate validator 1 in to validator 2"},
+		},
+		{
+			name:     "ConsolidateWithSwitch",
+			source:   validatorInfo(1, 0x01, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			target:   validatorInfo(2, 0x01, 0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			requests: []string{"Switch validator 2 to compounding withdrawal credentials"},
+			deferred: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				chainInfo: &beacon.ChainInfo{
+					Version: 4,
+					Epoch:   1000,
+					Validators: []*beacon.ValidatorInfo{
+						validatorInfo(3, 0x02, 0x01, apiv1.ValidatorStateActiveExiting, 32000000000),
+					},
+				},
+				source:                     test.source,
+				target:                     test.target,
+				maxEffectiveBalance:        2048000000000,
+				shardCommitteePeriod:       256,
+				pendingConsolidationsLimit: 2,
+				pendingConsolidations:      test.pendingConsolidations,
+				pendingWithdrawals:         test.pendingWithdrawals,
+			}
+			err := c.generateRequests()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			descriptions := make([]string, 0, len(c.requests))
+			for _, request := range c.requests {
+				descriptions = append(descriptions, request.description)
+			}
+			require.Equal(t, test.requests, descriptions)
+			require.Equal(t, test.deferred, c.consolidationDeferred)
+		})
+	}
+}
+
+func TestConsolidationRequestCalldata(t *testing.T) {
+	request := &consolidationRequest{
+		sourcePubkey: phase0.BLSPubKey{0x01},
+		targetPubkey: phase0.BLSPubKey{0x02},
+	}
+	res := consolidationRequestCalldata(request)
+	require.Len(t, res, 96)
+	require.Equal(t, "01"+hex.EncodeToString(make([]byte, 47))+"02"+hex.EncodeToString(make([]byte, 47)), hex.EncodeToString(res))
+}
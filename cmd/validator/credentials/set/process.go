@@ -80,8 +80,8 @@ func (c *command) process(ctx context.Context) error {
 		return errors.New("no suitable validators found; no operations generated")
 	}
 
-	if validated, reason := c.validateOperations(ctx); !validated {
-		return fmt.Errorf("operation failed validation: %s", reason)
+	if err := c.validateOperations(ctx); err != nil {
+		return errors.Wrap(err, "operation failed validation")
 	}
 
 	if c.json || c.offline {
@@ -178,7 +178,10 @@ func (c *command) generateOperationFromMnemonicAndPath(ctx context.Context) erro
 	// validator was not found in our case (it should be found if a path is
 	// provided) and return an error if not.
 	if !found {
-		return errors.New("no validator found with the provided path and mnemonic, please run with --debug to see more information")
+		return util.ErrorWithCode(errors.New("no validator found with the provided path and mnemonic, please run with --debug to see more information"),
+			util.ErrorCodeValidatorNotFound,
+			map[string]any{"path": c.path},
+		)
 	}
 
 	return nil
@@ -400,7 +403,10 @@ func (c *command) generateOperationsFromPrivateKey(ctx context.Context) error {
 		found = true
 	}
 	if !found {
-		return fmt.Errorf("no validator found with withdrawal credentials %#x", withdrawalCredentials)
+		return util.ErrorWithCode(fmt.Errorf("no validator found with withdrawal credentials %#x", withdrawalCredentials),
+			util.ErrorCodeValidatorNotFound,
+			map[string]any{"withdrawal_credentials": fmt.Sprintf("%#x", withdrawalCredentials)},
+		)
 	}
 	return nil
 }
@@ -625,7 +631,7 @@ func (c *command) parseWithdrawalAddress(_ context.Context) error {
 	return nil
 }
 
-func (c *command) validateOperations(ctx context.Context) (bool, string) {
+func (c *command) validateOperations(ctx context.Context) error {
 	// Turn the validators in to a map for easy lookup.
 	validators := make(map[phase0.ValidatorIndex]*beacon.ValidatorInfo, 0)
 	for _, validator := range c.chainInfo.Validators {
@@ -633,11 +639,11 @@ func (c *command) validateOperations(ctx context.Context) (bool, string) {
 	}
 
 	for _, signedOperation := range c.signedOperations {
-		if validated, reason := c.validateOperation(ctx, validators, signedOperation); !validated {
-			return validated, reason
+		if err := c.validateOperation(ctx, validators, signedOperation); err != nil {
+			return err
 		}
 	}
-	return true, ""
+	return nil
 }
 
 func (c *command) verifyOperation(_ context.Context, op *capella.SignedBLSToExecutionChange) error {
@@ -678,13 +684,13 @@ func (c *command) verifyOperation(_ context.Context, op *capella.SignedBLSToExec
 func (c *command) validateOperation(_ context.Context,
 	validators map[phase0.ValidatorIndex]*beacon.ValidatorInfo,
 	signedOperation *capella.SignedBLSToExecutionChange,
-) (
-	bool,
-	string,
-) {
+) error {
 	validator, exists := validators[signedOperation.Message.ValidatorIndex]
 	if !exists {
-		return false, "validator not known on chain"
+		return util.ErrorWithCode(errors.New("validator not known on chain"),
+			util.ErrorCodeValidatorNotFound,
+			map[string]any{"index": signedOperation.Message.ValidatorIndex},
+		)
 	}
 	if c.debug {
 		fmt.Fprintf(os.Stderr, "Credentials change operation: %v", signedOperation)
@@ -692,7 +698,10 @@ func (c *command) validateOperation(_ context.Context,
 	}
 
 	if validator.WithdrawalCredentials[0] != byte(0) {
-		return false, "validator is not using BLS withdrawal credentials"
+		return util.ErrorWithCode(errors.New("validator is not using BLS withdrawal credentials"),
+			util.ErrorCodeWrongCredentialsType,
+			map[string]any{"index": signedOperation.Message.ValidatorIndex},
+		)
 	}
 
 	withdrawalCredentials := ethutil.SHA256(signedOperation.Message.FromBLSPubkey[:])
@@ -701,10 +710,10 @@ func (c *command) validateOperation(_ context.Context,
 		if c.debug {
 			fmt.Fprintf(os.Stderr, "validator withdrawal credentials %#x do not match calculated operation withdrawal credentials %#x\n", validator.WithdrawalCredentials, withdrawalCredentials)
 		}
-		return false, "validator withdrawal credentials do not match those in the operation"
+		return errors.New("validator withdrawal credentials do not match those in the operation")
 	}
 
-	return true, ""
+	return nil
 }
 
 func (c *command) broadcastOperations(ctx context.Context) error {
@@ -731,6 +740,7 @@ func (c *command) setup(ctx context.Context) error {
 		Timeout:       c.timeout,
 		AllowInsecure: c.allowInsecureConnections,
 		LogFallback:   !c.quiet,
+		Quorum:        c.connectionQuorum,
 	})
 	if err != nil {
 		return err
@@ -1,4 +1,4 @@
-// Copyright © 2019, 2020 Weald Technology Trading
+// Copyright © 2019 - 2025 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -21,6 +21,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/api"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -32,6 +33,7 @@ import (
 
 type dataIn struct {
 	debug             bool
+	quiet             bool
 	format            string
 	timeout           time.Duration
 	withdrawalAccount string
@@ -43,15 +45,26 @@ type dataIn struct {
 	domain            *spec.Domain
 	passphrases       []string
 	compounding       bool
+	// Top-ups require a connection to obtain validator information.
+	topup                 bool
+	allowIneffectiveTopup bool
+	forkVersionSupplied   bool
+	eth2Client            string
+	allowInsecure         bool
 }
 
 func input() (*dataIn, error) {
 	var err error
 	data := &dataIn{
-		debug:       viper.GetBool("debug"),
-		forkVersion: &spec.Version{},
-		domain:      &spec.Domain{},
-		compounding: viper.GetBool("compounding"),
+		debug:                 viper.GetBool("debug"),
+		quiet:                 viper.GetBool("quiet"),
+		forkVersion:           &spec.Version{},
+		domain:                &spec.Domain{},
+		compounding:           viper.GetBool("compounding"),
+		topup:                 viper.GetBool("topup"),
+		allowIneffectiveTopup: viper.GetBool("allow-ineffective-topup"),
+		eth2Client:            viper.GetString("connection"),
+		allowInsecure:         viper.GetBool("allow-insecure-connections"),
 	}
 
 	if viper.GetString("validatoraccount") == "" {
@@ -97,7 +110,12 @@ func input() (*dataIn, error) {
 	if data.withdrawalAddress != "" {
 		withdrawalDetailsPresent++
 	}
-	if withdrawalDetailsPresent == 0 {
+	switch {
+	case data.topup && withdrawalDetailsPresent > 0:
+		return nil, errors.New("withdrawal account, public key or address cannot be supplied for a top-up; withdrawal credentials are obtained from the chain")
+	case data.topup && data.compounding:
+		return nil, errors.New("compounding cannot be supplied for a top-up; withdrawal credentials are obtained from the chain")
+	case !data.topup && withdrawalDetailsPresent == 0:
 		return nil, errors.New("withdrawal account, public key or address is required")
 	}
 	if withdrawalDetailsPresent > 1 {
@@ -116,6 +134,9 @@ func input() (*dataIn, error) {
 	}
 	data.amount = spec.Gwei(amount)
 
+	data.forkVersionSupplied = viper.GetString("forkversion") != "" ||
+		viper.GetString("network") != "" ||
+		viper.GetString("network-config") != ""
 	data.forkVersion, err = inputForkVersion(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain fork version")
@@ -132,10 +153,23 @@ func input() (*dataIn, error) {
 	return data, nil
 }
 
-func inputForkVersion(_ context.Context) (*spec.Version, error) {
+func inputForkVersion(ctx context.Context) (*spec.Version, error) {
 	// Default to mainnet.
 	forkVersion := &spec.Version{0x00, 0x00, 0x00, 0x00}
 
+	// Use the network configuration if supplied.
+	networkConfig, err := ethdoutil.NetworkConfigFromConfig()
+	if err != nil {
+		return nil, err
+	}
+	if networkConfig != nil {
+		genesisResponse, err := networkConfig.Genesis(ctx, &api.GenesisOpts{})
+		if err != nil {
+			return nil, err
+		}
+		*forkVersion = genesisResponse.Data.GenesisForkVersion
+	}
+
 	// Override if supplied.
 	if viper.GetString("forkversion") != "" {
 		data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("forkversion"), "0x"))
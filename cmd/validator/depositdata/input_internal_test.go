@@ -1,4 +1,4 @@
-// Copyright © 2019-2021 Weald Technology Trading
+// Copyright © 2019-2025 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -180,6 +180,28 @@ func TestInput(t *testing.T) {
 			},
 			err: "only one of withdrawal account, public key or address is allowed",
 		},
+		{
+			name: "TopupWithdrawalDetails",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawalaccount": "Test/Interop 0",
+				"depositvalue":      "1 Ether",
+				"topup":             true,
+			},
+			err: "withdrawal account, public key or address cannot be supplied for a top-up; withdrawal credentials are obtained from the chain",
+		},
+		{
+			name: "TopupCompounding",
+			vars: map[string]interface{}{
+				"timeout":          "10s",
+				"validatoraccount": "Test/Interop 0",
+				"depositvalue":     "1 Ether",
+				"topup":            true,
+				"compounding":      true,
+			},
+			err: "compounding cannot be supplied for a top-up; withdrawal credentials are obtained from the chain",
+		},
 		{
 			name: "DepositValueMissing",
 			vars: map[string]interface{}{
@@ -258,6 +280,22 @@ func TestInput(t *testing.T) {
 				domain:            domain,
 			},
 		},
+		{
+			name: "GoodTopup",
+			vars: map[string]interface{}{
+				"timeout":          "10s",
+				"validatoraccount": "Test/Interop 0",
+				"depositvalue":     "1 Ether",
+				"topup":            true,
+			},
+			res: &dataIn{
+				format:            "json",
+				amount:            1000000000,
+				validatorAccounts: []e2wtypes.Account{interop0},
+				forkVersion:       mainnetForkVersion,
+				domain:            mainnetDomain,
+			},
+		},
 		{
 			name: "GoodWithdrawalPubKey",
 			vars: map[string]interface{}{
@@ -1,4 +1,4 @@
-// Copyright © 2019-2021 Weald Technology Limited.
+// Copyright © 2019-2025 Weald Technology Limited.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -17,6 +17,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"os"
 	"sort"
 	"strconv"
 	"strings"
@@ -37,34 +38,61 @@ func process(data *dataIn) ([]*dataOut, error) {
 
 	results := make([]*dataOut, 0)
 
-	withdrawalCredentials, err := createWithdrawalCredentials(data)
-	if err != nil {
-		return nil, err
+	pubKeys := make([]spec.BLSPubKey, len(data.validatorAccounts))
+	for i, validatorAccount := range data.validatorAccounts {
+		validatorPubKey, err := ethdoutil.BestPublicKey(validatorAccount)
+		if err != nil {
+			return nil, errors.Wrap(err, "validator account does not provide a public key")
+		}
+		copy(pubKeys[i][:], validatorPubKey.Marshal())
 	}
 
-	// These values are hard-coded, to allow deposit data to be generated without a connection to the beacon node.
-	if data.amount < 1000000000 { // MIN_DEPOSIT_AMOUNT
-		return nil, errors.New("deposit value must be at least 1 Ether")
-	}
-	switch data.compounding {
-	case false:
-		if data.amount > 32000000000 {
-			return nil, errors.New("deposit value exceeds maximum for a non-compounding validator")
+	var withdrawalCredentials []byte
+	var topup *topupInfo
+	var err error
+	if data.topup {
+		// Top-ups use the existing withdrawal credentials of each validator.
+		topup, err = obtainTopupInfo(data, pubKeys)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		withdrawalCredentials, err = createWithdrawalCredentials(data)
+		if err != nil {
+			return nil, err
 		}
-	case true:
-		if data.amount > 2048000000000 {
-			return nil, errors.New("deposit value exceeds maximum for a compounding validator")
+
+		// These values are hard-coded, to allow deposit data to be generated without a connection to the beacon node.
+		if data.amount < 1000000000 { // MIN_DEPOSIT_AMOUNT
+			return nil, errors.New("deposit value must be at least 1 Ether")
+		}
+		switch data.compounding {
+		case false:
+			if data.amount > 32000000000 {
+				return nil, errors.New("deposit value exceeds maximum for a non-compounding validator")
+			}
+		case true:
+			if data.amount > 2048000000000 {
+				return nil, errors.New("deposit value exceeds maximum for a compounding validator")
+			}
 		}
 	}
 
-	for _, validatorAccount := range data.validatorAccounts {
-		validatorPubKey, err := ethdoutil.BestPublicKey(validatorAccount)
-		if err != nil {
-			return nil, errors.Wrap(err, "validator account does not provide a public key")
+	for i, validatorAccount := range data.validatorAccounts {
+		pubKey := pubKeys[i]
+		if topup != nil {
+			warnings, err := topup.checkTopup(pubKey, data.amount)
+			if err != nil {
+				return nil, err
+			}
+			if !data.quiet {
+				for _, warning := range warnings {
+					fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+				}
+			}
+			withdrawalCredentials = topup.validators[pubKey].Validator.WithdrawalCredentials
 		}
 
-		var pubKey spec.BLSPubKey
-		copy(pubKey[:], validatorPubKey.Marshal())
 		depositMessage := &spec.DepositMessage{
 			PublicKey:             pubKey,
 			WithdrawalCredentials: withdrawalCredentials,
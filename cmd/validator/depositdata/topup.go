@@ -0,0 +1,166 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositdata
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	ethdoutil "github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// topupInfo contains the information required to generate top-up deposits.
+type topupInfo struct {
+	validators                 map[spec.BLSPubKey]*apiv1.Validator
+	minDepositAmount           spec.Gwei
+	maxEffectiveBalance        spec.Gwei
+	maxEffectiveBalanceElectra spec.Gwei
+	// allowIneffective allows top-ups that cannot increase the effective balance.
+	allowIneffective bool
+}
+
+// obtainTopupInfo obtains the on-chain information for the validators to be topped up.
+func obtainTopupInfo(data *dataIn, pubKeys []spec.BLSPubKey) (*topupInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), data.timeout)
+	defer cancel()
+
+	eth2Client, err := ethdoutil.ConnectToBeaconNode(ctx, &ethdoutil.ConnectOpts{
+		Address:       data.eth2Client,
+		Timeout:       data.timeout,
+		AllowInsecure: data.allowInsecure,
+		LogFallback:   !data.quiet,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	specResponse, err := eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+	res := &topupInfo{
+		minDepositAmount:           1000000000,
+		maxEffectiveBalance:        32000000000,
+		maxEffectiveBalanceElectra: 2048000000000,
+		allowIneffective:           data.allowIneffectiveTopup,
+	}
+	if val, exists := specResponse.Data["MIN_DEPOSIT_AMOUNT"].(uint64); exists {
+		res.minDepositAmount = spec.Gwei(val)
+	}
+	if val, exists := specResponse.Data["MAX_EFFECTIVE_BALANCE"].(uint64); exists {
+		res.maxEffectiveBalance = spec.Gwei(val)
+	}
+	if val, exists := specResponse.Data["MAX_EFFECTIVE_BALANCE_ELECTRA"].(uint64); exists {
+		res.maxEffectiveBalanceElectra = spec.Gwei(val)
+	}
+
+	// Deposits are signed with the genesis fork version of the chain.
+	if genesisForkVersion, exists := specResponse.Data["GENESIS_FORK_VERSION"].(spec.Version); exists && genesisForkVersion != *data.forkVersion {
+		if data.forkVersionSupplied {
+			return nil, fmt.Errorf("fork version %#x does not match chain genesis fork version %#x", *data.forkVersion, genesisForkVersion)
+		}
+		*data.forkVersion = genesisForkVersion
+		copy(data.domain[:], e2types.Domain(e2types.DomainDeposit, data.forkVersion[:], e2types.ZeroGenesisValidatorsRoot))
+		if data.debug {
+			fmt.Fprintf(os.Stderr, "Fork version from chain is %#x\n", *data.forkVersion)
+			fmt.Fprintf(os.Stderr, "Signature domain is %#x\n", *data.domain)
+		}
+	}
+
+	validatorsResponse, err := eth2Client.(eth2client.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{
+		State:   "head",
+		PubKeys: pubKeys,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validators")
+	}
+	res.validators = make(map[spec.BLSPubKey]*apiv1.Validator, len(validatorsResponse.Data))
+	for _, validator := range validatorsResponse.Data {
+		res.validators[validator.Validator.PublicKey] = validator
+	}
+
+	return res, nil
+}
+
+// checkTopup checks that a top-up is suitable for the validator.  Top-ups that
+// cannot increase the validator's effective balance are rejected unless allowed,
+// and warnings are returned for top-ups that will not fully increase it.
+func (t *topupInfo) checkTopup(pubKey spec.BLSPubKey, amount spec.Gwei) ([]string, error) {
+	validator, exists := t.validators[pubKey]
+	if !exists {
+		return nil, ethdoutil.ErrorWithCode(fmt.Errorf("validator %#x not found on chain; top-ups can only be made to existing validators", pubKey),
+			ethdoutil.ErrorCodeValidatorNotFound,
+			map[string]any{"pubkey": fmt.Sprintf("%#x", pubKey)},
+		)
+	}
+
+	if amount < t.minDepositAmount {
+		return nil, fmt.Errorf("deposit value must be at least %s", string2eth.GWeiToString(uint64(t.minDepositAmount), true))
+	}
+
+	switch validator.Status {
+	case apiv1.ValidatorStateActiveExiting,
+		apiv1.ValidatorStateActiveSlashed,
+		apiv1.ValidatorStateExitedUnslashed,
+		apiv1.ValidatorStateExitedSlashed,
+		apiv1.ValidatorStateWithdrawalPossible,
+		apiv1.ValidatorStateWithdrawalDone:
+		return nil, fmt.Errorf("validator %d is in state %v, not suitable for a top-up", validator.Index, validator.Status)
+	}
+
+	var credentialsType string
+	maxEffectiveBalance := t.maxEffectiveBalance
+	switch validator.Validator.WithdrawalCredentials[0] {
+	case 0x00: // BLS_WITHDRAWAL_PREFIX
+		credentialsType = "BLS (0x00)"
+	case 0x01: // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+		credentialsType = "execution (0x01)"
+	case 0x02: // COMPOUNDING_WITHDRAWAL_PREFIX
+		credentialsType = "compounding (0x02)"
+		maxEffectiveBalance = t.maxEffectiveBalanceElectra
+	default:
+		return nil, fmt.Errorf("validator %d has unknown withdrawal credentials type %#02x", validator.Index, validator.Validator.WithdrawalCredentials[0])
+	}
+
+	warnings := make([]string, 0)
+	switch {
+	case validator.Validator.EffectiveBalance >= maxEffectiveBalance:
+		msg := fmt.Sprintf("validator %d has %s withdrawal credentials and is at its maximum effective balance of %s; the top-up will not increase its effective balance",
+			validator.Index,
+			credentialsType,
+			string2eth.GWeiToString(uint64(maxEffectiveBalance), true),
+		)
+		if !t.allowIneffective {
+			return nil, fmt.Errorf("%s (use --allow-ineffective-topup to create it regardless)", msg)
+		}
+		warnings = append(warnings, msg)
+	case validator.Validator.EffectiveBalance+amount > maxEffectiveBalance:
+		warnings = append(warnings, fmt.Sprintf("validator %d has %s withdrawal credentials and a maximum effective balance of %s; only %s of the top-up will increase its effective balance",
+			validator.Index,
+			credentialsType,
+			string2eth.GWeiToString(uint64(maxEffectiveBalance), true),
+			string2eth.GWeiToString(uint64(maxEffectiveBalance-validator.Validator.EffectiveBalance), true),
+		))
+	}
+
+	return warnings, nil
+}
@@ -0,0 +1,155 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositdata
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTopup(t *testing.T) {
+	validator := func(prefix byte, state apiv1.ValidatorState, effectiveBalance spec.Gwei) *apiv1.Validator {
+		credentials := make([]byte, 32)
+		credentials[0] = prefix
+		return &apiv1.Validator{
+			Index:  1,
+			Status: state,
+			Validator: &spec.Validator{
+				PublicKey:             spec.BLSPubKey{0x01},
+				WithdrawalCredentials: credentials,
+				EffectiveBalance:      effectiveBalance,
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		validator *apiv1.Validator
+		pubKey    spec.BLSPubKey
+		amount    spec.Gwei
+		allow     bool
+		warnings  []string
+		err       string
+	}{
+		{
+			name:      "Unknown",
+			validator: validator(0x01, apiv1.ValidatorStateActiveOngoing, 31000000000),
+			pubKey:    spec.BLSPubKey{0x02},
+			amount:    1000000000,
+			err:       "validator 0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000 not found on chain; top-ups can only be made to existing validators",
+		},
+		{
+			name:      "AmountTooLow",
+			validator: validator(0x01, apiv1.ValidatorStateActiveOngoing, 31000000000),
+			pubKey:    spec.BLSPubKey{0x01},
+			amount:    999999999,
+			err:       "deposit value must be at least 1 Ether",
+		},
+		{
+			name:      "Exited",
+			validator: validator(0x01, apiv1.ValidatorStateExitedUnslashed, 31000000000),
+			pubKey:    spec.BLSPubKey{0x01},
+			amount:    1000000000,
+			err:       "validator 1 is in state exited_unslashed, not suitable for a top-up",
+		},
+		{
+			name:      "UnknownCredentials",
+			validator: validator(0x03, apiv1.ValidatorStateActiveOngoing, 31000000000),
+			pubKey:    spec.BLSPubKey{0x01},
+			amount:    1000000000,
+			err:       "validator 1 has unknown withdrawal credentials type 0x03",
+		},
+		{
+			name:      "Execution",
+			validator: validator(0x01, apiv1.ValidatorStateActiveOngoing, 31000000000),
+			pubKey:    spec.BLSPubKey{0x01},
+			amount:    1000000000,
+			warnings:  []string{},
+		},
+		{
+			name:      "ExecutionAtMaximum",
+			validator: validator(0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			pubKey:    spec.BLSPubKey{0x01},
+			amount:    1000000000,
+			err:       "validator 1 has execution (0x01) withdrawal credentials and is at its maximum effective balance of 32 Ether; the top-up will not increase its effective balance (use --allow-ineffective-topup to create it regardless)",
+		},
+		{
+			name:      "ExecutionAtMaximumAllowed",
+			validator: validator(0x01, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			pubKey:    spec.BLSPubKey{0x01},
+			amount:    1000000000,
+			allow:     true,
+			warnings: []string{
+				"validator 1 has execution (0x01) withdrawal credentials and is at its maximum effective balance of 32 Ether; the top-up will not increase its effective balance",
+			},
+		},
+		{
+			name:      "BLSPartial",
+			validator: validator(0x00, apiv1.ValidatorStateActiveOngoing, 31000000000),
+			pubKey:    spec.BLSPubKey{0x01},
+			amount:    2000000000,
+			warnings: []string{
+				"validator 1 has BLS (0x00) withdrawal credentials and a maximum effective balance of 32 Ether; only 1 Ether of the top-up will increase its effective balance",
+			},
+		},
+		{
+			name:      "Compounding",
+			validator: validator(0x02, apiv1.ValidatorStateActiveOngoing, 32000000000),
+			pubKey:    spec.BLSPubKey{0x01},
+			amount:    100000000000,
+			warnings:  []string{},
+		},
+		{
+			name:      "CompoundingAtMaximum",
+			validator: validator(0x02, apiv1.ValidatorStateActiveOngoing, 2048000000000),
+			pubKey:    spec.BLSPubKey{0x01},
+			amount:    1000000000,
+			err:       "validator 1 has compounding (0x02) withdrawal credentials and is at its maximum effective balance of 2048 Ether; the top-up will not increase its effective balance (use --allow-ineffective-topup to create it regardless)",
+		},
+		{
+			name:      "CompoundingAtMaximumAllowed",
+			validator: validator(0x02, apiv1.ValidatorStateActiveOngoing, 2048000000000),
+			pubKey:    spec.BLSPubKey{0x01},
+			amount:    1000000000,
+			allow:     true,
+			warnings: []string{
+				"validator 1 has compounding (0x02) withdrawal credentials and is at its maximum effective balance of 2048 Ether; the top-up will not increase its effective balance",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			topup := &topupInfo{
+				validators: map[spec.BLSPubKey]*apiv1.Validator{
+					test.validator.Validator.PublicKey: test.validator,
+				},
+				minDepositAmount:           1000000000,
+				maxEffectiveBalance:        32000000000,
+				maxEffectiveBalanceElectra: 2048000000000,
+				allowIneffective:           test.allow,
+			}
+			warnings, err := topup.checkTopup(test.pubKey, test.amount)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.warnings, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatordiscover
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet    bool
+	verbose  bool
+	debug    bool
+	format   string
+	template string
+
+	// Input.
+	mnemonic   string
+	startIndex uint64
+	count      uint64
+	workers    int
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Processing.
+	consensusClient consensusclient.Service
+	keys            []*key
+
+	// Output.
+	validators []*validator
+}
+
+// key is the information derived from the mnemonic for a single EIP-2334 index.
+type key struct {
+	index                 uint64
+	path                  string
+	pubKey                phase0.BLSPubKey
+	withdrawalCredentials []byte
+}
+
+// validator is a validator found on chain.
+type validator struct {
+	path                  string
+	pubKey                phase0.BLSPubKey
+	index                 phase0.ValidatorIndex
+	state                 apiv1.ValidatorState
+	balance               phase0.Gwei
+	withdrawalCredentials []byte
+	// withdrawalKeyMatches is true if the validator has BLS withdrawal credentials
+	// that match the withdrawal key derived from the mnemonic.
+	withdrawalKeyMatches bool
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		format:                   util.OutputFormat(),
+		template:                 viper.GetString("template"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		mnemonic:                 viper.GetString("mnemonic"),
+		startIndex:               viper.GetUint64("start-index"),
+		count:                    viper.GetUint64("count"),
+		workers:                  viper.GetInt("workers"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.mnemonic == "" {
+		return nil, errors.New("mnemonic is required")
+	}
+
+	if c.count == 0 {
+		return nil, errors.New("count must be greater than 0")
+	}
+	if c.startIndex+c.count < c.startIndex {
+		return nil, errors.New("index range too large")
+	}
+
+	if c.workers < 0 {
+		return nil, errors.New("workers cannot be negative")
+	}
+	if c.workers == 0 {
+		c.workers = runtime.NumCPU()
+	}
+
+	return c, nil
+}
+
+// hasBLSCredentials returns true if the validator has BLS (0x00) withdrawal credentials.
+func (v *validator) hasBLSCredentials() bool {
+	return len(v.withdrawalCredentials) > 0 && v.withdrawalCredentials[0] == 0x00
+}
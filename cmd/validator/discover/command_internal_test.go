@@ -0,0 +1,101 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatordiscover
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"count":    1024,
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "MnemonicMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"count":   1024,
+			},
+			err: "mnemonic is required",
+		},
+		{
+			name: "CountZero",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			},
+			err: "count must be greater than 0",
+		},
+		{
+			name: "RangeOverflow",
+			vars: map[string]interface{}{
+				"timeout":     "5s",
+				"mnemonic":    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"start-index": uint64(0xffffffffffffffff),
+				"count":       2,
+			},
+			err: "index range too large",
+		},
+		{
+			name: "WorkersNegative",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"count":    1024,
+				"workers":  -1,
+			},
+			err: "workers cannot be negative",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":     "5s",
+				"mnemonic":    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"start-index": 100,
+				"count":       1024,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Positive(t, c.workers)
+			}
+		})
+	}
+}
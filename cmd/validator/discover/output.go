@@ -0,0 +1,94 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatordiscover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/go-string2eth"
+)
+
+type validatorJSON struct {
+	Path                  string `json:"path"`
+	Index                 uint64 `json:"index"`
+	PubKey                string `json:"pubkey"`
+	State                 string `json:"state"`
+	Balance               uint64 `json:"balance"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	// WithdrawalKeyMatches is only present for validators with BLS withdrawal credentials.
+	WithdrawalKeyMatches *bool `json:"withdrawal_key_matches,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputText,
+	})
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	validators := make([]*validatorJSON, 0, len(c.validators))
+	for _, validator := range c.validators {
+		validatorJSON := &validatorJSON{
+			Path:                  validator.path,
+			Index:                 uint64(validator.index),
+			PubKey:                fmt.Sprintf("%#x", validator.pubKey),
+			State:                 validator.state.String(),
+			Balance:               uint64(validator.balance),
+			WithdrawalCredentials: fmt.Sprintf("%#x", validator.withdrawalCredentials),
+		}
+		if validator.hasBLSCredentials() {
+			withdrawalKeyMatches := validator.withdrawalKeyMatches
+			validatorJSON.WithdrawalKeyMatches = &withdrawalKeyMatches
+		}
+		validators = append(validators, validatorJSON)
+	}
+
+	data, err := json.Marshal(validators)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Validators found: %d (indices %d to %d)\n", len(c.validators), c.startIndex, c.startIndex+c.count-1))
+	for _, validator := range c.validators {
+		builder.WriteString(validator.path)
+		builder.WriteString("\n")
+		builder.WriteString(fmt.Sprintf("  Index: %d\n", validator.index))
+		builder.WriteString(fmt.Sprintf("  Public key: %#x\n", validator.pubKey))
+		builder.WriteString(fmt.Sprintf("  State: %s\n", validator.state))
+		builder.WriteString(fmt.Sprintf("  Balance: %s\n", string2eth.GWeiToString(uint64(validator.balance), true)))
+		builder.WriteString(fmt.Sprintf("  Withdrawal credentials: %#x\n", validator.withdrawalCredentials))
+		if validator.hasBLSCredentials() {
+			builder.WriteString(fmt.Sprintf("  Withdrawal key matches: %t\n", validator.withdrawalKeyMatches))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,208 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatordiscover
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+// validatorsBatchSize is the number of validators requested from the beacon node at a time.
+const validatorsBatchSize = 1000
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	seed, err := util.SeedFromMnemonic(c.mnemonic)
+	if err != nil {
+		return err
+	}
+
+	c.keys, err = deriveKeys(ctx, seed, c.startIndex, c.count, c.workers)
+	if err != nil {
+		return err
+	}
+
+	c.validators, err = c.obtainValidators(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// deriveKeys derives the validator keys and BLS withdrawal credentials for
+// the given range of indices, spreading the work over the given number of workers.
+func deriveKeys(ctx context.Context,
+	seed []byte,
+	startIndex uint64,
+	count uint64,
+	workers int,
+) (
+	[]*key,
+	error,
+) {
+	keys := make([]*key, count)
+	errs := make([]error, workers)
+
+	indices := make(chan uint64)
+	var wg sync.WaitGroup
+	for worker := range workers {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for index := range indices {
+				if errs[worker] != nil {
+					// Already failed; drain the channel.
+					continue
+				}
+				keys[index-startIndex], errs[worker] = deriveKey(seed, index)
+			}
+		}(worker)
+	}
+
+feed:
+	for index := startIndex; index < startIndex+count; index++ {
+		select {
+		case <-ctx.Done():
+			break feed
+		case indices <- index:
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+// deriveKey derives the EIP-2334 validator key and BLS withdrawal credentials at the given index.
+func deriveKey(seed []byte, index uint64) (*key, error) {
+	validatorKeyPath := fmt.Sprintf("m/12381/3600/%d/0/0", index)
+	validatorPrivKey, err := ethutil.PrivateKeyFromSeedAndPath(seed, validatorKeyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate validator private key")
+	}
+
+	withdrawalKeyPath := strings.TrimSuffix(validatorKeyPath, "/0")
+	withdrawalPrivKey, err := ethutil.PrivateKeyFromSeedAndPath(seed, withdrawalKeyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate withdrawal private key")
+	}
+	withdrawalCredentials := ethutil.SHA256(withdrawalPrivKey.PublicKey().Marshal())
+	withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
+
+	res := &key{
+		index:                 index,
+		path:                  validatorKeyPath,
+		withdrawalCredentials: withdrawalCredentials,
+	}
+	copy(res.pubKey[:], validatorPrivKey.PublicKey().Marshal())
+
+	return res, nil
+}
+
+// obtainValidators obtains the validators on chain for the derived keys, in index order.
+func (c *command) obtainValidators(ctx context.Context) ([]*validator, error) {
+	validatorsProvider, isProvider := c.consensusClient.(consensusclient.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide validator information")
+	}
+
+	res := make([]*validator, 0)
+	for start := 0; start < len(c.keys); start += validatorsBatchSize {
+		end := start + validatorsBatchSize
+		if end > len(c.keys) {
+			end = len(c.keys)
+		}
+		batch := c.keys[start:end]
+
+		pubKeys := make([]phase0.BLSPubKey, len(batch))
+		for i, key := range batch {
+			pubKeys[i] = key.pubKey
+		}
+		response, err := validatorsProvider.Validators(ctx, &api.ValidatorsOpts{
+			State:   "head",
+			PubKeys: pubKeys,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators")
+		}
+
+		found := make(map[phase0.BLSPubKey]*apiv1.Validator, len(response.Data))
+		for _, v := range response.Data {
+			found[v.Validator.PublicKey] = v
+		}
+		for _, key := range batch {
+			v, exists := found[key.pubKey]
+			if !exists {
+				continue
+			}
+			res = append(res, &validator{
+				path:                  key.path,
+				pubKey:                key.pubKey,
+				index:                 v.Index,
+				state:                 v.Status,
+				balance:               v.Balance,
+				withdrawalCredentials: v.Validator.WithdrawalCredentials,
+				withdrawalKeyMatches:  bytes.Equal(v.Validator.WithdrawalCredentials, key.withdrawalCredentials),
+			})
+		}
+
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Checked indices %d to %d; %d validators found so far\n", batch[0].index, batch[len(batch)-1].index, len(res))
+		}
+	}
+
+	return res, nil
+}
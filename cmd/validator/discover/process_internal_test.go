@@ -0,0 +1,122 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatordiscover
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/mock"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestDeriveKeys(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	seed, err := util.SeedFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+	require.NoError(t, err)
+
+	expected := make([]*key, 0)
+	for index := uint64(5); index < 15; index++ {
+		key, err := deriveKey(seed, index)
+		require.NoError(t, err)
+		require.Equal(t, index, key.index)
+		require.Len(t, key.withdrawalCredentials, 32)
+		require.Equal(t, byte(0), key.withdrawalCredentials[0])
+		expected = append(expected, key)
+	}
+
+	for _, workers := range []int{1, 3, 16} {
+		keys, err := deriveKeys(ctx, seed, 5, 10, workers)
+		require.NoError(t, err)
+		require.Equal(t, expected, keys)
+	}
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = deriveKeys(cancelledCtx, seed, 0, 10, 2)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestObtainValidators(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	seed, err := util.SeedFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+	require.NoError(t, err)
+	keys, err := deriveKeys(ctx, seed, 0, 4, 2)
+	require.NoError(t, err)
+
+	// Validators exist for keys 1 and 3; 1 has matching BLS credentials, 3 has execution credentials.
+	executionCredentials := make([]byte, 32)
+	executionCredentials[0] = 0x01
+	onChain := map[phase0.BLSPubKey]*apiv1.Validator{
+		keys[1].pubKey: {
+			Index:   100,
+			Balance: 32000000000,
+			Status:  apiv1.ValidatorStateActiveOngoing,
+			Validator: &phase0.Validator{
+				PublicKey:             keys[1].pubKey,
+				WithdrawalCredentials: keys[1].withdrawalCredentials,
+			},
+		},
+		keys[3].pubKey: {
+			Index:   200,
+			Balance: 31000000000,
+			Status:  apiv1.ValidatorStateExitedUnslashed,
+			Validator: &phase0.Validator{
+				PublicKey:             keys[3].pubKey,
+				WithdrawalCredentials: executionCredentials,
+			},
+		},
+	}
+
+	consensusClient, err := mock.New(ctx)
+	require.NoError(t, err)
+	consensusClient.ValidatorsFunc = func(_ context.Context, opts *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator], error) {
+		data := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+		for _, pubKey := range opts.PubKeys {
+			if validator, exists := onChain[pubKey]; exists {
+				data[validator.Index] = validator
+			}
+		}
+
+		return &api.Response[map[phase0.ValidatorIndex]*apiv1.Validator]{Data: data}, nil
+	}
+
+	c := &command{
+		consensusClient: consensusClient,
+		keys:            keys,
+	}
+	validators, err := c.obtainValidators(ctx)
+	require.NoError(t, err)
+	require.Len(t, validators, 2)
+
+	require.Equal(t, "m/12381/3600/1/0/0", validators[0].path)
+	require.Equal(t, phase0.ValidatorIndex(100), validators[0].index)
+	require.True(t, validators[0].hasBLSCredentials())
+	require.True(t, validators[0].withdrawalKeyMatches)
+
+	require.Equal(t, "m/12381/3600/3/0/0", validators[1].path)
+	require.Equal(t, phase0.ValidatorIndex(200), validators[1].index)
+	require.Equal(t, apiv1.ValidatorStateExitedUnslashed, validators[1].state)
+	require.False(t, validators[1].hasBLSCredentials())
+	require.False(t, validators[1].withdrawalKeyMatches)
+}
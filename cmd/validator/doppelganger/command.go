@@ -0,0 +1,109 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatordoppelganger
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	validators          []string
+	epochs              uint64
+	ignorePreviousEpoch bool
+	format              string
+	template            string
+
+	// Data access.
+	eth2Client               eth2client.Service
+	chainTime                chaintime.Service
+	validatorsProvider       eth2client.ValidatorsProvider
+	livenessProvider         eth2client.ValidatorLivenessProvider
+	blocksProvider           eth2client.SignedBeaconBlockProvider
+	beaconCommitteesProvider eth2client.BeaconCommitteesProvider
+
+	// Processing.
+	indices    map[phase0.ValidatorIndex]struct{}
+	committees map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex
+	nextSlot   phase0.Slot
+
+	// Results.
+	fromEpoch phase0.Epoch
+	toEpoch   phase0.Epoch
+	activity  []*activity
+}
+
+// activity is activity by a validator.
+type activity struct {
+	Epoch          phase0.Epoch          `json:"epoch"`
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index"`
+	Source         string                `json:"source"`
+	Slot           *phase0.Slot          `json:"slot,omitempty"`
+}
+
+// Sources of activity.
+const (
+	sourceLiveness    = "liveness"
+	sourceAttestation = "attestation"
+	sourceProposal    = "proposal"
+)
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		validators:               viper.GetStringSlice("validators"),
+		epochs:                   viper.GetUint64("epochs"),
+		ignorePreviousEpoch:      viper.GetBool("ignore-previous-epoch"),
+		format:                   util.OutputFormat(),
+		template:                 viper.GetString("template"),
+		activity:                 make([]*activity, 0),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if len(c.validators) == 0 {
+		return nil, errors.New("validators are required")
+	}
+
+	if c.epochs == 0 {
+		return nil, errors.New("epochs must be at least 1")
+	}
+
+	return c, nil
+}
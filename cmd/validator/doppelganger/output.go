@@ -0,0 +1,79 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatordoppelganger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type jsonOutput struct {
+	FromEpoch  uint64      `json:"from_epoch,string"`
+	ToEpoch    uint64      `json:"to_epoch,string"`
+	Validators int         `json:"validators"`
+	Activity   []*activity `json:"activity"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputTxt,
+	})
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		FromEpoch:  uint64(c.fromEpoch),
+		ToEpoch:    uint64(c.toEpoch),
+		Validators: len(c.indices),
+		Activity:   c.activity,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal JSON")
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	if len(c.activity) == 0 {
+		return fmt.Sprintf("No activity found for %d validator(s) in epochs %d-%d", len(c.indices), c.fromEpoch, c.toEpoch), nil
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString("Activity found:")
+	for _, activity := range c.activity {
+		switch activity.Source {
+		case sourceAttestation:
+			builder.WriteString(fmt.Sprintf("\n  Validator %d attested in epoch %d (included in block at slot %d)", activity.ValidatorIndex, activity.Epoch, *activity.Slot))
+		case sourceProposal:
+			builder.WriteString(fmt.Sprintf("\n  Validator %d proposed block at slot %d", activity.ValidatorIndex, *activity.Slot))
+		default:
+			builder.WriteString(fmt.Sprintf("\n  Validator %d live in epoch %d", activity.ValidatorIndex, activity.Epoch))
+		}
+	}
+
+	return builder.String(), nil
+}
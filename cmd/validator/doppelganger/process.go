@@ -0,0 +1,328 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatordoppelganger
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+	if err != nil {
+		return err
+	}
+	if len(validators) == 0 {
+		return errors.New("no validators found")
+	}
+	c.indices = make(map[phase0.ValidatorIndex]struct{}, len(validators))
+	for _, validator := range validators {
+		c.indices[validator.Index] = struct{}{}
+	}
+
+	// Start with the previous epoch, which has already completed, to pick up
+	// recent activity immediately.  This will flag validators that were
+	// stopped elsewhere during the previous epoch, so it can be skipped.
+	currentEpoch := c.chainTime.CurrentEpoch()
+	c.fromEpoch = currentEpoch
+	if c.fromEpoch > 0 && !c.ignorePreviousEpoch {
+		c.fromEpoch--
+	}
+	c.toEpoch = currentEpoch + phase0.Epoch(c.epochs) - 1
+
+	for epoch := c.fromEpoch; epoch <= c.toEpoch; epoch++ {
+		// An epoch is checked once it has completed.
+		if err := c.waitFor(ctx, c.chainTime.StartOfEpoch(epoch+1)); err != nil {
+			return err
+		}
+		if c.verbose && !c.quiet {
+			fmt.Fprintf(os.Stderr, "Checking epoch %d\n", epoch)
+		}
+		if err := c.checkEpoch(ctx, epoch); err != nil {
+			return err
+		}
+		if len(c.activity) > 0 {
+			// No need to continue.
+			c.toEpoch = epoch
+
+			break
+		}
+	}
+
+	return nil
+}
+
+// waitFor waits until the given time.
+func (*command) waitFor(ctx context.Context, until time.Time) error {
+	wait := time.Until(until)
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+// checkEpoch checks the validators for activity in the given epoch.
+func (c *command) checkEpoch(ctx context.Context, epoch phase0.Epoch) error {
+	if c.livenessProvider != nil {
+		err := c.checkLiveness(ctx, epoch)
+		if err == nil {
+			return nil
+		}
+		// The endpoint is optional, so fall back to checking blocks from now on.
+		if !c.quiet {
+			fmt.Fprintf(os.Stderr, "Liveness information unavailable (%v); checking blocks instead\n", err)
+		}
+		c.livenessProvider = nil
+	}
+
+	return c.checkBlocks(ctx, epoch)
+}
+
+// checkLiveness checks for activity using the beacon node's liveness endpoint.
+func (c *command) checkLiveness(ctx context.Context, epoch phase0.Epoch) error {
+	indices := make([]phase0.ValidatorIndex, 0, len(c.indices))
+	for index := range c.indices {
+		indices = append(indices, index)
+	}
+
+	response, err := c.livenessProvider.ValidatorLiveness(ctx, &api.ValidatorLivenessOpts{
+		Epoch:   epoch,
+		Indices: indices,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, liveness := range response.Data {
+		if _, exists := c.indices[liveness.Index]; exists && liveness.IsLive {
+			c.activity = append(c.activity, &activity{
+				Epoch:          epoch,
+				ValidatorIndex: liveness.Index,
+				Source:         sourceLiveness,
+			})
+		}
+	}
+
+	return nil
+}
+
+// checkBlocks checks for activity in the given epoch using blocks.  Attestations
+// for an epoch can be included in blocks up to the end of the following epoch,
+// so the blocks are scanned through the end of the following epoch before the
+// epoch is considered clear.
+func (c *command) checkBlocks(ctx context.Context, epoch phase0.Epoch) error {
+	if err := c.waitFor(ctx, c.chainTime.StartOfEpoch(epoch+2)); err != nil {
+		return err
+	}
+	if c.verbose && !c.quiet {
+		fmt.Fprintf(os.Stderr, "Checking blocks to the end of epoch %d\n", epoch+1)
+	}
+
+	// Blocks scanned for an earlier epoch are not scanned again.
+	if c.nextSlot < c.chainTime.FirstSlotOfEpoch(epoch) {
+		c.nextSlot = c.chainTime.FirstSlotOfEpoch(epoch)
+	}
+	for ; c.nextSlot <= c.chainTime.LastSlotOfEpoch(epoch+1); c.nextSlot++ {
+		if err := c.checkBlock(ctx, c.nextSlot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkBlock checks for activity in the block at the given slot.
+func (c *command) checkBlock(ctx context.Context, slot phase0.Slot) error {
+	blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		Block: fmt.Sprintf("%d", slot),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			// No block at this slot.
+			return nil
+		}
+
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+	}
+	block := blockResponse.Data
+
+	proposerIndex, err := block.ProposerIndex()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain proposer index")
+	}
+	c.record(c.chainTime.SlotToEpoch(slot), proposerIndex, sourceProposal, slot)
+
+	attestations, err := block.Attestations()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain attestations")
+	}
+	for _, attestation := range attestations {
+		attestationData, err := attestation.Data()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain attestation data")
+		}
+		slotCommittees, err := c.slotCommittees(ctx, attestationData.Slot)
+		if err != nil {
+			return err
+		}
+
+		committee := slotCommittees[attestationData.Index]
+		// Update with all of the committees if we have committee bits (from Electra onwards).
+		committeeBits, err := attestation.CommitteeBits()
+		if err == nil {
+			committee = make([]phase0.ValidatorIndex, 0)
+			for _, index := range committeeBits.BitIndices() {
+				committee = append(committee, slotCommittees[phase0.CommitteeIndex(index)]...)
+			}
+		}
+
+		aggregationBits, err := attestation.AggregationBits()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain aggregation bits")
+		}
+		for i := range aggregationBits.Len() {
+			if aggregationBits.BitAt(i) && int(i) < len(committee) {
+				c.record(c.chainTime.SlotToEpoch(attestationData.Slot), committee[i], sourceAttestation, slot)
+			}
+		}
+	}
+
+	return nil
+}
+
+// record records activity by a validator in the given epoch if it is one of
+// ours, the epoch is being checked, and the activity has not already been
+// recorded.
+func (c *command) record(epoch phase0.Epoch,
+	index phase0.ValidatorIndex,
+	source string,
+	slot phase0.Slot,
+) {
+	if _, exists := c.indices[index]; !exists {
+		return
+	}
+	if epoch < c.fromEpoch {
+		// Activity before the checked epochs, for example the attestations of
+		// the previous epoch included in the first blocks checked.
+		return
+	}
+	for _, existing := range c.activity {
+		if existing.Epoch == epoch && existing.ValidatorIndex == index {
+			return
+		}
+	}
+	c.activity = append(c.activity, &activity{
+		Epoch:          epoch,
+		ValidatorIndex: index,
+		Source:         source,
+		Slot:           &slot,
+	})
+}
+
+// slotCommittees obtains the beacon committees for the given slot.
+func (c *command) slotCommittees(ctx context.Context, slot phase0.Slot) (map[phase0.CommitteeIndex][]phase0.ValidatorIndex, error) {
+	if slotCommittees, exists := c.committees[slot]; exists {
+		return slotCommittees, nil
+	}
+
+	response, err := c.beaconCommitteesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{
+		State: fmt.Sprintf("%d", slot),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain committees for slot %d", slot))
+	}
+	for _, beaconCommittee := range response.Data {
+		if _, exists := c.committees[beaconCommittee.Slot]; !exists {
+			c.committees[beaconCommittee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+		}
+		c.committees[beaconCommittee.Slot][beaconCommittee.Index] = beaconCommittee.Validators
+	}
+	if _, exists := c.committees[slot]; !exists {
+		// Avoid fetching again.
+		c.committees[slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+	}
+
+	return c.committees[slot], nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon block information")
+	}
+	c.beaconCommitteesProvider, isProvider = c.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon committee information")
+	}
+	// Liveness is optional; blocks are checked if it is not available.
+	c.livenessProvider, _ = c.eth2Client.(eth2client.ValidatorLivenessProvider)
+
+	c.committees = make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+
+	return nil
+}
+
+// isNotFound returns true if the error is a beacon node response for a missing item.
+func isNotFound(err error) bool {
+	var apiErr *api.Error
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
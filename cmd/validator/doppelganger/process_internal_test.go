@@ -0,0 +1,191 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatordoppelganger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/mock"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+)
+
+func newTestCommand(t *testing.T, client *mock.Service) *command {
+	t.Helper()
+
+	chainTime, err := standardchaintime.New(context.Background(),
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(client),
+		standardchaintime.WithSpecProvider(client),
+	)
+	require.NoError(t, err)
+
+	return &command{
+		quiet:                    true,
+		chainTime:                chainTime,
+		livenessProvider:         client,
+		blocksProvider:           client,
+		beaconCommitteesProvider: client,
+		indices: map[phase0.ValidatorIndex]struct{}{
+			2: {},
+			7: {},
+			8: {},
+		},
+		committees: make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex),
+		activity:   make([]*activity, 0),
+	}
+}
+
+func TestCheckLiveness(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := mock.New(ctx)
+	require.NoError(t, err)
+	client.ValidatorLivenessFunc = func(_ context.Context, opts *api.ValidatorLivenessOpts) (*api.Response[[]*apiv1.ValidatorLiveness], error) {
+		require.Equal(t, phase0.Epoch(10), opts.Epoch)
+		data := make([]*apiv1.ValidatorLiveness, 0, len(opts.Indices))
+		for _, index := range opts.Indices {
+			data = append(data, &apiv1.ValidatorLiveness{Index: index, IsLive: index == 8})
+		}
+
+		return &api.Response[[]*apiv1.ValidatorLiveness]{Data: data}, nil
+	}
+
+	c := newTestCommand(t, client)
+	require.NoError(t, c.checkEpoch(ctx, 10))
+	require.Equal(t, []*activity{{Epoch: 10, ValidatorIndex: 8, Source: sourceLiveness}}, c.activity)
+	require.NotNil(t, c.livenessProvider)
+}
+
+func TestCheckBlocks(t *testing.T) {
+	ctx := context.Background()
+
+	// Genesis is far enough in the past that the checked epochs have completed.
+	client, err := mock.New(ctx, mock.WithGenesisTime(time.Now().Add(-24*time.Hour)))
+	require.NoError(t, err)
+	client.ValidatorLivenessFunc = func(context.Context, *api.ValidatorLivenessOpts) (*api.Response[[]*apiv1.ValidatorLiveness], error) {
+		return nil, &api.Error{StatusCode: http.StatusNotFound}
+	}
+	aggregationBits := bitfield.NewBitlist(3)
+	aggregationBits.SetBitAt(1, true)
+	client.SignedBeaconBlockFunc = func(_ context.Context, opts *api.SignedBeaconBlockOpts) (*api.Response[*spec.VersionedSignedBeaconBlock], error) {
+		switch opts.Block {
+		case "321":
+			return &api.Response[*spec.VersionedSignedBeaconBlock]{
+				Data: &spec.VersionedSignedBeaconBlock{
+					Version: spec.DataVersionDeneb,
+					Deneb: &deneb.SignedBeaconBlock{
+						Message: &deneb.BeaconBlock{
+							Slot:          321,
+							ProposerIndex: 7,
+							Body: &deneb.BeaconBlockBody{
+								Attestations: []*phase0.Attestation{
+									{
+										AggregationBits: aggregationBits,
+										Data:            &phase0.AttestationData{Slot: 320, Index: 1},
+									},
+								},
+							},
+						},
+					},
+				},
+			}, nil
+		case "353":
+			// Block in the following epoch with attestations for the epoch.
+			return &api.Response[*spec.VersionedSignedBeaconBlock]{
+				Data: &spec.VersionedSignedBeaconBlock{
+					Version: spec.DataVersionDeneb,
+					Deneb: &deneb.SignedBeaconBlock{
+						Message: &deneb.BeaconBlock{
+							Slot:          353,
+							ProposerIndex: 4,
+							Body: &deneb.BeaconBlockBody{
+								Attestations: []*phase0.Attestation{
+									{
+										AggregationBits: aggregationBits,
+										Data:            &phase0.AttestationData{Slot: 351, Index: 0},
+									},
+									{
+										AggregationBits: aggregationBits,
+										Data:            &phase0.AttestationData{Slot: 319, Index: 0},
+									},
+								},
+							},
+						},
+					},
+				},
+			}, nil
+		case "360":
+			return nil, errors.New("unavailable")
+		default:
+			return nil, &api.Error{StatusCode: http.StatusNotFound}
+		}
+	}
+
+	c := newTestCommand(t, client)
+	c.committees[320] = map[phase0.CommitteeIndex][]phase0.ValidatorIndex{
+		0: {1, 3, 4},
+		1: {5, 2, 9},
+	}
+	c.committees[319] = map[phase0.CommitteeIndex][]phase0.ValidatorIndex{
+		0: {3, 2, 4},
+	}
+	c.committees[321] = map[phase0.CommitteeIndex][]phase0.ValidatorIndex{}
+	c.committees[351] = map[phase0.CommitteeIndex][]phase0.ValidatorIndex{
+		0: {1, 8, 9},
+	}
+	c.fromEpoch = 10
+
+	// Slot 360 fails, so check only the slots before it.
+	for slot := phase0.Slot(320); slot < 360; slot++ {
+		require.NoError(t, c.checkBlock(ctx, slot))
+	}
+	slot1 := phase0.Slot(321)
+	slot2 := phase0.Slot(353)
+	// The attestation for epoch 9 is before the checked epochs, so ignored.
+	require.Equal(t, []*activity{
+		{Epoch: 10, ValidatorIndex: 7, Source: sourceProposal, Slot: &slot1},
+		{Epoch: 10, ValidatorIndex: 2, Source: sourceAttestation, Slot: &slot1},
+		{Epoch: 10, ValidatorIndex: 8, Source: sourceAttestation, Slot: &slot2},
+	}, c.activity)
+
+	// Liveness is unavailable, so the check falls back to blocks through the
+	// end of the following epoch and reports the failure.
+	c.activity = make([]*activity, 0)
+	require.EqualError(t, c.checkEpoch(ctx, 10), fmt.Sprintf("failed to obtain block for slot %d: unavailable", 360))
+	require.Nil(t, c.livenessProvider)
+	require.Len(t, c.activity, 3)
+	require.Equal(t, phase0.Slot(360), c.nextSlot)
+}
+
+func TestWaitFor(t *testing.T) {
+	c := &command{}
+	require.NoError(t, c.waitFor(context.Background(), time.Now().Add(-time.Second)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, c.waitFor(ctx, time.Now().Add(time.Hour)), context.Canceled)
+}
@@ -0,0 +1,299 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// result is the result of exiting a single validator.
+type result struct {
+	validator string
+	operation *phase0.SignedVoluntaryExit
+	// broadcastEpoch and exitEpoch are only set if the exit has been projected.
+	broadcastEpoch phase0.Epoch
+	exitEpoch      phase0.Epoch
+	err            error
+}
+
+// readValidatorsFile reads the validators from the validators file.
+// Each line contains a validator index, public key or account; blank lines and
+// lines starting with '#' are ignored.
+func readValidatorsFile(filename string) ([]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read validators file")
+	}
+
+	validators := make([]string, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		validators = append(validators, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to parse validators file")
+	}
+	if len(validators) == 0 {
+		return nil, errors.New("validators file does not contain any validators")
+	}
+
+	return validators, nil
+}
+
+// generateOperationsFromValidatorsFile generates an exit operation for each validator
+// in the validators file.  Failures are recorded against the individual validator
+// rather than stopping the batch.
+func (c *command) generateOperationsFromValidatorsFile(ctx context.Context) error {
+	validators, err := readValidatorsFile(c.validatorsFile)
+	if err != nil {
+		return err
+	}
+
+	for _, validator := range validators {
+		res := &result{
+			validator: validator,
+		}
+		res.operation, res.err = c.generateOperationForValidator(ctx, validator)
+		c.results = append(c.results, res)
+		if res.err != nil && c.debug {
+			fmt.Fprintf(os.Stderr, "Failed to generate exit operation for %s: %v\n", validator, res.err)
+		}
+	}
+
+	return nil
+}
+
+// generateOperationForValidator generates and validates a single exit operation
+// for a validator in the validators file.
+func (c *command) generateOperationForValidator(ctx context.Context,
+	validator string,
+) (
+	*phase0.SignedVoluntaryExit,
+	error,
+) {
+	operations := len(c.signedOperations)
+	if c.mnemonic != "" {
+		c.validator = validator
+		if err := c.generateOperationFromMnemonicAndValidator(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		account, err := util.ParseAccount(ctx, validator, c.passphrases, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse validator account")
+		}
+		if err := c.generateOperationFromAccount(ctx, account); err != nil {
+			return nil, err
+		}
+	}
+	if len(c.signedOperations) == operations {
+		return nil, errors.New("no key found for validator")
+	}
+
+	operation := c.signedOperations[len(c.signedOperations)-1]
+	if err := c.validateOperation(ctx, operation); err != nil {
+		c.signedOperations = c.signedOperations[:operations]
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+// populateResults creates results for operations that do not already have them.
+func (c *command) populateResults() {
+	recorded := make(map[*phase0.SignedVoluntaryExit]bool, len(c.results))
+	for _, res := range c.results {
+		if res.operation != nil {
+			recorded[res.operation] = true
+		}
+	}
+	for _, operation := range c.signedOperations {
+		if recorded[operation] {
+			continue
+		}
+		c.results = append(c.results, &result{
+			validator: fmt.Sprintf("%d", operation.Message.ValidatorIndex),
+			operation: operation,
+		})
+	}
+}
+
+// failures returns an error listing the validators for which the command failed, if any.
+func (c *command) failures() error {
+	failed := make([]string, 0)
+	for _, res := range c.results {
+		if res.err != nil {
+			failed = append(failed, res.validator)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return util.ErrorWithCode(fmt.Errorf("failed to exit %d of %d validators: %s", len(failed), len(c.results), strings.Join(failed, ", ")),
+		util.ErrorCodeBatchFailed,
+		map[string]any{"validators": failed},
+	)
+}
+
+// successfulResults returns the results that have operations.
+func (c *command) successfulResults() []*result {
+	results := make([]*result, 0, len(c.results))
+	for _, res := range c.results {
+		if res.err == nil {
+			results = append(results, res)
+		}
+	}
+
+	return results
+}
+
+// scheduleOperations splits the operations in to batches, one per epoch, such that each batch
+// uses no more than the configured share of the exit churn.  It also projects the exit epoch
+// of each validator given the current exit queue, if the projection will be reported.
+func (c *command) scheduleOperations(ctx context.Context) ([][]*result, error) {
+	results := c.successfulResults()
+
+	electra := false
+	for _, fork := range c.chainTime.Forks() {
+		if fork.Name == "electra" && fork.Epoch <= c.chainTime.CurrentEpoch() {
+			electra = true
+		}
+	}
+	if !electra {
+		if c.churnShare > 0 {
+			return nil, errors.New("staggered exits are only available from the electra fork onwards")
+		}
+
+		// Broadcast all operations together, without projections.
+		return [][]*result{results}, nil
+	}
+	if c.churnShare == 0 && c.validatorsFile == "" && !c.verbose {
+		// Neither staggering nor projections are required.
+		return [][]*result{results}, nil
+	}
+
+	specResponse, err := c.consensusClient.(consensusclient.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+	maxSeedLookahead := phase0.Epoch(4)
+	if val, exists := specResponse.Data["MAX_SEED_LOOKAHEAD"].(uint64); exists {
+		maxSeedLookahead = phase0.Epoch(val)
+	}
+
+	// Use the validators already obtained with the chain information.
+	validators := make(map[phase0.ValidatorIndex]*apiv1.Validator, len(c.chainInfo.Validators))
+	currentEpoch := c.chainTime.CurrentEpoch()
+	totalActiveBalance := phase0.Gwei(0)
+	for _, validatorInfo := range c.chainInfo.Validators {
+		validators[validatorInfo.Index] = &apiv1.Validator{
+			Index: validatorInfo.Index,
+			Validator: &phase0.Validator{
+				EffectiveBalance: validatorInfo.EffectiveBalance,
+				ActivationEpoch:  validatorInfo.ActivationEpoch,
+				ExitEpoch:        validatorInfo.ExitEpoch,
+			},
+		}
+		if validatorInfo.ActivationEpoch <= currentEpoch && validatorInfo.ExitEpoch > currentEpoch {
+			totalActiveBalance += validatorInfo.EffectiveBalance
+		}
+	}
+	churn := util.CalculateChurnLimits(specResponse.Data, totalActiveBalance).ActivationExit
+
+	batches := batchResults(results, validators, churn*phase0.Gwei(c.churnShare)/100)
+	queue := util.NewExitQueue(validators, churn)
+	for i, batch := range batches {
+		broadcastEpoch := currentEpoch + phase0.Epoch(i)
+		for _, res := range batch {
+			res.broadcastEpoch = broadcastEpoch
+			validator, exists := validators[res.operation.Message.ValidatorIndex]
+			if !exists {
+				continue
+			}
+			res.exitEpoch = queue.Exit(validator.Validator.EffectiveBalance, broadcastEpoch+1+maxSeedLookahead)
+		}
+	}
+
+	return batches, nil
+}
+
+// batchResults splits the results in to batches with a total effective balance no greater
+// than the limit.  Each batch contains at least one result.  A limit of 0 places all results
+// in a single batch.
+func batchResults(results []*result,
+	validators map[phase0.ValidatorIndex]*apiv1.Validator,
+	limit phase0.Gwei,
+) [][]*result {
+	if limit == 0 {
+		return [][]*result{results}
+	}
+
+	batches := make([][]*result, 0)
+	batch := make([]*result, 0)
+	batchBalance := phase0.Gwei(0)
+	for _, res := range results {
+		balance := phase0.Gwei(0)
+		if validator, exists := validators[res.operation.Message.ValidatorIndex]; exists {
+			balance = validator.Validator.EffectiveBalance
+		}
+		if len(batch) > 0 && batchBalance+balance > limit {
+			batches = append(batches, batch)
+			batch = make([]*result, 0)
+			batchBalance = 0
+		}
+		batch = append(batch, res)
+		batchBalance += balance
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches
+}
+
+// waitForEpoch waits until the start of the given epoch.
+func (c *command) waitForEpoch(ctx context.Context, epoch phase0.Epoch) error {
+	wait := time.Until(c.chainTime.StartOfEpoch(epoch))
+	if wait <= 0 {
+		return nil
+	}
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "Waiting %v for epoch %d\n", wait.Round(time.Second), epoch)
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
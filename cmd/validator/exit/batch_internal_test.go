@@ -0,0 +1,266 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/mock"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestReadValidatorsFile(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name       string
+		data       string
+		validators []string
+		err        string
+	}{
+		{
+			name: "Empty",
+			data: "# No validators\n\n",
+			err:  "validators file does not contain any validators",
+		},
+		{
+			name:       "Good",
+			data:       "1\n # Comment\n\n 0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87 \nWallet/Account",
+			validators: []string{"1", "0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87", "Wallet/Account"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filename := filepath.Join(dir, test.name)
+			require.NoError(t, os.WriteFile(filename, []byte(test.data), 0o600))
+			validators, err := readValidatorsFile(filename)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.validators, validators)
+			}
+		})
+	}
+
+	_, err := readValidatorsFile(filepath.Join(dir, "missing"))
+	require.ErrorContains(t, err, "failed to read validators file")
+}
+
+func TestGenerateOperationsFromValidatorsFile(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+
+	chainInfo := &beacon.ChainInfo{
+		Version: 1,
+		Validators: []*beacon.ValidatorInfo{
+			{
+				Index:  0,
+				Pubkey: phase0.BLSPubKey{0xb3, 0x84, 0xf7, 0x67, 0xd9, 0x64, 0xe1, 0x00, 0xc8, 0xa9, 0xb2, 0x10, 0x18, 0xd0, 0x8c, 0x25, 0xff, 0xeb, 0xae, 0x26, 0x8b, 0x3a, 0xb6, 0xd6, 0x10, 0x35, 0x38, 0x97, 0x54, 0x19, 0x71, 0x72, 0x6d, 0xbf, 0xc3, 0xc7, 0x46, 0x38, 0x84, 0xc6, 0x8a, 0x53, 0x15, 0x15, 0xaa, 0xb9, 0x4c, 0x87},
+				State:  apiv1.ValidatorStateActiveOngoing,
+			},
+			{
+				Index:  1,
+				Pubkey: phase0.BLSPubKey{0xb3, 0xd8, 0x9e, 0x2f, 0x29, 0xc7, 0x12, 0xc6, 0xa9, 0xf8, 0xe5, 0xa2, 0x69, 0xb9, 0x76, 0x17, 0xc4, 0xa9, 0x4d, 0xd6, 0xf6, 0x66, 0x2a, 0xb3, 0xb0, 0x7c, 0xe9, 0xe5, 0x43, 0x45, 0x73, 0xf1, 0x5b, 0x5c, 0x98, 0x8c, 0xd1, 0x4b, 0xbd, 0x58, 0x04, 0xf7, 0x71, 0x56, 0xa8, 0xaf, 0x1c, 0xfa},
+				State:  apiv1.ValidatorStateActiveExiting,
+			},
+		},
+		Epoch: 1,
+	}
+
+	filename := filepath.Join(t.TempDir(), "validators")
+	require.NoError(t, os.WriteFile(filename, []byte("0\n1\n5\n"), 0o600))
+
+	c := &command{
+		mnemonic:       "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		validatorsFile: filename,
+		chainInfo:      chainInfo,
+	}
+	require.NoError(t, c.obtainOperations(ctx))
+
+	require.Len(t, c.signedOperations, 1)
+	require.Equal(t, phase0.ValidatorIndex(0), c.signedOperations[0].Message.ValidatorIndex)
+	require.Len(t, c.results, 3)
+	require.Equal(t, "0", c.results[0].validator)
+	require.NoError(t, c.results[0].err)
+	require.Equal(t, c.signedOperations[0], c.results[0].operation)
+	require.EqualError(t, c.results[1].err, "validator is in state active_exiting, not suitable to generate an exit")
+	require.EqualError(t, c.results[2].err, "unknown validator")
+	require.Equal(t, "0: exit operation broadcast\n1: validator is in state active_exiting, not suitable to generate an exit\n5: unknown validator", c.resultsText())
+
+	err := c.failures()
+	require.EqualError(t, err, "failed to exit 2 of 3 validators: 1, 5")
+	require.Equal(t, util.ErrorCodeBatchFailed, util.ErrorCodeOf(err))
+
+	data, err := c.operationsJSON()
+	require.NoError(t, err)
+	require.Equal(t, `[{"validator":"0","operation":{"message":{"epoch":"1","validator_index":"0"},"signature":"`+c.signedOperations[0].Signature.String()+`"}},{"validator":"1","error":"validator is in state active_exiting, not suitable to generate an exit"},{"validator":"5","error":"unknown validator"}]`, string(data))
+
+	// The results can be read back as signed operations.
+	operations, err := parseSignedOperations(data)
+	require.NoError(t, err)
+	require.Equal(t, c.signedOperations, operations)
+}
+
+func TestBatchResults(t *testing.T) {
+	validators := map[phase0.ValidatorIndex]*apiv1.Validator{
+		0: {Validator: &phase0.Validator{EffectiveBalance: 32000000000}},
+		1: {Validator: &phase0.Validator{EffectiveBalance: 32000000000}},
+		2: {Validator: &phase0.Validator{EffectiveBalance: 2048000000000}},
+		3: {Validator: &phase0.Validator{EffectiveBalance: 32000000000}},
+	}
+	results := make([]*result, 0, len(validators))
+	for i := range len(validators) {
+		results = append(results, &result{
+			operation: &phase0.SignedVoluntaryExit{
+				Message: &phase0.VoluntaryExit{ValidatorIndex: phase0.ValidatorIndex(i)},
+			},
+		})
+	}
+
+	tests := []struct {
+		name  string
+		limit phase0.Gwei
+		sizes []int
+	}{
+		{
+			name:  "Unlimited",
+			sizes: []int{4},
+		},
+		{
+			name:  "Limited",
+			limit: 64000000000,
+			sizes: []int{2, 1, 1},
+		},
+		{
+			name:  "Small",
+			limit: 1,
+			sizes: []int{1, 1, 1, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			batches := batchResults(results, validators, test.limit)
+			sizes := make([]int, 0, len(batches))
+			for _, batch := range batches {
+				sizes = append(sizes, len(batch))
+			}
+			require.Equal(t, test.sizes, sizes)
+		})
+	}
+}
+
+func TestScheduleOperations(t *testing.T) {
+	ctx := context.Background()
+
+	// A chain in epoch 12, with Electra active.
+	client, err := mock.New(ctx, mock.WithGenesisTime(time.Now().Add(-12*32*12*time.Second-6*time.Second)))
+	require.NoError(t, err)
+	client.SpecFunc = func(context.Context, *api.SpecOpts) (*api.Response[map[string]any], error) {
+		return &api.Response[map[string]any]{
+			Data: map[string]any{
+				"SECONDS_PER_SLOT":     time.Second * 12,
+				"SLOTS_PER_EPOCH":      uint64(32),
+				"ELECTRA_FORK_EPOCH":   uint64(0),
+				"ELECTRA_FORK_VERSION": phase0.Version{0x05, 0x00, 0x00, 0x00},
+				"MAX_SEED_LOOKAHEAD":   uint64(4),
+			},
+			Metadata: make(map[string]any),
+		}, nil
+	}
+	client.ValidatorsFunc = func(context.Context, *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator], error) {
+		t.Fatal("validators should not be fetched when scheduling operations")
+
+		return nil, nil
+	}
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(client),
+		standardchaintime.WithSpecProvider(client),
+	)
+	require.NoError(t, err)
+
+	chainInfo := &beacon.ChainInfo{
+		Validators: []*beacon.ValidatorInfo{
+			{Index: 0, EffectiveBalance: 32000000000, ExitEpoch: 0xffffffffffffffff},
+			{Index: 1, EffectiveBalance: 32000000000, ExitEpoch: 0xffffffffffffffff},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		verbose    bool
+		churnShare uint64
+		exitEpochs [][]phase0.Epoch
+	}{
+		{
+			name:       "Single",
+			exitEpochs: [][]phase0.Epoch{{0}},
+		},
+		{
+			name:       "Verbose",
+			verbose:    true,
+			exitEpochs: [][]phase0.Epoch{{17}},
+		},
+		{
+			name:       "Staggered",
+			churnShare: 1,
+			exitEpochs: [][]phase0.Epoch{{17}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				verbose:         test.verbose,
+				churnShare:      test.churnShare,
+				consensusClient: client,
+				chainTime:       chainTime,
+				chainInfo:       chainInfo,
+				results: []*result{
+					{
+						validator: "0",
+						operation: &phase0.SignedVoluntaryExit{Message: &phase0.VoluntaryExit{ValidatorIndex: 0}},
+					},
+				},
+			}
+			batches, err := c.scheduleOperations(ctx)
+			require.NoError(t, err)
+			exitEpochs := make([][]phase0.Epoch, 0, len(batches))
+			for _, batch := range batches {
+				epochs := make([]phase0.Epoch, 0, len(batch))
+				for _, res := range batch {
+					epochs = append(epochs, res.exitEpoch)
+				}
+				exitEpochs = append(exitEpochs, epochs)
+			}
+			require.Equal(t, test.exitEpochs, exitEpochs)
+		})
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright © 2023 Weald Technology Trading.
+// Copyright © 2023, 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -29,6 +29,11 @@ func (c *command) obtainChainInfo(ctx context.Context) error {
 	// Use the offline preparation file if present (and we haven't been asked to recreate it).
 	if !c.prepareOffline {
 		if err = c.obtainChainInfoFromFile(ctx); err == nil {
+			if c.offline && c.networkConfig != nil {
+				// Use the network configuration for the chain parameters.
+				return c.obtainChainInfoFromNetworkConfig(ctx)
+			}
+
 			return nil
 		}
 	}
@@ -87,6 +92,22 @@ func (c *command) obtainChainInfoFromNode(ctx context.Context) error {
 	return nil
 }
 
+// obtainChainInfoFromNetworkConfig obtains chain info from the network configuration,
+// retaining the validators from the existing chain info.
+func (c *command) obtainChainInfoFromNetworkConfig(ctx context.Context) error {
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Populating chain info from network configuration\n")
+	}
+
+	var err error
+	c.chainInfo, err = beacon.ObtainChainInfoFromNetworkConfig(ctx, c.networkConfig, c.chainTime, c.chainInfo.Validators)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // writeChainInfoToFile prepares for an offline run of this command by dumping
 // the chain information to a file.
 func (c *command) writeChainInfoToFile(_ context.Context) error {
@@ -1,4 +1,4 @@
-// Copyright © 2023 Weald Technology Trading.
+// Copyright © 2023, 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -39,6 +39,8 @@ type command struct {
 	path                  string
 	privateKey            string
 	validator             string
+	validatorsFile        string
+	churnShare            uint64
 	forkVersion           string
 	genesisValidatorsRoot string
 	prepareOffline        bool
@@ -50,6 +52,10 @@ type command struct {
 	timeout                  time.Duration
 	connection               string
 	allowInsecureConnections bool
+	connectionQuorum         int
+
+	// Network configuration for offline use.
+	networkConfig *util.NetworkConfig
 
 	// Information required to generate the operations.
 	chainInfo *beacon.ChainInfo
@@ -61,6 +67,7 @@ type command struct {
 
 	// Output.
 	signedOperations []*phase0.SignedVoluntaryExit
+	results          []*result
 }
 
 func newCommand(_ context.Context) (*command, error) {
@@ -73,6 +80,7 @@ func newCommand(_ context.Context) (*command, error) {
 		timeout:                  viper.GetDuration("timeout"),
 		connection:               viper.GetString("connection"),
 		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		connectionQuorum:         viper.GetInt("connection-quorum"),
 		prepareOffline:           viper.GetBool("prepare-offline"),
 		passphrases:              util.GetPassphrases(),
 		mnemonic:                 viper.GetString("mnemonic"),
@@ -80,6 +88,8 @@ func newCommand(_ context.Context) (*command, error) {
 		privateKey:               viper.GetString("private-key"),
 		signedOperationsInput:    viper.GetString("signed-operations"),
 		validator:                viper.GetString("validator"),
+		validatorsFile:           viper.GetString("validators-file"),
+		churnShare:               viper.GetUint64("churn-share"),
 		forkVersion:              viper.GetString("fork-version"),
 		genesisValidatorsRoot:    viper.GetString("genesis-validators-root"),
 		epoch:                    viper.GetString("epoch"),
@@ -97,6 +107,22 @@ func newCommand(_ context.Context) (*command, error) {
 		return nil, errors.New("timeout is required")
 	}
 
+	if c.validatorsFile != "" && (c.validator != "" || c.privateKey != "" || c.path != "") {
+		return nil, errors.New("validators file cannot be supplied with validator, private key or path")
+	}
+	if c.churnShare > 100 {
+		return nil, errors.New("churn share must be a percentage between 0 and 100")
+	}
+
+	var err error
+	c.networkConfig, err = util.NetworkConfigFromConfig()
+	if err != nil {
+		return nil, err
+	}
+	if c.networkConfig != nil && !c.offline {
+		return nil, errors.New("network configuration can only be used with offline")
+	}
+
 	// We are generating information for offline use, we don't need any information
 	// related to the accounts or signing.
 	if c.prepareOffline {
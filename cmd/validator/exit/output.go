@@ -1,4 +1,4 @@
-// Copyright © 2023 Weald Technology Trading.
+// Copyright © 2023, 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -18,10 +18,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
 
+// resultJSON is the JSON output for a single validator exited from a validators file.
+type resultJSON struct {
+	Validator      string                      `json:"validator"`
+	Operation      *phase0.SignedVoluntaryExit `json:"operation,omitempty"`
+	BroadcastEpoch *phase0.Epoch               `json:"broadcast_epoch,omitempty"`
+	ExitEpoch      *phase0.Epoch               `json:"exit_epoch,omitempty"`
+	Error          string                      `json:"error,omitempty"`
+}
+
 //nolint:unparam
 func (c *command) output(_ context.Context) (string, error) {
 	if c.quiet {
@@ -33,15 +44,9 @@ func (c *command) output(_ context.Context) (string, error) {
 	}
 
 	if c.json || c.offline {
-		var data []byte
-		var err error
-		if len(c.signedOperations) == 1 {
-			data, err = json.Marshal(c.signedOperations[0])
-		} else {
-			data, err = json.Marshal(c.signedOperations)
-		}
+		data, err := c.operationsJSON()
 		if err != nil {
-			return "", errors.Wrap(err, "failed to marshal signed operations")
+			return "", err
 		}
 		if c.json {
 			return string(data), nil
@@ -49,8 +54,84 @@ func (c *command) output(_ context.Context) (string, error) {
 		if err := os.WriteFile(exitOperationsFilename, data, 0o600); err != nil {
 			return "", errors.Wrap(err, fmt.Sprintf("failed to write %s", exitOperationsFilename))
 		}
-		return "", nil
+	}
+
+	if c.validatorsFile != "" || c.verbose || c.failures() != nil {
+		return c.resultsText(), nil
 	}
 
 	return "", nil
 }
+
+// operationsJSON provides the JSON for the signed operations.  When exiting validators
+// from a validators file this is the per-validator results, including failures, otherwise
+// it is the signed operations alone.
+func (c *command) operationsJSON() ([]byte, error) {
+	var data []byte
+	var err error
+	switch {
+	case c.validatorsFile != "":
+		data, err = json.Marshal(c.resultsJSON())
+	case len(c.signedOperations) == 1:
+		data, err = json.Marshal(c.signedOperations[0])
+	default:
+		data, err = json.Marshal(c.signedOperations)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal signed operations")
+	}
+
+	return data, nil
+}
+
+// resultsJSON provides a per-validator report of the results.
+func (c *command) resultsJSON() []*resultJSON {
+	results := make([]*resultJSON, 0, len(c.results))
+	for _, res := range c.results {
+		output := &resultJSON{
+			Validator: res.validator,
+			Operation: res.operation,
+		}
+		if res.exitEpoch != 0 {
+			output.ExitEpoch = &res.exitEpoch
+			if c.churnShare > 0 {
+				output.BroadcastEpoch = &res.broadcastEpoch
+			}
+		}
+		if res.err != nil {
+			output.Operation = nil
+			output.Error = res.err.Error()
+		}
+		results = append(results, output)
+	}
+
+	return results
+}
+
+// resultsText provides a per-validator report of the results.
+func (c *command) resultsText() string {
+	builder := strings.Builder{}
+	for _, res := range c.results {
+		builder.WriteString(res.validator)
+		builder.WriteString(": ")
+		switch {
+		case res.err != nil:
+			builder.WriteString(res.err.Error())
+		case res.exitEpoch != 0:
+			builder.WriteString(fmt.Sprintf("exit at approximately epoch %d (%s)",
+				res.exitEpoch,
+				c.chainTime.StartOfEpoch(res.exitEpoch).Format("2006-01-02 15:04:05"),
+			))
+			if c.churnShare > 0 {
+				builder.WriteString(fmt.Sprintf("; broadcast in epoch %d", res.broadcastEpoch))
+			}
+		case c.offline:
+			builder.WriteString(fmt.Sprintf("exit operation written to %s", exitOperationsFilename))
+		default:
+			builder.WriteString("exit operation broadcast")
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
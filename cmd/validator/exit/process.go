@@ -1,4 +1,4 @@
-// Copyright © 2023 Weald Technology Trading.
+// Copyright © 2023, 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -74,11 +74,15 @@ func (c *command) process(ctx context.Context) error {
 	}
 
 	if len(c.signedOperations) == 0 {
+		if len(c.results) > 0 {
+			return errors.Wrap(c.results[0].err, fmt.Sprintf("no suitable validators found; no operations generated; %s failed", c.results[0].validator))
+		}
 		return errors.New("no suitable validators found; no operations generated")
 	}
+	c.populateResults()
 
-	if validated, reason := c.validateOperations(ctx); !validated {
-		return fmt.Errorf("operations failed validation: %s", reason)
+	if err := c.validateOperations(ctx); err != nil {
+		return errors.Wrap(err, "operations failed validation")
 	}
 
 	if c.json || c.offline {
@@ -93,6 +97,10 @@ func (c *command) process(ctx context.Context) error {
 }
 
 func (c *command) obtainOperations(ctx context.Context) error {
+	if c.validatorsFile != "" {
+		return c.generateOperationsFromValidatorsFile(ctx)
+	}
+
 	if c.mnemonic == "" && c.privateKey == "" && c.validator == "" {
 		// No input information; fetch the operation from a file.
 		err := c.obtainOperationsFromFileOrInput(ctx)
@@ -154,7 +162,10 @@ func (c *command) generateOperationFromMnemonicAndPath(ctx context.Context) erro
 		return errors.Wrap(err, "failed to generate operation from seed and path")
 	}
 	if !found {
-		return errors.New("no validator found with the provided path and mnemonic, please run with --debug to see more information")
+		return util.ErrorWithCode(errors.New("no validator found with the provided path and mnemonic, please run with --debug to see more information"),
+			util.ErrorCodeValidatorNotFound,
+			map[string]any{"path": c.path},
+		)
 	}
 
 	return nil
@@ -296,7 +307,8 @@ func (c *command) obtainOperationsFromFile(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to read exit operations file")
 	}
-	if err := json.Unmarshal(data, &c.signedOperations); err != nil {
+	c.signedOperations, err = parseSignedOperations(data)
+	if err != nil {
 		return errors.Wrap(err, "failed to parse exit operations file")
 	}
 
@@ -319,13 +331,44 @@ func (c *command) obtainOperationsFromInput(ctx context.Context) error {
 		c.signedOperationsInput = fmt.Sprintf("[%s]", c.signedOperationsInput)
 	}
 
-	if err := json.Unmarshal([]byte(c.signedOperationsInput), &c.signedOperations); err != nil {
+	var err error
+	c.signedOperations, err = parseSignedOperations([]byte(c.signedOperationsInput))
+	if err != nil {
 		return errors.Wrap(err, "failed to parse exit operation input")
 	}
 
 	return c.verifySignedOperations(ctx)
 }
 
+// parseSignedOperations parses a list of signed operations.  The list can also be the
+// per-validator results generated from a validators file, in which case the operations
+// are taken from the results, skipping any validators that failed.
+func parseSignedOperations(data []byte) ([]*phase0.SignedVoluntaryExit, error) {
+	items := make([]json.RawMessage, 0)
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+
+	operations := make([]*phase0.SignedVoluntaryExit, 0, len(items))
+	for _, item := range items {
+		res := &resultJSON{}
+		if err := json.Unmarshal(item, res); err == nil && res.Validator != "" {
+			if res.Operation != nil {
+				operations = append(operations, res.Operation)
+			}
+			continue
+		}
+
+		operation := &phase0.SignedVoluntaryExit{}
+		if err := json.Unmarshal(item, operation); err != nil {
+			return nil, err
+		}
+		operations = append(operations, operation)
+	}
+
+	return operations, nil
+}
+
 func (c *command) generateOperationFromSeedAndPath(ctx context.Context,
 	validators map[string]*beacon.ValidatorInfo,
 	seed []byte,
@@ -343,7 +386,7 @@ func (c *command) generateOperationFromSeedAndPath(ctx context.Context,
 
 	validatorInfo, exists := validators[fmt.Sprintf("%#x", validatorPrivkey.PublicKey().Marshal())]
 	if !exists {
-		return false, errors.New("unknown validator")
+		return false, util.NewError(util.ErrorCodeValidatorNotFound, "unknown validator")
 	}
 	if validatorInfo.State == apiv1.ValidatorStateActiveExiting ||
 		validatorInfo.State == apiv1.ValidatorStateActiveSlashed ||
@@ -351,7 +394,10 @@ func (c *command) generateOperationFromSeedAndPath(ctx context.Context,
 		validatorInfo.State == apiv1.ValidatorStateExitedSlashed ||
 		validatorInfo.State == apiv1.ValidatorStateWithdrawalPossible ||
 		validatorInfo.State == apiv1.ValidatorStateWithdrawalDone {
-		return false, fmt.Errorf("validator is in state %v, not suitable to generate an exit", validatorInfo.State)
+		return false, util.ErrorWithCode(fmt.Errorf("validator is in state %v, not suitable to generate an exit", validatorInfo.State),
+			util.ErrorCodeNotEligibleToExit,
+			map[string]any{"index": validatorInfo.Index, "state": validatorInfo.State.String()},
+		)
 	}
 
 	validatorAccount, err := util.ParseAccount(ctx, privateKey, nil, true)
@@ -519,23 +565,19 @@ func (c *command) verifySignedOperation(ctx context.Context, op *phase0.SignedVo
 	return nil
 }
 
-func (c *command) validateOperations(ctx context.Context) (bool, string) {
+func (c *command) validateOperations(ctx context.Context) error {
 	for _, op := range c.signedOperations {
-		valid, issue := c.validateOperation(ctx, op)
-		if !valid {
-			return valid, issue
+		if err := c.validateOperation(ctx, op); err != nil {
+			return err
 		}
 	}
 
-	return true, ""
+	return nil
 }
 
 func (c *command) validateOperation(_ context.Context,
 	op *phase0.SignedVoluntaryExit,
-) (
-	bool,
-	string,
-) {
+) error {
 	var validatorInfo *beacon.ValidatorInfo
 	for _, chainValidatorInfo := range c.chainInfo.Validators {
 		if chainValidatorInfo.Index == op.Message.ValidatorIndex {
@@ -544,7 +586,10 @@ func (c *command) validateOperation(_ context.Context,
 		}
 	}
 	if validatorInfo == nil {
-		return false, "validator not known on chain"
+		return util.ErrorWithCode(errors.New("validator not known on chain"),
+			util.ErrorCodeValidatorNotFound,
+			map[string]any{"index": op.Message.ValidatorIndex},
+		)
 	}
 	if c.debug {
 		fmt.Fprintf(os.Stderr, "Validator exit operation: %v", op)
@@ -557,23 +602,43 @@ func (c *command) validateOperation(_ context.Context,
 		validatorInfo.State == apiv1.ValidatorStateExitedSlashed ||
 		validatorInfo.State == apiv1.ValidatorStateWithdrawalPossible ||
 		validatorInfo.State == apiv1.ValidatorStateWithdrawalDone {
-		return false, fmt.Sprintf("validator is in state %v, not suitable to generate an exit", validatorInfo.State)
+		return util.ErrorWithCode(fmt.Errorf("validator is in state %v, not suitable to generate an exit", validatorInfo.State),
+			util.ErrorCodeNotEligibleToExit,
+			map[string]any{"index": op.Message.ValidatorIndex, "state": validatorInfo.State.String()},
+		)
 	}
 
-	return true, ""
+	return nil
 }
 
 func (c *command) broadcastOperations(ctx context.Context) error {
-	for _, op := range c.signedOperations {
-		if c.debug {
-			data, err := json.Marshal(op)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Broadcasting %s\n", string(data))
-			}
+	batches, err := c.scheduleOperations(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range batches {
+		if len(batch) == 0 {
+			continue
 		}
-		if err := c.consensusClient.(consensusclient.VoluntaryExitSubmitter).SubmitVoluntaryExit(ctx, op); err != nil {
+		if err := c.waitForEpoch(ctx, batch[0].broadcastEpoch); err != nil {
 			return err
 		}
+		for _, res := range batch {
+			if c.debug {
+				data, err := json.Marshal(res.operation)
+				if err == nil {
+					fmt.Fprintf(os.Stderr, "Broadcasting %s\n", string(data))
+				}
+			}
+			if err := c.consensusClient.(consensusclient.VoluntaryExitSubmitter).SubmitVoluntaryExit(ctx, res.operation); err != nil {
+				if len(c.results) == 1 {
+					return err
+				}
+				// Failures are reported per-validator with the results.
+				res.err = errors.Wrap(err, "failed to broadcast exit operation")
+			}
+		}
 	}
 
 	return nil
@@ -581,6 +646,20 @@ func (c *command) broadcastOperations(ctx context.Context) error {
 
 func (c *command) setup(ctx context.Context) error {
 	if c.offline {
+		if c.networkConfig == nil {
+			return nil
+		}
+
+		// Set up chaintime from the network configuration.
+		var err error
+		c.chainTime, err = standardchaintime.New(ctx,
+			standardchaintime.WithGenesisProvider(c.networkConfig),
+			standardchaintime.WithSpecProvider(c.networkConfig),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to create chaintime service")
+		}
+
 		return nil
 	}
 
@@ -599,6 +678,7 @@ func (c *command) setup(ctx context.Context) error {
 		Timeout:       c.timeout,
 		AllowInsecure: c.allowInsecureConnections,
 		LogFallback:   !c.quiet,
+		Quorum:        c.connectionQuorum,
 	})
 	if err != nil {
 		return err
@@ -19,6 +19,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 )
 
 // Run runs the command.
@@ -27,7 +28,7 @@ func Run(cmd *cobra.Command) (string, error) {
 
 	c, err := newCommand(ctx)
 	if err != nil {
-		return "", errors.Join(errors.New("failed to set up command"), err)
+		return "", util.ErrorWithCode(errors.Join(errors.New("failed to set up command"), err), util.ErrorCodeInvalidInput, nil)
 	}
 
 	// Further errors do not need a usage report.
@@ -36,14 +37,14 @@ func Run(cmd *cobra.Command) (string, error) {
 	if err := c.process(ctx); err != nil {
 		switch {
 		case errors.Is(err, context.DeadlineExceeded):
-			return "", errors.New("operation timed out; try increasing with --timeout option")
+			return "", util.NewError(util.ErrorCodeTimeout, "operation timed out; try increasing with --timeout option")
 		default:
 			return "", errors.Join(errors.New("failed to process"), err)
 		}
 	}
 
 	if viper.GetBool("quiet") {
-		return "", nil
+		return "", c.failures()
 	}
 
 	results, err := c.output(ctx)
@@ -51,5 +52,6 @@ func Run(cmd *cobra.Command) (string, error) {
 		return "", errors.Join(errors.New("failed to obtain output"), err)
 	}
 
-	return results, nil
+	// Output the results even if some validators failed, so that the failures are reported.
+	return results, c.failures()
 }
@@ -23,13 +23,15 @@ import (
 	"github.com/hako/durafmt"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type command struct {
-	quiet   bool
-	verbose bool
-	debug   bool
-	json    bool
+	quiet    bool
+	verbose  bool
+	debug    bool
+	format   string
+	template string
 
 	// Beacon node connection.
 	timeout                  time.Duration
@@ -74,11 +76,12 @@ func (r *results) MarshalJSON() ([]byte, error) {
 
 func newCommand(_ context.Context) (*command, error) {
 	c := &command{
-		quiet:   viper.GetBool("quiet"),
-		verbose: viper.GetBool("verbose"),
-		debug:   viper.GetBool("debug"),
-		json:    viper.GetBool("json"),
-		res:     &results{},
+		quiet:    viper.GetBool("quiet"),
+		verbose:  viper.GetBool("verbose"),
+		debug:    viper.GetBool("debug"),
+		format:   util.OutputFormat(),
+		template: viper.GetString("template"),
+		res:      &results{},
 	}
 
 	// Timeout.
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"github.com/hako/durafmt"
+	"github.com/wealdtech/ethdo/util"
 )
 
 func (c *command) output(ctx context.Context) (string, error) {
@@ -27,10 +28,12 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
-	if c.json {
-		return c.outputJSON(ctx)
-	}
-	return c.outputTxt(ctx)
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputTxt,
+	})
 }
 
 func (c *command) outputJSON(_ context.Context) (string, error) {
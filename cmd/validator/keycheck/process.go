@@ -1,4 +1,4 @@
-// Copyright © 2021 Weald Technology Trading
+// Copyright © 2021, 2025 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -17,7 +17,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
-	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -101,23 +100,5 @@ func checkMnemonic(ctx context.Context, debug bool, validatorWithdrawalCredentia
 	// Create seed from mnemonic and passphrase.
 	seed := bip39.NewSeed(mnemonic, mnemonicPassphrase)
 	// Check first 1024 indices.
-	for i := range 1024 {
-		path := fmt.Sprintf("m/12381/3600/%d/0", i)
-		if debug {
-			fmt.Printf("Checking path %s\n", path)
-		}
-		key, err := util.PrivateKeyFromSeedAndPath(seed, path)
-		if err != nil {
-			return false, "", errors.Wrap(err, "failed to generate key")
-		}
-		match, err := checkPrivKey(ctx, validatorWithdrawalCredentials, key)
-		if err != nil {
-			return false, "", errors.Wrap(err, "failed to match key")
-		}
-		if match {
-			return true, path, nil
-		}
-	}
-
-	return false, "", nil
+	return CheckSeed(ctx, debug, seed, &Target{WithdrawalCredentials: validatorWithdrawalCredentials}, 1024)
 }
@@ -0,0 +1,120 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorkeycheck
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+// Target is a key against which keys derived from a seed are checked.
+// Exactly one of the fields should be set.
+type Target struct {
+	// PubKey is the public key of a validator.
+	PubKey []byte
+	// WithdrawalCredentials are BLS (0x00) withdrawal credentials.
+	WithdrawalCredentials []byte
+}
+
+// CheckSeed checks the EIP-2334 keys derived from the seed for the first
+// count indices against the target, returning the matching path if found.
+func CheckSeed(ctx context.Context, debug bool, seed []byte, target *Target, count int) (bool, string, error) {
+	if target == nil {
+		return false, "", errors.New("no target supplied")
+	}
+
+	// The common part of the path only needs to be derived once.
+	sk, err := util.DeriveMasterSK(seed)
+	if err != nil {
+		return false, "", errors.Wrap(err, "failed to generate master key")
+	}
+	for _, index := range []uint32{12381, 3600} {
+		sk, err = util.DeriveChildSK(sk, index)
+		if err != nil {
+			return false, "", errors.Wrap(err, "failed to generate key")
+		}
+	}
+
+	for i := range count {
+		if err := ctx.Err(); err != nil {
+			return false, "", err
+		}
+
+		// Withdrawal key is at m/12381/3600/i/0.
+		path := fmt.Sprintf("m/12381/3600/%d/0", i)
+		if debug {
+			fmt.Printf("Checking path %s\n", path)
+		}
+		indexSK, err := util.DeriveChildSK(sk, uint32(i))
+		if err != nil {
+			return false, "", errors.Wrap(err, "failed to generate key")
+		}
+		withdrawalSK, err := util.DeriveChildSK(indexSK, 0)
+		if err != nil {
+			return false, "", errors.Wrap(err, "failed to generate key")
+		}
+
+		if target.WithdrawalCredentials != nil {
+			key, err := privateKey(withdrawalSK)
+			if err != nil {
+				return false, "", err
+			}
+			match, err := checkPrivKey(ctx, target.WithdrawalCredentials, key)
+			if err != nil {
+				return false, "", errors.Wrap(err, "failed to match key")
+			}
+			if match {
+				return true, path, nil
+			}
+		}
+
+		if target.PubKey != nil {
+			// Validator key is at m/12381/3600/i/0/0.
+			validatorSK, err := util.DeriveChildSK(withdrawalSK, 0)
+			if err != nil {
+				return false, "", errors.Wrap(err, "failed to generate key")
+			}
+			key, err := privateKey(validatorSK)
+			if err != nil {
+				return false, "", err
+			}
+			if bytes.Equal(key.PublicKey().Marshal(), target.PubKey) {
+				return true, fmt.Sprintf("%s/0", path), nil
+			}
+		}
+	}
+
+	return false, "", nil
+}
+
+// privateKey creates a BLS private key from a derived secret key.
+func privateKey(sk *big.Int) (*e2types.BLSPrivateKey, error) {
+	// SK can be shorter than 32 bytes so left-pad it here.
+	keyBytes := make([]byte, 32)
+	skBytes := sk.Bytes()
+	copy(keyBytes[32-len(skBytes):], skBytes)
+
+	key, err := e2types.BLSPrivateKeyFromBytes(keyBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate key")
+	}
+
+	return key, nil
+}
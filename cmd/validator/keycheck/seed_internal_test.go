@@ -0,0 +1,90 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorkeycheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tyler-smith/go-bip39"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestCheckSeed(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	seed := bip39.NewSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+
+	validatorKey, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/2/0/0")
+	require.NoError(t, err)
+	withdrawalKey, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/3/0")
+	require.NoError(t, err)
+	withdrawalCredentials := util.SHA256(withdrawalKey.PublicKey().Marshal())
+	withdrawalCredentials[0] = byte(0)
+
+	tests := []struct {
+		name   string
+		target *Target
+		count  int
+		match  bool
+		path   string
+		err    string
+	}{
+		{
+			name:  "TargetMissing",
+			count: 4,
+			err:   "no target supplied",
+		},
+		{
+			name:   "PubKey",
+			target: &Target{PubKey: validatorKey.PublicKey().Marshal()},
+			count:  4,
+			match:  true,
+			path:   "m/12381/3600/2/0/0",
+		},
+		{
+			name:   "PubKeyOutOfRange",
+			target: &Target{PubKey: validatorKey.PublicKey().Marshal()},
+			count:  2,
+		},
+		{
+			name:   "WithdrawalCredentials",
+			target: &Target{WithdrawalCredentials: withdrawalCredentials},
+			count:  4,
+			match:  true,
+			path:   "m/12381/3600/3/0",
+		},
+		{
+			name:   "WithdrawalCredentialsOutOfRange",
+			target: &Target{WithdrawalCredentials: withdrawalCredentials},
+			count:  3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			match, path, err := CheckSeed(ctx, false, seed, test.target, test.count)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.match, match)
+				require.Equal(t, test.path, path)
+			}
+		})
+	}
+}
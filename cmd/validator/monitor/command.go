@@ -0,0 +1,101 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatormonitor
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	validatorsummary "github.com/wealdtech/ethdo/cmd/validator/summary"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	validators    []string
+	webhook       string
+	finalized     bool
+	exitOnProblem bool
+
+	// Data access.
+	eth2Client     eth2client.Service
+	chainTime      chaintime.Service
+	eventsProvider eth2client.EventsProvider
+	// evaluate obtains the faults for an epoch; replaceable for testing.
+	evaluate func(ctx context.Context, epoch phase0.Epoch) ([]*validatorsummary.Fault, error)
+
+	// Output.
+	out io.Writer
+
+	// Processing.
+	mu            sync.Mutex
+	nextEpoch     phase0.Epoch
+	cancel        context.CancelFunc
+	err           error
+	problemsFound bool
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		validators:               viper.GetStringSlice("validators"),
+		webhook:                  viper.GetString("webhook"),
+		finalized:                viper.GetBool("finalized"),
+		exitOnProblem:            viper.GetBool("exit-on-problem"),
+		out:                      os.Stdout,
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if len(c.validators) == 0 {
+		return nil, errors.New("validators are required")
+	}
+
+	if c.webhook != "" {
+		webhook, err := url.Parse(c.webhook)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid webhook")
+		}
+		if webhook.Scheme != "http" && webhook.Scheme != "https" {
+			return nil, errors.New("webhook must be an HTTP or HTTPS URL")
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,46 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatormonitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	validatorsummary "github.com/wealdtech/ethdo/cmd/validator/summary"
+)
+
+func (*command) output(_ context.Context) (string, error) {
+	// Faults are output as they are found, so nothing to output on completion.
+	return "", nil
+}
+
+// outputFaults outputs the faults as newline-delimited JSON.
+func (c *command) outputFaults(faults []*validatorsummary.Fault) error {
+	if c.quiet {
+		return nil
+	}
+
+	for _, fault := range faults {
+		data, err := json.Marshal(fault)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(c.out, string(data)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
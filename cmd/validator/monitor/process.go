@@ -0,0 +1,220 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatormonitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	validatorsummary "github.com/wealdtech/ethdo/cmd/validator/summary"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// webhookPayload is the body sent to the webhook when faults are found.
+type webhookPayload struct {
+	Epoch  phase0.Epoch              `json:"epoch"`
+	Faults []*validatorsummary.Fault `json:"faults"`
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	// Start with the latest epoch whose attestations can no longer be included.
+	currentEpoch := c.chainTime.CurrentEpoch()
+	if currentEpoch > 2 {
+		c.nextEpoch = currentEpoch - 2
+	}
+
+	ctx, c.cancel = context.WithCancel(ctx)
+	defer c.cancel()
+
+	if err := c.eventsProvider.Events(ctx, &api.EventsOpts{
+		Topics:                     []string{"head", "finalized_checkpoint"},
+		HeadHandler:                c.headEventHandler,
+		FinalizedCheckpointHandler: c.finalizedCheckpointEventHandler,
+	}); err != nil {
+		return errors.Wrap(err, "failed to start event stream")
+	}
+
+	<-ctx.Done()
+
+	return c.result()
+}
+
+// result returns the result of monitoring once it has stopped.
+func (c *command) result() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+	if c.problemsFound {
+		return errors.New("validator problems detected")
+	}
+
+	return nil
+}
+
+func (c *command) headEventHandler(ctx context.Context, event *apiv1.HeadEvent) {
+	if c.finalized {
+		return
+	}
+
+	// Attestations for an epoch can be included up to the first slot of the
+	// next-but-one epoch, so that is the point at which it can be evaluated.
+	epoch := c.chainTime.SlotToEpoch(event.Slot)
+	if epoch < 2 {
+		return
+	}
+	// Evaluation can take some time, so do not hold up the event stream.
+	go c.evaluateTo(ctx, epoch-2)
+}
+
+func (c *command) finalizedCheckpointEventHandler(ctx context.Context, event *apiv1.FinalizedCheckpointEvent) {
+	if !c.finalized {
+		return
+	}
+
+	// The finalized checkpoint is the first slot of its epoch, so the
+	// attestations for two epochs earlier are all finalized.
+	if event.Epoch < 2 {
+		return
+	}
+	go c.evaluateTo(ctx, event.Epoch-2)
+}
+
+// evaluateTo evaluates all epochs up to and including the given epoch that
+// have not yet been evaluated.
+func (c *command) evaluateTo(ctx context.Context, epoch phase0.Epoch) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for ; c.nextEpoch <= epoch; c.nextEpoch++ {
+		if ctx.Err() != nil {
+			return
+		}
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Evaluating epoch %d\n", c.nextEpoch)
+		}
+
+		faults, err := c.evaluate(ctx, c.nextEpoch)
+		if err != nil {
+			c.err = errors.Wrap(err, fmt.Sprintf("failed to evaluate epoch %d", c.nextEpoch))
+			c.cancel()
+
+			return
+		}
+		if len(faults) == 0 {
+			continue
+		}
+
+		if err := c.outputFaults(faults); err != nil {
+			c.err = errors.Wrap(err, "failed to output faults")
+			c.cancel()
+
+			return
+		}
+		if c.webhook != "" {
+			if err := c.postWebhook(ctx, c.nextEpoch, faults); err != nil && !c.quiet {
+				// A failing webhook should not stop monitoring.
+				fmt.Fprintf(os.Stderr, "Failed to send faults for epoch %d to webhook: %v\n", c.nextEpoch, err)
+			}
+		}
+		if c.exitOnProblem {
+			c.problemsFound = true
+			c.cancel()
+
+			return
+		}
+	}
+}
+
+// postWebhook sends the faults for an epoch to the webhook.
+func (c *command) postWebhook(ctx context.Context, epoch phase0.Epoch, faults []*validatorsummary.Fault) error {
+	data, err := json.Marshal(&webhookPayload{
+		Epoch:  epoch,
+		Faults: faults,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.webhook, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.eventsProvider, isProvider = c.eth2Client.(eth2client.EventsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide events")
+	}
+
+	c.evaluate = func(ctx context.Context, epoch phase0.Epoch) ([]*validatorsummary.Fault, error) {
+		return validatorsummary.EpochFaults(ctx, c.eth2Client, c.chainTime, c.validators, epoch)
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatormonitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	validatorsummary "github.com/wealdtech/ethdo/cmd/validator/summary"
+)
+
+// faultyEpochs returns an evaluation function that finds a fault in the given epochs.
+func faultyEpochs(evaluated *[]phase0.Epoch, faulty ...phase0.Epoch) func(context.Context, phase0.Epoch) ([]*validatorsummary.Fault, error) {
+	return func(_ context.Context, epoch phase0.Epoch) ([]*validatorsummary.Fault, error) {
+		*evaluated = append(*evaluated, epoch)
+		for _, faultyEpoch := range faulty {
+			if epoch == faultyEpoch {
+				return []*validatorsummary.Fault{
+					{
+						Epoch:          epoch,
+						Slot:           phase0.Slot(epoch * 32),
+						ValidatorIndex: 1,
+						Type:           validatorsummary.FaultNonParticipating,
+					},
+				}, nil
+			}
+		}
+
+		return nil, nil
+	}
+}
+
+func TestEvaluateTo(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	evaluated := make([]phase0.Epoch, 0)
+	out := &bytes.Buffer{}
+	c := &command{
+		timeout:   time.Second,
+		nextEpoch: 10,
+		out:       out,
+		cancel:    cancel,
+		evaluate:  faultyEpochs(&evaluated, 11, 13),
+	}
+
+	c.evaluateTo(ctx, 12)
+	require.Equal(t, []phase0.Epoch{10, 11, 12}, evaluated)
+	require.Equal(t, "{\"epoch\":\"11\",\"slot\":\"352\",\"validator_index\":\"1\",\"type\":\"non_participating\"}\n", out.String())
+
+	// Already evaluated epochs are not evaluated again.
+	c.evaluateTo(ctx, 12)
+	require.Len(t, evaluated, 3)
+
+	c.evaluateTo(ctx, 13)
+	require.Equal(t, []phase0.Epoch{10, 11, 12, 13}, evaluated)
+	require.Equal(t, 2, strings.Count(out.String(), "\n"))
+	require.NoError(t, c.result())
+	require.NoError(t, ctx.Err())
+}
+
+func TestEvaluateToExitOnProblem(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	evaluated := make([]phase0.Epoch, 0)
+	c := &command{
+		quiet:         true,
+		exitOnProblem: true,
+		nextEpoch:     10,
+		out:           &bytes.Buffer{},
+		cancel:        cancel,
+		evaluate:      faultyEpochs(&evaluated, 11),
+	}
+
+	c.evaluateTo(ctx, 20)
+	require.Equal(t, []phase0.Epoch{10, 11}, evaluated)
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+	require.EqualError(t, c.result(), "validator problems detected")
+}
+
+func TestEvaluateToError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := &command{
+		nextEpoch: 10,
+		out:       &bytes.Buffer{},
+		cancel:    cancel,
+		evaluate: func(_ context.Context, _ phase0.Epoch) ([]*validatorsummary.Fault, error) {
+			return nil, errors.New("no beacon node")
+		},
+	}
+
+	c.evaluateTo(ctx, 20)
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+	require.EqualError(t, c.result(), "failed to evaluate epoch 10: no beacon node")
+}
+
+func TestWebhook(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	payloads := make([]*webhookPayload, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		payload := &webhookPayload{}
+		require.NoError(t, json.Unmarshal(body, payload))
+		payloads = append(payloads, payload)
+	}))
+	defer srv.Close()
+
+	evaluated := make([]phase0.Epoch, 0)
+	c := &command{
+		quiet:     true,
+		timeout:   time.Second,
+		webhook:   srv.URL,
+		nextEpoch: 10,
+		out:       &bytes.Buffer{},
+		cancel:    cancel,
+		evaluate:  faultyEpochs(&evaluated, 12),
+	}
+
+	c.evaluateTo(ctx, 13)
+	require.Len(t, payloads, 1)
+	require.Equal(t, phase0.Epoch(12), payloads[0].Epoch)
+	require.Len(t, payloads[0].Faults, 1)
+	require.Equal(t, phase0.ValidatorIndex(1), payloads[0].Faults[0].ValidatorIndex)
+
+	// A failing webhook does not stop monitoring.
+	c.webhook = srv.URL + "/missing"
+	srv.Config.Handler = http.NotFoundHandler()
+	require.EqualError(t, c.postWebhook(ctx, 12, payloads[0].Faults), "webhook returned status 404")
+	c.evaluate = faultyEpochs(&evaluated, 14)
+	c.evaluateTo(ctx, 15)
+	require.Equal(t, phase0.Epoch(16), c.nextEpoch)
+	require.NoError(t, c.result())
+}
@@ -0,0 +1,145 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatoroperationstatus
+
+import (
+	"context"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet    bool
+	verbose  bool
+	debug    bool
+	format   string
+	template string
+
+	// Input.
+	operationsInput string
+	wait            time.Duration
+	searchSlots     uint64
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Processing.
+	consensusClient consensusclient.Service
+	chainTime       chaintime.Service
+	// lastSearchedSlot is the last slot searched for included operations.
+	lastSearchedSlot phase0.Slot
+
+	// Output.
+	operations []*operation
+}
+
+// operation is a signed operation and its current status.
+type operation struct {
+	exit   *phase0.SignedVoluntaryExit
+	change *capella.SignedBLSToExecutionChange
+
+	status operationStatus
+	// slot is the slot of the block in which the operation was included, if known.
+	slot phase0.Slot
+	// detail provides additional information about the status.
+	detail string
+}
+
+// operationStatus is the status of an operation.
+type operationStatus int
+
+const (
+	// statusNotFound is an operation that has not been seen in the pool or on the chain.
+	statusNotFound operationStatus = iota
+	// statusPool is an operation that is in the node's pool awaiting inclusion.
+	statusPool
+	// statusIncluded is an operation that has been included in a block.
+	statusIncluded
+	// statusApplied is an operation whose effect is present in the validator's state.
+	statusApplied
+	// statusSuperseded is an operation whose validator has been changed by another operation.
+	statusSuperseded
+	// statusInvalid is an operation that cannot be applied.
+	statusInvalid
+)
+
+var operationStatusStrings = [...]string{
+	"not found",
+	"in pool",
+	"included",
+	"applied",
+	"superseded",
+	"invalid",
+}
+
+func (s operationStatus) String() string {
+	if int(s) < 0 || int(s) >= len(operationStatusStrings) {
+		return "unknown"
+	}
+
+	return operationStatusStrings[s]
+}
+
+// final returns true if the status will not change further.
+func (s operationStatus) final() bool {
+	return s == statusIncluded || s == statusApplied || s == statusSuperseded || s == statusInvalid
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		format:                   util.OutputFormat(),
+		template:                 viper.GetString("template"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		operationsInput:          viper.GetString("operations"),
+		wait:                     viper.GetDuration("wait"),
+		searchSlots:              viper.GetUint64("search-slots"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.wait < 0 {
+		return nil, errors.New("wait cannot be negative")
+	}
+
+	return c, nil
+}
+
+// outstanding returns the number of operations that have not yet reached a final status.
+func (c *command) outstanding() int {
+	outstanding := 0
+	for _, operation := range c.operations {
+		if !operation.status.final() {
+			outstanding++
+		}
+	}
+
+	return outstanding
+}
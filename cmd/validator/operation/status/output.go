@@ -0,0 +1,91 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatoroperationstatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+type operationJSON struct {
+	Type           string `json:"type"`
+	ValidatorIndex string `json:"validator_index"`
+	Status         string `json:"status"`
+	Slot           string `json:"slot,omitempty"`
+	Detail         string `json:"detail,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputText,
+	})
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	operations := make([]*operationJSON, 0, len(c.operations))
+	for _, operation := range c.operations {
+		output := &operationJSON{
+			Type:           operation.typeString(),
+			ValidatorIndex: fmt.Sprintf("%d", operation.index()),
+			Status:         operation.status.String(),
+			Detail:         operation.detail,
+		}
+		if operation.status == statusIncluded {
+			output.Slot = fmt.Sprintf("%d", operation.slot)
+		}
+		operations = append(operations, output)
+	}
+
+	data, err := json.Marshal(operations)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+	for _, operation := range c.operations {
+		builder.WriteString(fmt.Sprintf("Validator %d %s: %s", operation.index(), operation.typeString(), operation.status))
+		if operation.status == statusIncluded {
+			builder.WriteString(fmt.Sprintf(" in slot %d", operation.slot))
+		}
+		if operation.detail != "" {
+			builder.WriteString(fmt.Sprintf(" (%s)", operation.detail))
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (o *operation) typeString() string {
+	if o.exit != nil {
+		return "exit"
+	}
+
+	return "credentials change"
+}
@@ -0,0 +1,452 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatoroperationstatus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+const (
+	exitOperationsFilename   = "exit-operations.json"
+	changeOperationsFilename = "change-operations.json"
+	farFutureEpoch           = phase0.Epoch(0xffffffffffffffff)
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.obtainOperations(ctx); err != nil {
+		return err
+	}
+
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.update(ctx); err != nil {
+		return err
+	}
+	if c.wait == 0 {
+		return nil
+	}
+
+	deadline := time.Now().Add(c.wait)
+	for c.outstanding() > 0 {
+		// Wait until the next slot, or the deadline if that is sooner.
+		next := c.chainTime.StartOfSlot(c.chainTime.CurrentSlot() + 1)
+		if next.After(deadline) {
+			next = deadline
+		}
+		if c.verbose {
+			fmt.Fprintf(os.Stderr, "%d operation(s) outstanding; waiting until %s\n", c.outstanding(), next.Format("15:04:05"))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Until(next)):
+		}
+		if !time.Now().Before(deadline) {
+			break
+		}
+		if err := c.update(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// obtainOperations obtains the operations from the input, or from the standard files.
+func (c *command) obtainOperations(_ context.Context) error {
+	input := c.operationsInput
+	if input == "" {
+		for _, filename := range []string{exitOperationsFilename, changeOperationsFilename} {
+			if _, err := os.Stat(filename); err == nil {
+				input = filename
+				break
+			}
+		}
+		if input == "" {
+			return fmt.Errorf("no operations supplied, and neither %s nor %s found", exitOperationsFilename, changeOperationsFilename)
+		}
+	}
+
+	var err error
+	c.operations, err = parseOperations(input)
+
+	return err
+}
+
+// parseOperations parses exit or credential change operations from a file or JSON input.
+func parseOperations(input string) ([]*operation, error) {
+	if !strings.HasPrefix(input, "{") && !strings.HasPrefix(input, "[") {
+		// This looks like a file; read it in.
+		data, err := os.ReadFile(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read operations file")
+		}
+		input = strings.TrimSpace(string(data))
+	}
+	if strings.HasPrefix(input, "{") {
+		// Single operation; put it in an array.
+		input = fmt.Sprintf("[%s]", input)
+	}
+
+	items := make([]json.RawMessage, 0)
+	if err := json.Unmarshal([]byte(input), &items); err != nil {
+		return nil, errors.Wrap(err, "failed to parse operations")
+	}
+
+	operations := make([]*operation, 0, len(items))
+	for i, item := range items {
+		// Items can be the per-validator results of validator exit with a validators file.
+		res := &struct {
+			Validator string          `json:"validator"`
+			Operation json.RawMessage `json:"operation"`
+		}{}
+		if err := json.Unmarshal(item, res); err == nil && res.Validator != "" {
+			if len(res.Operation) == 0 {
+				// No operation was generated for this validator.
+				continue
+			}
+			item = res.Operation
+		}
+
+		op := &operation{}
+		if bytes.Contains(item, []byte(`"from_bls_pubkey"`)) {
+			op.change = &capella.SignedBLSToExecutionChange{}
+			if err := json.Unmarshal(item, op.change); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse credential change operation %d", i)
+			}
+		} else {
+			op.exit = &phase0.SignedVoluntaryExit{}
+			if err := json.Unmarshal(item, op.exit); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse exit operation %d", i)
+			}
+		}
+		operations = append(operations, op)
+	}
+	if len(operations) == 0 {
+		return nil, errors.New("no operations supplied")
+	}
+
+	return operations, nil
+}
+
+// index returns the index of the validator to which the operation applies.
+func (o *operation) index() phase0.ValidatorIndex {
+	if o.exit != nil {
+		return o.exit.Message.ValidatorIndex
+	}
+
+	return o.change.Message.ValidatorIndex
+}
+
+// update updates the status of the operations that are not yet final.
+func (c *command) update(ctx context.Context) error {
+	if err := c.searchBlocks(ctx); err != nil {
+		return err
+	}
+
+	indices := make([]phase0.ValidatorIndex, 0, len(c.operations))
+	for _, operation := range c.operations {
+		indices = append(indices, operation.index())
+	}
+	validatorsResponse, err := c.consensusClient.(consensusclient.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{
+		State:   "head",
+		Indices: indices,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+
+	exitPool, err := c.obtainExitPool(ctx)
+	if err != nil {
+		return err
+	}
+
+	changePool, err := c.obtainChangePool(ctx)
+	if err != nil {
+		return err
+	}
+
+	shardCommitteePeriod, err := c.obtainShardCommitteePeriod(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, operation := range c.operations {
+		if operation.status == statusIncluded {
+			continue
+		}
+		validator := validatorsResponse.Data[operation.index()]
+		if operation.exit != nil {
+			updateExitStatus(operation, validator, exitPool, c.chainTime.CurrentEpoch(), shardCommitteePeriod)
+		} else {
+			updateChangeStatus(operation, validator, changePool)
+		}
+	}
+
+	return nil
+}
+
+// searchBlocks searches blocks since the last search for the operations.
+func (c *command) searchBlocks(ctx context.Context) error {
+	headSlot := c.chainTime.CurrentSlot()
+	startSlot := c.lastSearchedSlot + 1
+	if c.lastSearchedSlot == 0 {
+		startSlot = 0
+		if headSlot > phase0.Slot(c.searchSlots) {
+			startSlot = headSlot - phase0.Slot(c.searchSlots)
+		}
+	}
+
+	for slot := startSlot; slot <= headSlot; slot++ {
+		blockResponse, err := c.consensusClient.(consensusclient.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// Empty slot.
+				continue
+			}
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if err := searchBlock(blockResponse.Data, slot, c.operations); err != nil {
+			return err
+		}
+		c.lastSearchedSlot = slot
+	}
+
+	return nil
+}
+
+// searchBlock marks the operations included in the given block.
+func searchBlock(block operationsBlock, slot phase0.Slot, operations []*operation) error {
+	exits, err := block.VoluntaryExits()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain voluntary exits")
+	}
+	changes, err := block.BLSToExecutionChanges()
+	if err != nil {
+		// Blocks prior to Capella do not have credential changes.
+		changes = nil
+	}
+
+	for _, operation := range operations {
+		if operation.exit != nil {
+			for _, exit := range exits {
+				if exit.Message.ValidatorIndex == operation.exit.Message.ValidatorIndex &&
+					exit.Signature == operation.exit.Signature {
+					operation.status = statusIncluded
+					operation.slot = slot
+				}
+			}
+		} else {
+			for _, change := range changes {
+				if change.Message.ValidatorIndex == operation.change.Message.ValidatorIndex &&
+					change.Signature == operation.change.Signature {
+					operation.status = statusIncluded
+					operation.slot = slot
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// operationsBlock is the subset of a block used to search for operations.
+type operationsBlock interface {
+	VoluntaryExits() ([]*phase0.SignedVoluntaryExit, error)
+	BLSToExecutionChanges() ([]*capella.SignedBLSToExecutionChange, error)
+}
+
+// updateExitStatus updates the status of an exit operation that has not been found in a block.
+func updateExitStatus(operation *operation,
+	validator *apiv1.Validator,
+	exitPool []*phase0.SignedVoluntaryExit,
+	currentEpoch phase0.Epoch,
+	shardCommitteePeriod phase0.Epoch,
+) {
+	operation.detail = ""
+	switch {
+	case validator == nil:
+		operation.status = statusInvalid
+		operation.detail = "validator not known on chain"
+	case validator.Validator.Slashed:
+		operation.status = statusSuperseded
+		operation.detail = "validator has been slashed"
+	case validator.Validator.ExitEpoch != farFutureEpoch:
+		operation.status = statusApplied
+		operation.detail = fmt.Sprintf("exit epoch %d", validator.Validator.ExitEpoch)
+	case validator.Validator.ActivationEpoch > currentEpoch:
+		operation.status = statusInvalid
+		operation.detail = "validator is not active"
+	case operation.exit.Message.Epoch > currentEpoch:
+		operation.status = statusInvalid
+		operation.detail = fmt.Sprintf("exit is not valid until epoch %d, and must be broadcast again after that", operation.exit.Message.Epoch)
+	case validator.Validator.ActivationEpoch+shardCommitteePeriod > currentEpoch:
+		operation.status = statusInvalid
+		operation.detail = fmt.Sprintf("validator cannot exit until epoch %d", validator.Validator.ActivationEpoch+shardCommitteePeriod)
+	default:
+		operation.status = statusNotFound
+		for _, exit := range exitPool {
+			if exit.Message.ValidatorIndex == operation.exit.Message.ValidatorIndex &&
+				exit.Signature == operation.exit.Signature {
+				operation.status = statusPool
+				break
+			}
+		}
+	}
+}
+
+// updateChangeStatus updates the status of a credential change operation that has not been found in a block.
+func updateChangeStatus(operation *operation,
+	validator *apiv1.Validator,
+	changePool []*capella.SignedBLSToExecutionChange,
+) {
+	operation.detail = ""
+	if validator == nil {
+		operation.status = statusInvalid
+		operation.detail = "validator not known on chain"
+
+		return
+	}
+
+	credentials := validator.Validator.WithdrawalCredentials
+	if credentials[0] != 0x00 {
+		if bytes.Equal(credentials[12:], operation.change.Message.ToExecutionAddress[:]) {
+			operation.status = statusApplied
+		} else {
+			operation.status = statusSuperseded
+			operation.detail = fmt.Sprintf("withdrawal credentials changed to %#x", credentials)
+		}
+
+		return
+	}
+
+	expected := ethutil.SHA256(operation.change.Message.FromBLSPubkey[:])
+	if !bytes.Equal(credentials[1:], expected[1:]) {
+		operation.status = statusInvalid
+		operation.detail = "public key does not match validator withdrawal credentials"
+
+		return
+	}
+
+	operation.status = statusNotFound
+	for _, change := range changePool {
+		if change.Message.ValidatorIndex == operation.change.Message.ValidatorIndex &&
+			change.Signature == operation.change.Signature {
+			operation.status = statusPool
+			break
+		}
+	}
+}
+
+func (c *command) obtainExitPool(ctx context.Context) ([]*phase0.SignedVoluntaryExit, error) {
+	hasExits := false
+	for _, operation := range c.operations {
+		if operation.exit != nil && !operation.status.final() {
+			hasExits = true
+		}
+	}
+	if !hasExits {
+		return nil, nil
+	}
+
+	provider, isProvider := c.consensusClient.(consensusclient.VoluntaryExitPoolProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide the voluntary exit pool")
+	}
+	response, err := provider.VoluntaryExitPool(ctx, &api.VoluntaryExitPoolOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain voluntary exit pool")
+	}
+
+	return response.Data, nil
+}
+
+func (c *command) obtainChangePool(ctx context.Context) ([]*capella.SignedBLSToExecutionChange, error) {
+	hasChanges := false
+	for _, operation := range c.operations {
+		if operation.change != nil && !operation.status.final() {
+			hasChanges = true
+		}
+	}
+	if !hasChanges {
+		return nil, nil
+	}
+
+	changes := make([]*capella.SignedBLSToExecutionChange, 0)
+	if err := util.ObtainBeaconNodePool(ctx, c.consensusClient, c.timeout, "/eth/v1/beacon/pool/bls_to_execution_changes", &changes); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain BLS to execution change pool")
+	}
+
+	return changes, nil
+}
+
+func (c *command) obtainShardCommitteePeriod(ctx context.Context) (phase0.Epoch, error) {
+	specResponse, err := c.consensusClient.(consensusclient.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain spec")
+	}
+	if val, exists := specResponse.Data["SHARD_COMMITTEE_PERIOD"].(uint64); exists {
+		return phase0.Epoch(val), nil
+	}
+
+	return 256, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisProvider(c.consensusClient.(consensusclient.GenesisProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return nil
+}
@@ -0,0 +1,321 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatoroperationstatus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+const (
+	exitJSON   = `{"message":{"epoch":"100","validator_index":"2"},"signature":"0x010203040506070809000102030405060708090001020304050607080900010203040506070809000102030405060708090001020304050607080900010203040506070809000102030405060708090001020304050607080900010203040506"}`
+	changeJSON = `{"message":{"validator_index":"3","from_bls_pubkey":"0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87","to_execution_address":"0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15"},"signature":"0x010203040506070809000102030405060708090001020304050607080900010203040506070809000102030405060708090001020304050607080900010203040506070809000102030405060708090001020304050607080900010203040506"}`
+)
+
+func TestParseOperations(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "operations.json")
+	require.NoError(t, os.WriteFile(filename, []byte("["+exitJSON+","+changeJSON+"]\n"), 0o600))
+
+	tests := []struct {
+		name    string
+		input   string
+		exits   int
+		changes int
+		err     string
+	}{
+		{
+			name:  "Empty",
+			input: "[]",
+			err:   "no operations supplied",
+		},
+		{
+			name:  "Invalid",
+			input: "[{]",
+			err:   "failed to parse operations: invalid character ']' looking for beginning of object key string",
+		},
+		{
+			name:  "FileMissing",
+			input: filepath.Join(dir, "missing.json"),
+			err:   "failed to read operations file: open " + filepath.Join(dir, "missing.json") + ": no such file or directory",
+		},
+		{
+			name:  "SingleExit",
+			input: exitJSON,
+			exits: 1,
+		},
+		{
+			name:    "SingleChange",
+			input:   changeJSON,
+			changes: 1,
+		},
+		{
+			name:    "File",
+			input:   filename,
+			exits:   1,
+			changes: 1,
+		},
+		{
+			name:  "ExitResults",
+			input: `[{"validator":"0","operation":` + exitJSON + `},{"validator":"1","error":"unknown validator"}]`,
+			exits: 1,
+		},
+		{
+			name:  "ExitResultsFailed",
+			input: `[{"validator":"1","error":"unknown validator"}]`,
+			err:   "no operations supplied",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			operations, err := parseOperations(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			exits := 0
+			changes := 0
+			for _, operation := range operations {
+				if operation.exit != nil {
+					exits++
+				} else {
+					changes++
+				}
+			}
+			require.Equal(t, test.exits, exits)
+			require.Equal(t, test.changes, changes)
+		})
+	}
+}
+
+type testBlock struct {
+	exits   []*phase0.SignedVoluntaryExit
+	changes []*capella.SignedBLSToExecutionChange
+}
+
+func (b *testBlock) VoluntaryExits() ([]*phase0.SignedVoluntaryExit, error) {
+	return b.exits, nil
+}
+
+func (b *testBlock) BLSToExecutionChanges() ([]*capella.SignedBLSToExecutionChange, error) {
+	return b.changes, nil
+}
+
+func TestSearchBlock(t *testing.T) {
+	operations, err := parseOperations("[" + exitJSON + "," + changeJSON + "]")
+	require.NoError(t, err)
+
+	// Block with a different exit for the same validator.
+	otherExit := &phase0.SignedVoluntaryExit{
+		Message: &phase0.VoluntaryExit{Epoch: 101, ValidatorIndex: 2},
+	}
+	require.NoError(t, searchBlock(&testBlock{exits: []*phase0.SignedVoluntaryExit{otherExit}}, 10, operations))
+	require.Equal(t, statusNotFound, operations[0].status)
+
+	require.NoError(t, searchBlock(&testBlock{exits: []*phase0.SignedVoluntaryExit{operations[0].exit}}, 11, operations))
+	require.Equal(t, statusIncluded, operations[0].status)
+	require.Equal(t, phase0.Slot(11), operations[0].slot)
+	require.Equal(t, statusNotFound, operations[1].status)
+
+	require.NoError(t, searchBlock(&testBlock{changes: []*capella.SignedBLSToExecutionChange{operations[1].change}}, 12, operations))
+	require.Equal(t, statusIncluded, operations[1].status)
+	require.Equal(t, phase0.Slot(12), operations[1].slot)
+}
+
+func TestUpdateExitStatus(t *testing.T) {
+	operations, err := parseOperations(exitJSON)
+	require.NoError(t, err)
+	exit := operations[0].exit
+
+	tests := []struct {
+		name      string
+		validator *apiv1.Validator
+		pool      []*phase0.SignedVoluntaryExit
+		epoch     phase0.Epoch
+		status    operationStatus
+		detail    string
+	}{
+		{
+			name:   "Unknown",
+			epoch:  1000,
+			status: statusInvalid,
+			detail: "validator not known on chain",
+		},
+		{
+			name:      "Slashed",
+			validator: &apiv1.Validator{Validator: &phase0.Validator{Slashed: true, ExitEpoch: 1005}},
+			epoch:     1000,
+			status:    statusSuperseded,
+			detail:    "validator has been slashed",
+		},
+		{
+			name:      "Applied",
+			validator: &apiv1.Validator{Validator: &phase0.Validator{ExitEpoch: 1005}},
+			epoch:     1000,
+			status:    statusApplied,
+			detail:    "exit epoch 1005",
+		},
+		{
+			name:      "NotActive",
+			validator: &apiv1.Validator{Validator: &phase0.Validator{ActivationEpoch: farFutureEpoch, ExitEpoch: farFutureEpoch}},
+			epoch:     1000,
+			status:    statusInvalid,
+			detail:    "validator is not active",
+		},
+		{
+			name:      "TooYoung",
+			validator: &apiv1.Validator{Validator: &phase0.Validator{ActivationEpoch: 900, ExitEpoch: farFutureEpoch}},
+			epoch:     1000,
+			status:    statusInvalid,
+			detail:    "validator cannot exit until epoch 1156",
+		},
+		{
+			name:      "FutureEpoch",
+			validator: &apiv1.Validator{Validator: &phase0.Validator{ExitEpoch: farFutureEpoch}},
+			epoch:     50,
+			status:    statusInvalid,
+			detail:    "exit is not valid until epoch 100, and must be broadcast again after that",
+		},
+		{
+			name:      "NotFound",
+			validator: &apiv1.Validator{Validator: &phase0.Validator{ExitEpoch: farFutureEpoch}},
+			epoch:     1000,
+			status:    statusNotFound,
+		},
+		{
+			name:      "Pool",
+			validator: &apiv1.Validator{Validator: &phase0.Validator{ExitEpoch: farFutureEpoch}},
+			pool:      []*phase0.SignedVoluntaryExit{exit},
+			epoch:     1000,
+			status:    statusPool,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			operation := &operation{exit: exit}
+			updateExitStatus(operation, test.validator, test.pool, test.epoch, 256)
+			require.Equal(t, test.status, operation.status)
+			require.Equal(t, test.detail, operation.detail)
+		})
+	}
+}
+
+func TestUpdateChangeStatus(t *testing.T) {
+	operations, err := parseOperations(changeJSON)
+	require.NoError(t, err)
+	change := operations[0].change
+
+	blsCredentials := ethutil.SHA256(change.Message.FromBLSPubkey[:])
+	blsCredentials[0] = 0x00
+	appliedCredentials := make([]byte, 32)
+	appliedCredentials[0] = 0x01
+	copy(appliedCredentials[12:], change.Message.ToExecutionAddress[:])
+	otherAddress := bellatrix.ExecutionAddress{0x01}
+	otherCredentials := make([]byte, 32)
+	otherCredentials[0] = 0x01
+	copy(otherCredentials[12:], otherAddress[:])
+
+	tests := []struct {
+		name        string
+		credentials []byte
+		pool        []*capella.SignedBLSToExecutionChange
+		status      operationStatus
+		detail      string
+	}{
+		{
+			name:        "Applied",
+			credentials: appliedCredentials,
+			status:      statusApplied,
+		},
+		{
+			name:        "Superseded",
+			credentials: otherCredentials,
+			status:      statusSuperseded,
+			detail:      "withdrawal credentials changed to 0x0100000000000000000000000100000000000000000000000000000000000000",
+		},
+		{
+			name:        "Mismatch",
+			credentials: make([]byte, 32),
+			status:      statusInvalid,
+			detail:      "public key does not match validator withdrawal credentials",
+		},
+		{
+			name:        "NotFound",
+			credentials: blsCredentials,
+			status:      statusNotFound,
+		},
+		{
+			name:        "OtherInPool",
+			credentials: blsCredentials,
+			pool: []*capella.SignedBLSToExecutionChange{
+				{
+					Message:   &capella.BLSToExecutionChange{ValidatorIndex: change.Message.ValidatorIndex + 1},
+					Signature: change.Signature,
+				},
+			},
+			status: statusNotFound,
+		},
+		{
+			name:        "InPool",
+			credentials: blsCredentials,
+			pool:        []*capella.SignedBLSToExecutionChange{change},
+			status:      statusPool,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			operation := &operation{change: change}
+			updateChangeStatus(operation, &apiv1.Validator{Validator: &phase0.Validator{WithdrawalCredentials: test.credentials}}, test.pool)
+			require.Equal(t, test.status, operation.status)
+			require.Equal(t, test.detail, operation.detail)
+		})
+	}
+}
+
+func TestOutput(t *testing.T) {
+	operations, err := parseOperations("[" + exitJSON + "," + changeJSON + "]")
+	require.NoError(t, err)
+	operations[0].status = statusIncluded
+	operations[0].slot = 12345
+	operations[1].status = statusInvalid
+	operations[1].detail = "validator not known on chain"
+
+	c := &command{operations: operations}
+	res, err := c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Validator 2 exit: included in slot 12345\nValidator 3 credentials change: invalid (validator not known on chain)", res)
+	require.Equal(t, 0, c.outstanding())
+
+	c.format = util.OutputFormatJSON
+	operations[1].status = statusPool
+	operations[1].detail = ""
+	require.Equal(t, 1, c.outstanding())
+	res, err = c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, `[{"type":"exit","validator_index":"2","status":"included","slot":"12345"},{"type":"credentials change","validator_index":"3","status":"in pool"}]`, res)
+}
@@ -0,0 +1,94 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorqueueposition
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet    bool
+	verbose  bool
+	debug    bool
+	format   string
+	template string
+
+	// Input.
+	validator string
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Processing.
+	eth2Client                 eth2client.Service
+	chainTime                  chaintime.Service
+	currentEpoch               phase0.Epoch
+	spec                       map[string]any
+	maxSeedLookahead           phase0.Epoch
+	shardCommitteePeriod       phase0.Epoch
+	maxPendingDepositsPerEpoch uint64
+	minActivationBalance       phase0.Gwei
+	churnLimits                *util.ChurnLimits
+	// validatorInfo is nil if the validator is not yet on chain.
+	validatorInfo *apiv1.Validator
+	pubKey        phase0.BLSPubKey
+
+	// Output.
+	events []*event
+}
+
+// event is an estimate of when an operation for the validator will be processed.
+type event struct {
+	name        string
+	description string
+	epoch       phase0.Epoch
+	estimated   bool
+	amount      phase0.Gwei
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		format:                   util.OutputFormat(),
+		template:                 viper.GetString("template"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		validator:                viper.GetString("validator"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+
+	return c, nil
+}
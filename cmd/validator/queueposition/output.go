@@ -0,0 +1,101 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorqueueposition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type eventJSON struct {
+	Event       string `json:"event"`
+	Description string `json:"description"`
+	Epoch       string `json:"epoch"`
+	Time        string `json:"time"`
+	Estimated   bool   `json:"estimated"`
+	Amount      string `json:"amount,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	sort.SliceStable(c.events, func(i int, j int) bool {
+		return c.events[i].epoch < c.events[j].epoch
+	})
+
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputText,
+	})
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	events := make([]*eventJSON, 0, len(c.events))
+	for _, event := range c.events {
+		output := &eventJSON{
+			Event:       event.name,
+			Description: event.description,
+			Epoch:       fmt.Sprintf("%d", event.epoch),
+			Time:        c.chainTime.StartOfEpoch(event.epoch).Format(time.RFC3339),
+			Estimated:   event.estimated,
+		}
+		if event.amount > 0 {
+			output.Amount = fmt.Sprintf("%d", event.amount)
+		}
+		events = append(events, output)
+	}
+
+	data, err := json.Marshal(events)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	if len(c.events) == 0 {
+		return "No pending operations", nil
+	}
+
+	builder := strings.Builder{}
+	for _, event := range c.events {
+		builder.WriteString(event.description)
+		if event.amount > 0 && c.verbose {
+			builder.WriteString(" of ")
+			builder.WriteString(string2eth.GWeiToString(uint64(event.amount), true))
+		}
+		builder.WriteString(": ")
+		if event.estimated {
+			builder.WriteString("approximately ")
+		}
+		builder.WriteString(fmt.Sprintf("epoch %d, %s\n",
+			event.epoch,
+			c.chainTime.StartOfEpoch(event.epoch).Format("2006-01-02 15:04:05"),
+		))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
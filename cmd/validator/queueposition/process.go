@@ -0,0 +1,349 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorqueueposition
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// farFutureEpoch is the epoch used for events that have not been scheduled.
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.obtainSpecValues(ctx); err != nil {
+		return err
+	}
+
+	validators, err := c.obtainValidators(ctx)
+	if err != nil {
+		return err
+	}
+
+	deposits, err := c.obtainPendingDeposits(ctx)
+	if err != nil {
+		return err
+	}
+
+	if c.validatorInfo == nil {
+		// Validator is not on chain, so the best we can do is estimate the processing of its deposits.
+		c.events = depositEvents(deposits, c.pubKey, c.currentEpoch, c.churnLimits.ActivationExit, c.maxPendingDepositsPerEpoch, c.chainTime.SlotToEpoch)
+		if len(c.events) == 0 {
+			return util.ErrorWithCode(errors.New("unknown validator"),
+				util.ErrorCodeValidatorNotFound,
+				map[string]any{"validator": c.validator},
+			)
+		}
+		c.addActivationFromDeposits(c.events)
+
+		return nil
+	}
+
+	validator := c.validatorInfo.Validator
+	switch {
+	case validator.ExitEpoch != farFutureEpoch:
+		c.events = append(c.events, &event{
+			name:        "exit",
+			description: "Exit",
+			epoch:       validator.ExitEpoch,
+		}, &event{
+			name:        "withdrawable",
+			description: "Withdrawable",
+			epoch:       validator.WithdrawableEpoch,
+		})
+	case validator.ActivationEpoch != farFutureEpoch:
+		// Validator is active or scheduled for activation.
+		if validator.ActivationEpoch > c.currentEpoch {
+			c.events = append(c.events, &event{
+				name:        "activation",
+				description: "Activation",
+				epoch:       validator.ActivationEpoch,
+			})
+		}
+		exitEpoch := util.NewExitQueue(validators, c.churnLimits.ActivationExit).Exit(validator.EffectiveBalance, c.currentEpoch+1+c.maxSeedLookahead)
+		c.events = append(c.events, &event{
+			name:        "exit",
+			description: "Exit if requested now",
+			epoch:       max(exitEpoch, validator.ActivationEpoch+c.shardCommitteePeriod),
+			estimated:   true,
+			amount:      validator.EffectiveBalance,
+		})
+	case validator.ActivationEligibilityEpoch != farFutureEpoch:
+		// Validator is eligible for activation; it will be activated once its eligibility has finalized.
+		c.events = append(c.events, &event{
+			name:        "activation",
+			description: "Activation",
+			epoch:       max(validator.ActivationEligibilityEpoch+util.FinalityDelay, c.currentEpoch) + 1 + c.maxSeedLookahead,
+			estimated:   true,
+		})
+	}
+
+	events := depositEvents(deposits, c.pubKey, c.currentEpoch, c.churnLimits.ActivationExit, c.maxPendingDepositsPerEpoch, c.chainTime.SlotToEpoch)
+	c.events = append(c.events, events...)
+	if validator.ActivationEligibilityEpoch == farFutureEpoch {
+		c.addActivationFromDeposits(events)
+	}
+
+	consolidations, err := c.obtainPendingConsolidations(ctx)
+	if err != nil {
+		return err
+	}
+	c.events = append(c.events, consolidationEvents(consolidations, c.validatorInfo.Index, validators)...)
+
+	return nil
+}
+
+// addActivationFromDeposits adds an estimated activation event once a validator's
+// pending deposits bring its balance up to the minimum activation balance.
+func (c *command) addActivationFromDeposits(events []*event) {
+	balance := phase0.Gwei(0)
+	if c.validatorInfo != nil {
+		balance = c.validatorInfo.Validator.EffectiveBalance
+	}
+	for _, event := range events {
+		balance += event.amount
+		if balance >= c.minActivationBalance {
+			// Eligibility is set in the epoch after the deposit is processed, and activation
+			// follows once that eligibility has finalized.
+			c.events = append(c.events, activationEvent(event.epoch+1+util.FinalityDelay+1+c.maxSeedLookahead))
+
+			return
+		}
+	}
+}
+
+func activationEvent(epoch phase0.Epoch) *event {
+	return &event{
+		name:        "activation",
+		description: "Activation",
+		epoch:       epoch,
+		estimated:   true,
+	}
+}
+
+// depositEvents estimates the epochs at which the pending deposits for the given public key
+// will be processed.
+func depositEvents(deposits []*electra.PendingDeposit,
+	pubKey phase0.BLSPubKey,
+	currentEpoch phase0.Epoch,
+	churn phase0.Gwei,
+	maxPendingDepositsPerEpoch uint64,
+	slotToEpoch func(phase0.Slot) phase0.Epoch,
+) []*event {
+	events := make([]*event, 0)
+	epochs := util.PendingDepositEpochs(deposits, currentEpoch, churn, maxPendingDepositsPerEpoch, slotToEpoch)
+	for i, epoch := range epochs {
+		deposit := deposits[i]
+		if bytes.Equal(deposit.Pubkey[:], pubKey[:]) {
+			events = append(events, &event{
+				name:        "deposit",
+				description: "Deposit",
+				epoch:       epoch,
+				estimated:   true,
+				amount:      deposit.Amount,
+			})
+		}
+	}
+
+	return events
+}
+
+// consolidationEvents provides the events for pending consolidations involving the given validator.
+func consolidationEvents(consolidations []*electra.PendingConsolidation,
+	index phase0.ValidatorIndex,
+	validators map[phase0.ValidatorIndex]*apiv1.Validator,
+) []*event {
+	events := make([]*event, 0)
+	for _, consolidation := range consolidations {
+		source, exists := validators[consolidation.SourceIndex]
+		if !exists {
+			continue
+		}
+		switch index {
+		case consolidation.SourceIndex:
+			events = append(events, &event{
+				name:        "consolidation",
+				description: "Consolidation into validator " + strconv.FormatUint(uint64(consolidation.TargetIndex), 10),
+				epoch:       source.Validator.WithdrawableEpoch,
+				amount:      source.Validator.EffectiveBalance,
+			})
+		case consolidation.TargetIndex:
+			events = append(events, &event{
+				name:        "consolidation",
+				description: "Consolidation from validator " + strconv.FormatUint(uint64(consolidation.SourceIndex), 10),
+				epoch:       source.Validator.WithdrawableEpoch,
+				amount:      source.Validator.EffectiveBalance,
+			})
+		}
+	}
+
+	return events
+}
+
+func (c *command) obtainSpecValues(ctx context.Context) error {
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+
+	c.maxSeedLookahead = 4
+	if val, exists := specResponse.Data["MAX_SEED_LOOKAHEAD"].(uint64); exists {
+		c.maxSeedLookahead = phase0.Epoch(val)
+	}
+	c.maxPendingDepositsPerEpoch = 16
+	if val, exists := specResponse.Data["MAX_PENDING_DEPOSITS_PER_EPOCH"].(uint64); exists {
+		c.maxPendingDepositsPerEpoch = val
+	}
+	c.minActivationBalance = 32000000000
+	if val, exists := specResponse.Data["MIN_ACTIVATION_BALANCE"].(uint64); exists {
+		c.minActivationBalance = phase0.Gwei(val)
+	}
+	c.shardCommitteePeriod = 256
+	if val, exists := specResponse.Data["SHARD_COMMITTEE_PERIOD"].(uint64); exists {
+		c.shardCommitteePeriod = phase0.Epoch(val)
+	}
+	c.spec = specResponse.Data
+
+	return nil
+}
+
+// obtainValidators obtains all validators, locates the requested validator and
+// calculates the churn limits.
+func (c *command) obtainValidators(ctx context.Context) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	index, err := strconv.ParseUint(c.validator, 10, 64)
+	isIndex := err == nil
+	if !isIndex {
+		account, err := util.ParseAccount(ctx, c.validator, nil, false)
+		if err != nil {
+			return nil, err
+		}
+		pubKey, err := util.BestPublicKey(account)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to obtain public key for account")
+		}
+		copy(c.pubKey[:], pubKey.Marshal())
+	}
+
+	response, err := c.eth2Client.(eth2client.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{
+		State: "head",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validators")
+	}
+
+	totalActiveBalance := phase0.Gwei(0)
+	for _, validator := range response.Data {
+		if validator.Validator == nil {
+			continue
+		}
+		if validator.Validator.ActivationEpoch <= c.currentEpoch && validator.Validator.ExitEpoch > c.currentEpoch {
+			totalActiveBalance += validator.Validator.EffectiveBalance
+		}
+		if (isIndex && uint64(validator.Index) == index) ||
+			(!isIndex && bytes.Equal(validator.Validator.PublicKey[:], c.pubKey[:])) {
+			c.validatorInfo = validator
+			c.pubKey = validator.Validator.PublicKey
+		}
+	}
+	if isIndex && c.validatorInfo == nil {
+		return nil, util.ErrorWithCode(errors.New("unknown validator"),
+			util.ErrorCodeValidatorNotFound,
+			map[string]any{"validator": c.validator},
+		)
+	}
+	c.churnLimits = util.CalculateChurnLimits(c.spec, totalActiveBalance)
+
+	return response.Data, nil
+}
+
+func (c *command) obtainPendingDeposits(ctx context.Context) ([]*electra.PendingDeposit, error) {
+	provider, isProvider := c.eth2Client.(eth2client.PendingDepositProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide pending deposits")
+	}
+	response, err := provider.PendingDeposits(ctx, &api.PendingDepositsOpts{
+		State: "head",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain pending deposits")
+	}
+
+	return response.Data, nil
+}
+
+func (c *command) obtainPendingConsolidations(ctx context.Context) ([]*electra.PendingConsolidation, error) {
+	provider, isProvider := c.eth2Client.(eth2client.PendingConsolidationsProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide pending consolidations")
+	}
+	response, err := provider.PendingConsolidations(ctx, &api.PendingConsolidationsOpts{
+		State: "head",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain pending consolidations")
+	}
+
+	return response.Data, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+	c.currentEpoch = c.chainTime.CurrentEpoch()
+
+	electraActive := false
+	for _, fork := range c.chainTime.Forks() {
+		if fork.Name == "electra" && fork.Epoch <= c.currentEpoch {
+			electraActive = true
+		}
+	}
+	if !electraActive {
+		return errors.New("queue positions are only available from the electra fork onwards")
+	}
+
+	return nil
+}
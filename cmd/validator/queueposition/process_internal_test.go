@@ -0,0 +1,118 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorqueueposition
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func slotToEpoch(slot phase0.Slot) phase0.Epoch {
+	return phase0.Epoch(slot / 32)
+}
+
+func TestDepositEvents(t *testing.T) {
+	pubKey := phase0.BLSPubKey{0x01}
+	otherPubKey := phase0.BLSPubKey{0x02}
+
+	tests := []struct {
+		name     string
+		deposits []*electra.PendingDeposit
+		epochs   []phase0.Epoch
+	}{
+		{
+			name:   "Empty",
+			epochs: []phase0.Epoch{},
+		},
+		{
+			name: "First",
+			deposits: []*electra.PendingDeposit{
+				{Pubkey: pubKey, Amount: 32000000000},
+			},
+			epochs: []phase0.Epoch{101},
+		},
+		{
+			name: "Churn",
+			deposits: []*electra.PendingDeposit{
+				{Pubkey: otherPubKey, Amount: 2048000000000},
+				{Pubkey: pubKey, Amount: 32000000000},
+			},
+			epochs: []phase0.Epoch{109},
+		},
+		{
+			name: "Count",
+			deposits: []*electra.PendingDeposit{
+				{Pubkey: otherPubKey, Amount: 1000000000},
+				{Pubkey: otherPubKey, Amount: 1000000000},
+				{Pubkey: otherPubKey, Amount: 1000000000},
+				{Pubkey: pubKey, Amount: 1000000000},
+			},
+			epochs: []phase0.Epoch{101},
+		},
+		{
+			name: "Finality",
+			deposits: []*electra.PendingDeposit{
+				{Pubkey: pubKey, Amount: 32000000000, Slot: 32 * 105},
+			},
+			epochs: []phase0.Epoch{108},
+		},
+		{
+			name: "Multiple",
+			deposits: []*electra.PendingDeposit{
+				{Pubkey: pubKey, Amount: 1000000000},
+				{Pubkey: otherPubKey, Amount: 512000000000},
+				{Pubkey: pubKey, Amount: 1000000000},
+			},
+			epochs: []phase0.Epoch{101, 103},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			events := depositEvents(test.deposits, pubKey, 100, 256000000000, 2, slotToEpoch)
+			epochs := make([]phase0.Epoch, 0, len(events))
+			for _, event := range events {
+				epochs = append(epochs, event.epoch)
+			}
+			require.Equal(t, test.epochs, epochs)
+		})
+	}
+}
+
+func TestConsolidationEvents(t *testing.T) {
+	validators := map[phase0.ValidatorIndex]*apiv1.Validator{
+		1: {Index: 1, Validator: &phase0.Validator{WithdrawableEpoch: 120, EffectiveBalance: 32000000000}},
+		2: {Index: 2, Validator: &phase0.Validator{WithdrawableEpoch: farFutureEpoch, EffectiveBalance: 32000000000}},
+	}
+	consolidations := []*electra.PendingConsolidation{
+		{SourceIndex: 1, TargetIndex: 2},
+		{SourceIndex: 3, TargetIndex: 2},
+	}
+
+	events := consolidationEvents(consolidations, 1, validators)
+	require.Len(t, events, 1)
+	require.Equal(t, "Consolidation into validator 2", events[0].description)
+	require.Equal(t, phase0.Epoch(120), events[0].epoch)
+
+	events = consolidationEvents(consolidations, 2, validators)
+	require.Len(t, events, 1)
+	require.Equal(t, "Consolidation from validator 1", events[0].description)
+	require.Equal(t, phase0.Gwei(32000000000), events[0].amount)
+
+	require.Empty(t, consolidationEvents(consolidations, 4, validators))
+}
@@ -0,0 +1,165 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrewards
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet    bool
+	verbose  bool
+	debug    bool
+	format   string
+	template string
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	fromEpochStr string
+	toEpochStr   string
+	validators   []string
+
+	// Data access.
+	eth2Client                   eth2client.Service
+	chainTime                    chaintime.Service
+	validatorsProvider           eth2client.ValidatorsProvider
+	attestationRewardsProvider   eth2client.AttestationRewardsProvider
+	proposerDutiesProvider       eth2client.ProposerDutiesProvider
+	blockRewardsProvider         eth2client.BlockRewardsProvider
+	syncCommitteesProvider       eth2client.SyncCommitteesProvider
+	syncCommitteeRewardsProvider eth2client.SyncCommitteeRewardsProvider
+
+	// Processing.
+	fromEpoch phase0.Epoch
+	toEpoch   phase0.Epoch
+
+	// Results.
+	epochs           []*epochRewards
+	validatorRewards map[phase0.ValidatorIndex]*rewards
+	total            *rewards
+}
+
+// rewards are the rewards and penalties for one or more validators.
+// All values are in Gwei; penalties are negative.
+type rewards struct {
+	Head           int64 `json:"head"`
+	Source         int64 `json:"source"`
+	Target         int64 `json:"target"`
+	InclusionDelay int64 `json:"inclusion_delay"`
+	Inactivity     int64 `json:"inactivity"`
+	Proposer       int64 `json:"proposer"`
+	Sync           int64 `json:"sync"`
+	// Penalties is the sum of the negative values above.
+	Penalties int64 `json:"penalties"`
+	// Total is the sum of the values above.
+	Total int64 `json:"total"`
+	// IdealAttestation is the attestation reward that would have been obtained
+	// with perfect attestations.
+	IdealAttestation int64 `json:"ideal_attestation"`
+	// Attestation is the attestation reward actually obtained.
+	Attestation     int64 `json:"attestation"`
+	Proposals       int   `json:"proposals"`
+	MissedProposals int   `json:"missed_proposals"`
+}
+
+type epochRewards struct {
+	Epoch phase0.Epoch `json:"epoch"`
+	*rewards
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		format:                   util.OutputFormat(),
+		template:                 viper.GetString("template"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		fromEpochStr:             viper.GetString("from-epoch"),
+		toEpochStr:               viper.GetString("to-epoch"),
+		validators:               viper.GetStringSlice("validators"),
+		validatorRewards:         make(map[phase0.ValidatorIndex]*rewards),
+		total:                    &rewards{},
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if len(c.validators) == 0 {
+		return nil, errors.New("validators are required")
+	}
+
+	if viper.GetBool("csv") {
+		// --csv is an alias for --format=csv.
+		if c.format != util.OutputFormatText && c.format != util.OutputFormatCSV {
+			return nil, fmt.Errorf("only one of %s and CSV output can be selected", strings.ToUpper(c.format))
+		}
+		c.format = util.OutputFormatCSV
+	}
+
+	return c, nil
+}
+
+// record records an amount for a component of the rewards, updating the penalties and total.
+func (r *rewards) record(component *int64, amount int64) {
+	*component += amount
+	if amount < 0 {
+		r.Penalties += amount
+	}
+	r.Total += amount
+}
+
+// add adds the given rewards to these rewards.
+func (r *rewards) add(other *rewards) {
+	r.Head += other.Head
+	r.Source += other.Source
+	r.Target += other.Target
+	r.InclusionDelay += other.InclusionDelay
+	r.Inactivity += other.Inactivity
+	r.Proposer += other.Proposer
+	r.Sync += other.Sync
+	r.Penalties += other.Penalties
+	r.Total += other.Total
+	r.IdealAttestation += other.IdealAttestation
+	r.Attestation += other.Attestation
+	r.Proposals += other.Proposals
+	r.MissedProposals += other.MissedProposals
+}
+
+// efficiency returns the actual attestation rewards as a percentage of the ideal attestation rewards.
+func (r *rewards) efficiency() float64 {
+	if r.IdealAttestation == 0 {
+		return 0
+	}
+
+	return 100 * float64(r.Attestation) / float64(r.IdealAttestation)
+}
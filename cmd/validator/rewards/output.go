@@ -0,0 +1,147 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrewards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type jsonOutput struct {
+	Epochs     []*epochRewards                    `json:"epochs"`
+	Validators map[phase0.ValidatorIndex]*rewards `json:"validators"`
+	Total      *rewards                           `json:"total"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputTxt,
+		CSV:      c.outputCSV,
+	})
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		Epochs:     c.epochs,
+		Validators: c.validatorRewards,
+		Total:      c.total,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString("epoch,head,source,target,inclusion_delay,inactivity,proposer,sync,penalties,total,ideal_attestation,attestation,proposals,missed_proposals\n")
+	for _, epoch := range c.epochs {
+		builder.WriteString(fmt.Sprintf("%d,%d,%d,%d,%d,%d,%d,%d,%d,%d,%d,%d,%d,%d\n",
+			epoch.Epoch,
+			epoch.Head,
+			epoch.Source,
+			epoch.Target,
+			epoch.InclusionDelay,
+			epoch.Inactivity,
+			epoch.Proposer,
+			epoch.Sync,
+			epoch.Penalties,
+			epoch.Total,
+			epoch.IdealAttestation,
+			epoch.Attestation,
+			epoch.Proposals,
+			epoch.MissedProposals,
+		))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.fromEpoch == c.toEpoch {
+		builder.WriteString(fmt.Sprintf("Epoch %d:\n", c.fromEpoch))
+	} else {
+		builder.WriteString(fmt.Sprintf("Epochs %d-%d:\n", c.fromEpoch, c.toEpoch))
+		for _, epoch := range c.epochs {
+			builder.WriteString(fmt.Sprintf("  Epoch %d: %s (penalties %s)\n", epoch.Epoch, formatGwei(epoch.Total), formatGwei(epoch.Penalties)))
+		}
+	}
+
+	outputRewards(&builder, "  ", c.total)
+
+	if c.verbose && len(c.validatorRewards) > 0 {
+		indices := make([]phase0.ValidatorIndex, 0, len(c.validatorRewards))
+		for index := range c.validatorRewards {
+			indices = append(indices, index)
+		}
+		sort.Slice(indices, func(i, j int) bool {
+			return indices[i] < indices[j]
+		})
+		builder.WriteString("  Validators:\n")
+		for _, index := range indices {
+			builder.WriteString(fmt.Sprintf("    %d:\n", index))
+			outputRewards(&builder, "      ", c.validatorRewards[index])
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func outputRewards(builder *strings.Builder, prefix string, rewards *rewards) {
+	builder.WriteString(fmt.Sprintf("%sHead: %s\n", prefix, formatGwei(rewards.Head)))
+	builder.WriteString(fmt.Sprintf("%sSource: %s\n", prefix, formatGwei(rewards.Source)))
+	builder.WriteString(fmt.Sprintf("%sTarget: %s\n", prefix, formatGwei(rewards.Target)))
+	if rewards.InclusionDelay != 0 {
+		builder.WriteString(fmt.Sprintf("%sInclusion delay: %s\n", prefix, formatGwei(rewards.InclusionDelay)))
+	}
+	if rewards.Inactivity != 0 {
+		builder.WriteString(fmt.Sprintf("%sInactivity: %s\n", prefix, formatGwei(rewards.Inactivity)))
+	}
+	builder.WriteString(fmt.Sprintf("%sProposer: %s", prefix, formatGwei(rewards.Proposer)))
+	if rewards.Proposals > 0 {
+		builder.WriteString(fmt.Sprintf(" (%d proposals, %d missed)", rewards.Proposals, rewards.MissedProposals))
+	}
+	builder.WriteString("\n")
+	builder.WriteString(fmt.Sprintf("%sSync committee: %s\n", prefix, formatGwei(rewards.Sync)))
+	builder.WriteString(fmt.Sprintf("%sPenalties: %s\n", prefix, formatGwei(rewards.Penalties)))
+	builder.WriteString(fmt.Sprintf("%sTotal: %s\n", prefix, formatGwei(rewards.Total)))
+	builder.WriteString(fmt.Sprintf("%sAttestation rewards: %s of ideal %s (%.2f%%)\n", prefix, formatGwei(rewards.Attestation), formatGwei(rewards.IdealAttestation), rewards.efficiency()))
+}
+
+// formatGwei formats a signed Gwei value.
+func formatGwei(amount int64) string {
+	if amount < 0 {
+		return "-" + string2eth.GWeiToString(uint64(-amount), true)
+	}
+
+	return string2eth.GWeiToString(uint64(amount), true)
+}
@@ -0,0 +1,337 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrewards
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.parseEpochs(ctx); err != nil {
+		return err
+	}
+
+	for epoch := c.fromEpoch; epoch <= c.toEpoch; epoch++ {
+		epochRewards, err := c.processEpoch(ctx, epoch)
+		if err != nil {
+			return err
+		}
+		c.epochs = append(c.epochs, epochRewards)
+		c.total.add(epochRewards.rewards)
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.attestationRewardsProvider, isProvider = c.eth2Client.(eth2client.AttestationRewardsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide attestation rewards")
+	}
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	c.blockRewardsProvider, isProvider = c.eth2Client.(eth2client.BlockRewardsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide block rewards")
+	}
+	c.syncCommitteesProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committee duties")
+	}
+	c.syncCommitteeRewardsProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteeRewardsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committee rewards")
+	}
+
+	return nil
+}
+
+// parseEpochs parses the epoch range.  Attestation rewards for an epoch are only
+// available once the following epoch has completed, so by default the range is
+// the latest epoch for which rewards are available.
+func (c *command) parseEpochs(ctx context.Context) error {
+	currentEpoch := c.chainTime.CurrentEpoch()
+	if currentEpoch < 2 {
+		return errors.New("rewards are not yet available")
+	}
+	latestEpoch := currentEpoch - 2
+
+	var err error
+	c.toEpoch = latestEpoch
+	if c.toEpochStr != "" {
+		c.toEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.toEpochStr)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse to epoch")
+		}
+	}
+	c.fromEpoch = c.toEpoch
+	if c.fromEpochStr != "" {
+		c.fromEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.fromEpochStr)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse from epoch")
+		}
+	}
+
+	if c.fromEpoch > c.toEpoch {
+		return errors.New("from epoch cannot be after to epoch")
+	}
+	if c.toEpoch > latestEpoch {
+		return fmt.Errorf("rewards are not yet available for epoch %d; latest available epoch is %d", c.toEpoch, latestEpoch)
+	}
+
+	return nil
+}
+
+func (c *command) processEpoch(ctx context.Context, epoch phase0.Epoch) (*epochRewards, error) {
+	res := &epochRewards{
+		Epoch:   epoch,
+		rewards: &rewards{},
+	}
+
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse validators")
+	}
+	activeValidators := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+	for _, validator := range validators {
+		if validator.Validator.ActivationEpoch <= epoch && validator.Validator.ExitEpoch > epoch {
+			activeValidators[validator.Index] = validator
+		}
+	}
+	if len(activeValidators) == 0 {
+		return res, nil
+	}
+
+	if err := c.processAttestationRewards(ctx, epoch, activeValidators, res.rewards); err != nil {
+		return nil, err
+	}
+	if err := c.processProposerRewards(ctx, epoch, activeValidators, res.rewards); err != nil {
+		return nil, err
+	}
+	if err := c.processSyncCommitteeRewards(ctx, epoch, activeValidators, res.rewards); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// validatorRewardsFor returns the rewards for the given validator, creating them if required.
+func (c *command) validatorRewardsFor(index phase0.ValidatorIndex) *rewards {
+	validatorRewards, exists := c.validatorRewards[index]
+	if !exists {
+		validatorRewards = &rewards{}
+		c.validatorRewards[index] = validatorRewards
+	}
+
+	return validatorRewards
+}
+
+func (c *command) processAttestationRewards(ctx context.Context,
+	epoch phase0.Epoch,
+	activeValidators map[phase0.ValidatorIndex]*apiv1.Validator,
+	res *rewards,
+) error {
+	indices := make([]phase0.ValidatorIndex, 0, len(activeValidators))
+	for index := range activeValidators {
+		indices = append(indices, index)
+	}
+	response, err := c.attestationRewardsProvider.AttestationRewards(ctx, &api.AttestationRewardsOpts{
+		Epoch:   epoch,
+		Indices: indices,
+	})
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain attestation rewards for epoch %d", epoch))
+	}
+
+	idealRewards := make(map[phase0.Gwei]*apiv1.IdealAttestationRewards, len(response.Data.IdealRewards))
+	for i := range response.Data.IdealRewards {
+		idealRewards[response.Data.IdealRewards[i].EffectiveBalance] = &response.Data.IdealRewards[i]
+	}
+
+	for _, validatorAttestationRewards := range response.Data.TotalRewards {
+		validator, exists := activeValidators[validatorAttestationRewards.ValidatorIndex]
+		if !exists {
+			continue
+		}
+		recordAttestationRewards(res, &validatorAttestationRewards, idealRewards[validator.Validator.EffectiveBalance])
+		recordAttestationRewards(c.validatorRewardsFor(validator.Index), &validatorAttestationRewards, idealRewards[validator.Validator.EffectiveBalance])
+	}
+
+	return nil
+}
+
+// recordAttestationRewards records the attestation rewards for a validator, along
+// with the ideal rewards for its effective balance if known.
+func recordAttestationRewards(res *rewards,
+	actual *apiv1.ValidatorAttestationRewards,
+	ideal *apiv1.IdealAttestationRewards,
+) {
+	res.record(&res.Head, int64(actual.Head))
+	res.record(&res.Source, actual.Source)
+	res.record(&res.Target, actual.Target)
+	attestation := int64(actual.Head) + actual.Source + actual.Target
+	if actual.InclusionDelay != nil {
+		res.record(&res.InclusionDelay, int64(*actual.InclusionDelay))
+		attestation += int64(*actual.InclusionDelay)
+	}
+	// Inactivity is a penalty.
+	res.record(&res.Inactivity, -int64(actual.Inactivity))
+	res.Attestation += attestation
+
+	if ideal != nil {
+		res.IdealAttestation += int64(ideal.Head) + int64(ideal.Source) + int64(ideal.Target)
+		if ideal.InclusionDelay != nil {
+			res.IdealAttestation += int64(*ideal.InclusionDelay)
+		}
+	}
+}
+
+func (c *command) processProposerRewards(ctx context.Context,
+	epoch phase0.Epoch,
+	activeValidators map[phase0.ValidatorIndex]*apiv1.Validator,
+	res *rewards,
+) error {
+	response, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+		Epoch: epoch,
+	})
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain proposer duties for epoch %d", epoch))
+	}
+
+	for _, duty := range response.Data {
+		if _, exists := activeValidators[duty.ValidatorIndex]; !exists {
+			continue
+		}
+		validatorRewards := c.validatorRewardsFor(duty.ValidatorIndex)
+		res.Proposals++
+		validatorRewards.Proposals++
+
+		blockRewardsResponse, err := c.blockRewardsProvider.BlockRewards(ctx, &api.BlockRewardsOpts{
+			Block: fmt.Sprintf("%d", duty.Slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// No block, so a missed proposal.
+				res.MissedProposals++
+				validatorRewards.MissedProposals++
+
+				continue
+			}
+
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block rewards for slot %d", duty.Slot))
+		}
+		res.record(&res.Proposer, int64(blockRewardsResponse.Data.Total))
+		validatorRewards.record(&validatorRewards.Proposer, int64(blockRewardsResponse.Data.Total))
+	}
+
+	return nil
+}
+
+func (c *command) processSyncCommitteeRewards(ctx context.Context,
+	epoch phase0.Epoch,
+	activeValidators map[phase0.ValidatorIndex]*apiv1.Validator,
+	res *rewards,
+) error {
+	if epoch < c.chainTime.AltairInitialEpoch() {
+		// The epoch is pre-Altair.  No sync committee but no error.
+		return nil
+	}
+
+	committeeResponse, err := c.syncCommitteesProvider.SyncCommittee(ctx, &api.SyncCommitteeOpts{
+		State: fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch)),
+		Epoch: &epoch,
+	})
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain sync committee for epoch %d", epoch))
+	}
+	members := make([]phase0.ValidatorIndex, 0)
+	for _, index := range committeeResponse.Data.Validators {
+		if _, exists := activeValidators[index]; exists {
+			members = append(members, index)
+		}
+	}
+	if len(members) == 0 {
+		return nil
+	}
+
+	for slot := c.chainTime.FirstSlotOfEpoch(epoch); slot <= c.chainTime.LastSlotOfEpoch(epoch); slot++ {
+		response, err := c.syncCommitteeRewardsProvider.SyncCommitteeRewards(ctx, &api.SyncCommitteeRewardsOpts{
+			Block:   fmt.Sprintf("%d", slot),
+			Indices: members,
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// No block, so no sync committee rewards.
+				continue
+			}
+
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain sync committee rewards for slot %d", slot))
+		}
+		for _, reward := range response.Data {
+			if _, exists := activeValidators[reward.ValidatorIndex]; !exists {
+				continue
+			}
+			res.record(&res.Sync, reward.Reward)
+			validatorRewards := c.validatorRewardsFor(reward.ValidatorIndex)
+			validatorRewards.record(&validatorRewards.Sync, reward.Reward)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrewards
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/mock"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewards(t *testing.T) {
+	r := &rewards{}
+	r.record(&r.Head, 100)
+	r.record(&r.Source, -50)
+	r.record(&r.Target, 200)
+	require.Equal(t, int64(250), r.Total)
+	require.Equal(t, int64(-50), r.Penalties)
+
+	other := &rewards{}
+	other.record(&other.Sync, 25)
+	other.Attestation = 300
+	other.IdealAttestation = 400
+	r.add(other)
+	require.Equal(t, int64(275), r.Total)
+	require.Equal(t, int64(25), r.Sync)
+	require.InDelta(t, 75.0, r.efficiency(), 0.001)
+
+	require.Equal(t, 0.0, (&rewards{}).efficiency())
+}
+
+func TestProcessAttestationRewards(t *testing.T) {
+	ctx := context.Background()
+
+	inclusionDelay := phase0.Gwei(5)
+	client, err := mock.New(ctx)
+	require.NoError(t, err)
+	client.AttestationRewardsFunc = func(_ context.Context, _ *api.AttestationRewardsOpts) (*api.Response[*apiv1.AttestationRewards], error) {
+		return &api.Response[*apiv1.AttestationRewards]{
+			Data: &apiv1.AttestationRewards{
+				IdealRewards: []apiv1.IdealAttestationRewards{
+					{
+						EffectiveBalance: 32000000000,
+						Head:             100,
+						Target:           200,
+						Source:           150,
+						InclusionDelay:   &inclusionDelay,
+					},
+				},
+				TotalRewards: []apiv1.ValidatorAttestationRewards{
+					{
+						ValidatorIndex: 1,
+						Head:           100,
+						Target:         200,
+						Source:         150,
+						InclusionDelay: &inclusionDelay,
+					},
+					{
+						ValidatorIndex: 2,
+						Head:           0,
+						Target:         -200,
+						Source:         -150,
+						Inactivity:     10,
+					},
+					{
+						// Not requested, so should be ignored.
+						ValidatorIndex: 3,
+						Head:           100,
+					},
+				},
+			},
+		}, nil
+	}
+
+	c := &command{
+		attestationRewardsProvider: client,
+		validatorRewards:           make(map[phase0.ValidatorIndex]*rewards),
+	}
+	activeValidators := map[phase0.ValidatorIndex]*apiv1.Validator{
+		1: {Index: 1, Validator: &phase0.Validator{EffectiveBalance: 32000000000}},
+		2: {Index: 2, Validator: &phase0.Validator{EffectiveBalance: 32000000000}},
+	}
+	res := &rewards{}
+	require.NoError(t, c.processAttestationRewards(ctx, 10, activeValidators, res))
+
+	require.Equal(t, int64(100), res.Head)
+	require.Equal(t, int64(0), res.Source)
+	require.Equal(t, int64(0), res.Target)
+	require.Equal(t, int64(5), res.InclusionDelay)
+	require.Equal(t, int64(-10), res.Inactivity)
+	require.Equal(t, int64(-360), res.Penalties)
+	require.Equal(t, int64(95), res.Total)
+	require.Equal(t, int64(2*455), res.IdealAttestation)
+	require.Equal(t, int64(105), res.Attestation)
+
+	require.Len(t, c.validatorRewards, 2)
+	require.Equal(t, int64(455), c.validatorRewards[1].Total)
+	require.Equal(t, int64(-360), c.validatorRewards[2].Total)
+}
+
+func TestProcessProposerRewards(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := mock.New(ctx)
+	require.NoError(t, err)
+	client.ProposerDutiesFunc = func(_ context.Context, _ *api.ProposerDutiesOpts) (*api.Response[[]*apiv1.ProposerDuty], error) {
+		return &api.Response[[]*apiv1.ProposerDuty]{
+			Data: []*apiv1.ProposerDuty{
+				{Slot: 320, ValidatorIndex: 1},
+				{Slot: 321, ValidatorIndex: 5},
+				{Slot: 322, ValidatorIndex: 1},
+				{Slot: 323, ValidatorIndex: 2},
+			},
+		}, nil
+	}
+	client.BlockRewardsFunc = func(_ context.Context, opts *api.BlockRewardsOpts) (*api.Response[*apiv1.BlockRewards], error) {
+		if opts.Block == "322" {
+			return nil, &api.Error{StatusCode: http.StatusNotFound}
+		}
+		if opts.Block == "323" {
+			return nil, errors.New("internal error")
+		}
+
+		return &api.Response[*apiv1.BlockRewards]{
+			Data: &apiv1.BlockRewards{
+				Total: 50000000,
+			},
+		}, nil
+	}
+
+	c := &command{
+		proposerDutiesProvider: client,
+		blockRewardsProvider:   client,
+		validatorRewards:       make(map[phase0.ValidatorIndex]*rewards),
+	}
+	res := &rewards{}
+	activeValidators := map[phase0.ValidatorIndex]*apiv1.Validator{
+		1: {Index: 1, Validator: &phase0.Validator{}},
+	}
+	require.NoError(t, c.processProposerRewards(ctx, 10, activeValidators, res))
+	require.Equal(t, int64(50000000), res.Proposer)
+	require.Equal(t, 2, res.Proposals)
+	require.Equal(t, 1, res.MissedProposals)
+	require.Equal(t, 1, c.validatorRewards[1].MissedProposals)
+
+	activeValidators[2] = &apiv1.Validator{Index: 2, Validator: &phase0.Validator{}}
+	require.EqualError(t, c.processProposerRewards(ctx, 10, activeValidators, &rewards{}), "failed to obtain block rewards for slot 323: internal error")
+}
@@ -15,6 +15,8 @@ package validatorsummary
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -23,8 +25,12 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
 )
 
+// defaultWorkers is the default number of epochs processed concurrently.
+const defaultWorkers = 8
+
 type command struct {
 	quiet   bool
 	verbose bool
@@ -37,8 +43,18 @@ type command struct {
 
 	// Operation.
 	epoch      string
+	fromEpoch  string
+	toEpoch    string
+	since      string
+	epochRange bool
+	workers    int
 	validators []string
-	jsonOutput bool
+	format     string
+	template   string
+
+	// allDuties also records missed proposals and sync committee
+	// participation, which the single-epoch summary does not.
+	allDuties bool
 
 	// Data access.
 	eth2Client                 eth2client.Service
@@ -55,7 +71,9 @@ type command struct {
 	validatorsByIndex map[phase0.ValidatorIndex]*apiv1.Validator
 
 	// Results.
-	summary *validatorSummary
+	summary      *validatorSummary
+	summaries    []*validatorSummary
+	rangeSummary *validatorRangeSummary
 }
 
 type validatorSummary struct {
@@ -72,10 +90,39 @@ type validatorSummary struct {
 	IncorrectTargetValidators  []*validatorFault            `json:"incorrect_target_validators"`
 	UntimelyTargetValidators   []*validatorFault            `json:"untimely_target_validators"`
 	Slots                      []*slot                      `json:"slots"`
+	InclusionDelays            map[phase0.Slot]int          `json:"-"`
 	Proposals                  []*epochProposal             `json:"-"`
 	SyncCommittee              []*epochSyncCommittee        `json:"-"`
 }
 
+// validatorRangeSummary is the aggregate of the summaries for a range of epochs.
+type validatorRangeSummary struct {
+	FromEpoch               phase0.Epoch                               `json:"from_epoch"`
+	ToEpoch                 phase0.Epoch                               `json:"to_epoch"`
+	ActiveValidators        int                                        `json:"active_validators"`
+	ParticipatingValidators int                                        `json:"participating_validators"`
+	Attestations            *slotAttestations                          `json:"attestations"`
+	InclusionDelays         map[phase0.Slot]int                        `json:"inclusion_delays"`
+	Proposals               int                                        `json:"proposals"`
+	MissedProposals         []*epochProposal                           `json:"missed_proposals"`
+	SyncCommitteeExpected   int                                        `json:"sync_committee_expected"`
+	SyncCommitteeMissed     int                                        `json:"sync_committee_missed"`
+	ValidatorFaults         map[phase0.ValidatorIndex]*validatorFaults `json:"validator_faults"`
+	Epochs                  []*validatorSummary                        `json:"epochs,omitempty"`
+}
+
+// validatorFaults are the number of epochs in which a validator had each type of fault.
+type validatorFaults struct {
+	NonParticipating int `json:"non_participating"`
+	IncorrectHead    int `json:"incorrect_head"`
+	UntimelyHead     int `json:"untimely_head"`
+	UntimelySource   int `json:"untimely_source"`
+	IncorrectTarget  int `json:"incorrect_target"`
+	UntimelyTarget   int `json:"untimely_target"`
+	MissedProposals  int `json:"missed_proposals"`
+	MissedSync       int `json:"missed_sync"`
+}
+
 type slot struct {
 	Slot         phase0.Slot       `json:"slot"`
 	Attestations *slotAttestations `json:"attestations"`
@@ -98,8 +145,9 @@ type epochProposal struct {
 }
 
 type epochSyncCommittee struct {
-	Index  phase0.ValidatorIndex `json:"index"`
-	Missed int                   `json:"missed"`
+	Index    phase0.ValidatorIndex `json:"index"`
+	Expected int                   `json:"expected"`
+	Missed   int                   `json:"missed"`
 }
 
 type validatorFault struct {
@@ -116,12 +164,11 @@ type nonParticipatingValidator struct {
 
 func newCommand(_ context.Context) (*command, error) {
 	c := &command{
-		quiet:             viper.GetBool("quiet"),
-		verbose:           viper.GetBool("verbose"),
-		debug:             viper.GetBool("debug"),
-		validatorsByIndex: make(map[phase0.ValidatorIndex]*apiv1.Validator),
-		summary:           &validatorSummary{},
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
 	}
+	c.initEpochState()
 
 	// Timeout.
 	if viper.GetDuration("timeout") == 0 {
@@ -133,8 +180,47 @@ func newCommand(_ context.Context) (*command, error) {
 	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
 
 	c.epoch = viper.GetString("epoch")
+	c.fromEpoch = viper.GetString("from-epoch")
+	c.toEpoch = viper.GetString("to-epoch")
+	c.since = viper.GetString("since")
+	c.epochRange = c.fromEpoch != "" || c.toEpoch != "" || c.since != ""
+	c.allDuties = c.epochRange
+	if c.epoch != "" && c.epochRange {
+		return nil, errors.New("epoch cannot be supplied with an epoch range")
+	}
+	c.workers = viper.GetInt("workers")
+	if c.workers < 1 {
+		c.workers = defaultWorkers
+	}
 	c.validators = viper.GetStringSlice("validators")
-	c.jsonOutput = viper.GetBool("json")
+	c.format = util.OutputFormat()
+	c.template = viper.GetString("template")
+	if viper.GetBool("csv") {
+		// --csv is an alias for --format=csv.
+		if c.format != util.OutputFormatText && c.format != util.OutputFormatCSV {
+			return nil, fmt.Errorf("only one of %s and CSV output can be selected", strings.ToUpper(c.format))
+		}
+		c.format = util.OutputFormatCSV
+	}
 
 	return c, nil
 }
+
+// initEpochState initialises the intermediate data and summary for processing an epoch.
+func (c *command) initEpochState() {
+	c.validatorsByIndex = make(map[phase0.ValidatorIndex]*apiv1.Validator)
+	c.summary = &validatorSummary{
+		InclusionDelays: make(map[phase0.Slot]int),
+	}
+}
+
+// epochCommand returns a copy of the command with its own state, allowing
+// multiple epochs to be processed concurrently.
+func (c *command) epochCommand() *command {
+	ec := *c
+	ec.summaries = nil
+	ec.rangeSummary = nil
+	ec.initEpochState()
+
+	return &ec
+}
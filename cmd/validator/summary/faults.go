@@ -0,0 +1,131 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsummary
+
+import (
+	"context"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+// Fault types.
+const (
+	FaultNonParticipating = "non_participating"
+	FaultIncorrectHead    = "incorrect_head"
+	FaultUntimelyHead     = "untimely_head"
+	FaultUntimelySource   = "untimely_source"
+	FaultIncorrectTarget  = "incorrect_target"
+	FaultUntimelyTarget   = "untimely_target"
+	FaultMissedProposal   = "missed_proposal"
+	FaultMissedSync       = "missed_sync_committee"
+)
+
+// Fault is a fault for a validator in an epoch.
+type Fault struct {
+	Epoch          phase0.Epoch          `json:"epoch"`
+	Slot           phase0.Slot           `json:"slot"`
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index"`
+	Type           string                `json:"type"`
+	InclusionDelay int                   `json:"inclusion_delay,omitempty"`
+	// Missed and Expected are provided for sync committee faults.
+	Missed   int `json:"missed,omitempty"`
+	Expected int `json:"expected,omitempty"`
+}
+
+// EpochFaults obtains the faults for the given validators in the given epoch.
+func EpochFaults(ctx context.Context,
+	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+	validators []string,
+	epoch phase0.Epoch,
+) (
+	[]*Fault,
+	error,
+) {
+	summary, err := Summarise(ctx, eth2Client, chainTime, validators, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return summary.Faults, nil
+}
+
+// faults returns the faults in the summary, ordered by slot and validator.
+func (s *validatorSummary) faults() []*Fault {
+	res := make([]*Fault, 0)
+
+	for _, validator := range s.NonParticipatingValidators {
+		res = append(res, &Fault{
+			Epoch:          s.Epoch,
+			Slot:           validator.Slot,
+			ValidatorIndex: validator.Validator,
+			Type:           FaultNonParticipating,
+		})
+	}
+	for faultType, validators := range map[string][]*validatorFault{
+		FaultIncorrectHead:   s.IncorrectHeadValidators,
+		FaultUntimelyHead:    s.UntimelyHeadValidators,
+		FaultUntimelySource:  s.UntimelySourceValidators,
+		FaultIncorrectTarget: s.IncorrectTargetValidators,
+		FaultUntimelyTarget:  s.UntimelyTargetValidators,
+	} {
+		for _, validator := range validators {
+			res = append(res, &Fault{
+				Epoch:          s.Epoch,
+				Slot:           validator.AttestationData.Slot,
+				ValidatorIndex: validator.Validator,
+				Type:           faultType,
+				InclusionDelay: validator.InclusionDistance,
+			})
+		}
+	}
+	for _, proposal := range s.Proposals {
+		if !proposal.Block {
+			res = append(res, &Fault{
+				Epoch:          s.Epoch,
+				Slot:           proposal.Slot,
+				ValidatorIndex: proposal.Proposer,
+				Type:           FaultMissedProposal,
+			})
+		}
+	}
+	for _, syncCommittee := range s.SyncCommittee {
+		if syncCommittee.Missed > 0 {
+			res = append(res, &Fault{
+				Epoch:          s.Epoch,
+				Slot:           s.FirstSlot,
+				ValidatorIndex: syncCommittee.Index,
+				Type:           FaultMissedSync,
+				Missed:         syncCommittee.Missed,
+				Expected:       syncCommittee.Expected,
+			})
+		}
+	}
+
+	sort.Slice(res, func(i int, j int) bool {
+		if res[i].Slot != res[j].Slot {
+			return res[i].Slot < res[j].Slot
+		}
+		if res[i].ValidatorIndex != res[j].ValidatorIndex {
+			return res[i].ValidatorIndex < res[j].ValidatorIndex
+		}
+
+		return res[i].Type < res[j].Type
+	})
+
+	return res
+}
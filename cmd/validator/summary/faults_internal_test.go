@@ -0,0 +1,55 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsummary
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaults(t *testing.T) {
+	summary := &validatorSummary{
+		Epoch:     10,
+		FirstSlot: 320,
+		NonParticipatingValidators: []*nonParticipatingValidator{
+			{Validator: 5, Slot: 325, Committee: 1},
+		},
+		IncorrectHeadValidators: []*validatorFault{
+			{Validator: 2, AttestationData: &phase0.AttestationData{Slot: 321}, InclusionDistance: 1},
+		},
+		UntimelyTargetValidators: []*validatorFault{
+			{Validator: 3, AttestationData: &phase0.AttestationData{Slot: 321}, InclusionDistance: 33},
+		},
+		Proposals: []*epochProposal{
+			{Slot: 322, Proposer: 1, Block: true},
+			{Slot: 330, Proposer: 4, Block: false},
+		},
+		SyncCommittee: []*epochSyncCommittee{
+			{Index: 6, Expected: 31, Missed: 0},
+			{Index: 7, Expected: 31, Missed: 2},
+		},
+	}
+
+	require.Equal(t, []*Fault{
+		{Epoch: 10, Slot: 320, ValidatorIndex: 7, Type: FaultMissedSync, Missed: 2, Expected: 31},
+		{Epoch: 10, Slot: 321, ValidatorIndex: 2, Type: FaultIncorrectHead, InclusionDelay: 1},
+		{Epoch: 10, Slot: 321, ValidatorIndex: 3, Type: FaultUntimelyTarget, InclusionDelay: 33},
+		{Epoch: 10, Slot: 325, ValidatorIndex: 5, Type: FaultNonParticipating},
+		{Epoch: 10, Slot: 330, ValidatorIndex: 4, Type: FaultMissedProposal},
+	}, summary.faults())
+
+	require.Empty(t, (&validatorSummary{Epoch: 10}).faults())
+}
@@ -17,7 +17,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
 )
 
 func (c *command) output(ctx context.Context) (string, error) {
@@ -25,11 +29,23 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
-	if c.jsonOutput {
-		return c.outputJSON(ctx)
+	if c.rangeSummary != nil {
+		return util.FormatOutput(ctx, &util.FormatOpts{
+			Format:   c.format,
+			Template: c.template,
+			JSON:     c.outputRangeJSON,
+			Text:     c.outputRangeTxt,
+			CSV:      c.outputCSV,
+		})
 	}
 
-	return c.outputTxt(ctx)
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputTxt,
+		CSV:      c.outputCSV,
+	})
 }
 
 func (c *command) outputJSON(_ context.Context) (string, error) {
@@ -40,6 +56,122 @@ func (c *command) outputJSON(_ context.Context) (string, error) {
 	return string(data), nil
 }
 
+func (c *command) outputRangeJSON(_ context.Context) (string, error) {
+	if c.verbose {
+		c.rangeSummary.Epochs = c.summaries
+	}
+	data, err := json.Marshal(c.rangeSummary)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// outputCSV outputs a row for each epoch.
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	summaries := c.summaries
+	if c.rangeSummary == nil {
+		summaries = []*validatorSummary{c.summary}
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString("epoch,active_validators,participating_validators,correct_head,timely_head,timely_source,correct_target,timely_target,proposals,missed_proposals,sync_committee_expected,sync_committee_missed")
+	for _, summary := range summaries {
+		totals := summariseEpochs(summary.Epoch, summary.Epoch, []*validatorSummary{summary})
+		builder.WriteString(fmt.Sprintf("\n%d,%d,%d,%d,%d,%d,%d,%d,%d,%d,%d,%d",
+			summary.Epoch,
+			totals.ActiveValidators,
+			totals.ParticipatingValidators,
+			totals.Attestations.CorrectHead,
+			totals.Attestations.TimelyHead,
+			totals.Attestations.TimelySource,
+			totals.Attestations.CorrectTarget,
+			totals.Attestations.TimelyTarget,
+			totals.Proposals,
+			len(totals.MissedProposals),
+			totals.SyncCommitteeExpected,
+			totals.SyncCommitteeMissed,
+		))
+	}
+
+	return builder.String(), nil
+}
+
+func (c *command) outputRangeTxt(_ context.Context) (string, error) {
+	summary := c.rangeSummary
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Epochs %d-%d:\n", summary.FromEpoch, summary.ToEpoch))
+	builder.WriteString(fmt.Sprintf("  Attestations: %d/%d (%0.2f%%)\n", summary.ParticipatingValidators, summary.ActiveValidators, percentage(summary.ParticipatingValidators, summary.ActiveValidators)))
+	builder.WriteString(fmt.Sprintf("    Correct head: %d/%d (%0.2f%%)\n", summary.Attestations.CorrectHead, summary.ActiveValidators, percentage(summary.Attestations.CorrectHead, summary.ActiveValidators)))
+	builder.WriteString(fmt.Sprintf("    Timely head: %d/%d (%0.2f%%)\n", summary.Attestations.TimelyHead, summary.ActiveValidators, percentage(summary.Attestations.TimelyHead, summary.ActiveValidators)))
+	builder.WriteString(fmt.Sprintf("    Timely source: %d/%d (%0.2f%%)\n", summary.Attestations.TimelySource, summary.ActiveValidators, percentage(summary.Attestations.TimelySource, summary.ActiveValidators)))
+	builder.WriteString(fmt.Sprintf("    Correct target: %d/%d (%0.2f%%)\n", summary.Attestations.CorrectTarget, summary.ActiveValidators, percentage(summary.Attestations.CorrectTarget, summary.ActiveValidators)))
+	builder.WriteString(fmt.Sprintf("    Timely target: %d/%d (%0.2f%%)\n", summary.Attestations.TimelyTarget, summary.ActiveValidators, percentage(summary.Attestations.TimelyTarget, summary.ActiveValidators)))
+
+	if len(summary.InclusionDelays) > 0 {
+		delays := make([]phase0.Slot, 0, len(summary.InclusionDelays))
+		for delay := range summary.InclusionDelays {
+			delays = append(delays, delay)
+		}
+		sort.Slice(delays, func(i int, j int) bool {
+			return delays[i] < delays[j]
+		})
+		builder.WriteString("  Inclusion delays:\n")
+		for _, delay := range delays {
+			builder.WriteString(fmt.Sprintf("    %d: %d (%0.2f%%)\n", delay, summary.InclusionDelays[delay], percentage(summary.InclusionDelays[delay], summary.ParticipatingValidators)))
+		}
+	}
+
+	if summary.Proposals > 0 {
+		proposed := summary.Proposals - len(summary.MissedProposals)
+		builder.WriteString(fmt.Sprintf("  Proposals: %d/%d (%0.2f%%)\n", proposed, summary.Proposals, percentage(proposed, summary.Proposals)))
+		for _, proposal := range summary.MissedProposals {
+			builder.WriteString(fmt.Sprintf("    Slot %d validator %d not proposed or not included\n", proposal.Slot, proposal.Proposer))
+		}
+	}
+
+	if summary.SyncCommitteeExpected > 0 {
+		included := summary.SyncCommitteeExpected - summary.SyncCommitteeMissed
+		builder.WriteString(fmt.Sprintf("  Sync committee: %d/%d (%0.2f%%)\n", included, summary.SyncCommitteeExpected, percentage(included, summary.SyncCommitteeExpected)))
+	}
+
+	if c.verbose && len(summary.ValidatorFaults) > 0 {
+		indices := make([]phase0.ValidatorIndex, 0, len(summary.ValidatorFaults))
+		for index := range summary.ValidatorFaults {
+			indices = append(indices, index)
+		}
+		sort.Slice(indices, func(i int, j int) bool {
+			return indices[i] < indices[j]
+		})
+		builder.WriteString("  Validator faults:\n")
+		for _, index := range indices {
+			faults := summary.ValidatorFaults[index]
+			builder.WriteString(fmt.Sprintf("    %d: non-participating %d, incorrect head %d, untimely head %d, untimely source %d, incorrect target %d, untimely target %d, missed proposals %d, missed sync %d\n",
+				index,
+				faults.NonParticipating,
+				faults.IncorrectHead,
+				faults.UntimelyHead,
+				faults.UntimelySource,
+				faults.IncorrectTarget,
+				faults.UntimelyTarget,
+				faults.MissedProposals,
+				faults.MissedSync,
+			))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func percentage(value int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return 100.0 * float64(value) / float64(total)
+}
+
 func (c *command) outputTxt(_ context.Context) (string, error) {
 	builder := strings.Builder{}
 
@@ -22,6 +22,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
@@ -39,10 +40,126 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	c.summary.Epoch, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
+	if c.epochRange {
+		return c.processEpochRange(ctx)
+	}
+
+	epoch, err := util.ParseEpoch(ctx, c.chainTime, c.epoch)
 	if err != nil {
 		return errors.Wrap(err, "failed to parse epoch")
 	}
+
+	return c.processEpoch(ctx, epoch)
+}
+
+// processEpochRange processes each epoch in the range concurrently and
+// aggregates the results.
+func (c *command) processEpochRange(ctx context.Context) error {
+	fromEpoch, toEpoch, err := util.ParseEpochRange(ctx, c.chainTime, c.fromEpoch, c.toEpoch, c.since)
+	if err != nil {
+		return err
+	}
+
+	c.summaries = make([]*validatorSummary, toEpoch-fromEpoch+1)
+	err = util.ForEachEpoch(ctx, fromEpoch, toEpoch, c.workers, func(ctx context.Context, epoch phase0.Epoch) error {
+		ec := c.epochCommand()
+		if err := ec.processEpoch(ctx, epoch); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to process epoch %d", epoch))
+		}
+		c.summaries[epoch-fromEpoch] = ec.summary
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.rangeSummary = summariseEpochs(fromEpoch, toEpoch, c.summaries)
+
+	return nil
+}
+
+// summariseEpochs aggregates the summaries for a range of epochs.
+func summariseEpochs(fromEpoch phase0.Epoch,
+	toEpoch phase0.Epoch,
+	summaries []*validatorSummary,
+) *validatorRangeSummary {
+	res := &validatorRangeSummary{
+		FromEpoch:       fromEpoch,
+		ToEpoch:         toEpoch,
+		Attestations:    &slotAttestations{},
+		InclusionDelays: make(map[phase0.Slot]int),
+		MissedProposals: make([]*epochProposal, 0),
+		ValidatorFaults: make(map[phase0.ValidatorIndex]*validatorFaults),
+	}
+	faults := func(index phase0.ValidatorIndex) *validatorFaults {
+		if _, exists := res.ValidatorFaults[index]; !exists {
+			res.ValidatorFaults[index] = &validatorFaults{}
+		}
+
+		return res.ValidatorFaults[index]
+	}
+
+	for _, summary := range summaries {
+		res.ActiveValidators += summary.ActiveValidators
+		res.ParticipatingValidators += summary.ParticipatingValidators
+		for _, slot := range summary.Slots {
+			if slot.Attestations == nil {
+				continue
+			}
+			res.Attestations.Expected += slot.Attestations.Expected
+			res.Attestations.Included += slot.Attestations.Included
+			res.Attestations.CorrectHead += slot.Attestations.CorrectHead
+			res.Attestations.TimelyHead += slot.Attestations.TimelyHead
+			res.Attestations.CorrectTarget += slot.Attestations.CorrectTarget
+			res.Attestations.TimelyTarget += slot.Attestations.TimelyTarget
+			res.Attestations.TimelySource += slot.Attestations.TimelySource
+		}
+		for delay, count := range summary.InclusionDelays {
+			res.InclusionDelays[delay] += count
+		}
+		for _, proposal := range summary.Proposals {
+			res.Proposals++
+			if !proposal.Block {
+				res.MissedProposals = append(res.MissedProposals, proposal)
+				faults(proposal.Proposer).MissedProposals++
+			}
+		}
+		for _, syncCommittee := range summary.SyncCommittee {
+			res.SyncCommitteeExpected += syncCommittee.Expected
+			res.SyncCommitteeMissed += syncCommittee.Missed
+			if syncCommittee.Missed > 0 {
+				faults(syncCommittee.Index).MissedSync += syncCommittee.Missed
+			}
+		}
+		for _, validator := range summary.NonParticipatingValidators {
+			faults(validator.Validator).NonParticipating++
+		}
+		for _, validator := range summary.IncorrectHeadValidators {
+			faults(validator.Validator).IncorrectHead++
+		}
+		for _, validator := range summary.UntimelyHeadValidators {
+			faults(validator.Validator).UntimelyHead++
+		}
+		for _, validator := range summary.UntimelySourceValidators {
+			faults(validator.Validator).UntimelySource++
+		}
+		for _, validator := range summary.IncorrectTargetValidators {
+			faults(validator.Validator).IncorrectTarget++
+		}
+		for _, validator := range summary.UntimelyTargetValidators {
+			faults(validator.Validator).UntimelyTarget++
+		}
+	}
+
+	return res
+}
+
+// processEpoch processes a single epoch, populating the summary.
+func (c *command) processEpoch(ctx context.Context, epoch phase0.Epoch) error {
+	var err error
+
+	c.summary.Epoch = epoch
 	c.summary.FirstSlot = c.chainTime.FirstSlotOfEpoch(c.summary.Epoch)
 	c.summary.LastSlot = c.chainTime.FirstSlotOfEpoch(c.summary.Epoch+1) - 1
 	c.summary.Slots = make([]*slot, 1+int(c.summary.LastSlot)-int(c.summary.FirstSlot))
@@ -75,11 +192,11 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	// if err := c.processSyncCommitteeDuties(ctx); err != nil {
-	// 	return err
-	// }
+	if !c.allDuties {
+		return nil
+	}
 
-	return nil
+	return c.processSyncCommitteeDuties(ctx)
 }
 
 func (c *command) processProposerDuties(ctx context.Context) error {
@@ -96,16 +213,18 @@ func (c *command) processProposerDuties(ctx context.Context) error {
 		blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
 			Block: fmt.Sprintf("%d", duty.Slot),
 		})
-		if err != nil {
-			var apiErr *api.Error
-			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		present := false
+		switch {
+		case err == nil:
+			present = blockResponse.Data != nil
+		case isNotFound(err):
+			if !c.allDuties {
 				return nil
 			}
-
+			// No block for this slot, so a missed proposal.
+		default:
 			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", duty.Slot))
 		}
-		block := blockResponse.Data
-		present := block != nil
 		c.summary.Proposals = append(c.summary.Proposals, &epochProposal{
 			Slot:     duty.Slot,
 			Proposer: duty.ValidatorIndex,
@@ -230,8 +349,7 @@ func (c *command) processAttesterDutiesSlot(ctx context.Context,
 		Block: fmt.Sprintf("%d", slot),
 	})
 	if err != nil {
-		var apiErr *api.Error
-		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		if isNotFound(err) {
 			return nil
 		}
 
@@ -272,6 +390,7 @@ func (c *command) processAttesterDutiesSlot(ctx context.Context,
 				index := int(attestationData.Slot - c.chainTime.FirstSlotOfEpoch(c.summary.Epoch))
 				c.summary.Slots[index].Attestations.Included++
 				inclusionDelay := slot - duty.Slot
+				c.summary.InclusionDelays[inclusionDelay]++
 
 				fault := &validatorFault{
 					Validator:         duty.ValidatorIndex,
@@ -332,75 +451,82 @@ func (c *command) processAttesterDutiesSlot(ctx context.Context,
 	return nil
 }
 
-// func (c *command) processSyncCommitteeDuties(ctx context.Context) error {
-// 	if c.summary.Epoch < c.chainTime.AltairInitialEpoch() {
-// 		// The epoch is pre-Altair.  No info but no error.
-// 		return nil
-// 	}
-//
-// 	committee, err := c.syncCommitteesProvider.SyncCommittee(ctx, fmt.Sprintf("%d", c.summary.FirstSlot))
-// 	if err != nil {
-// 		return errors.Wrap(err, "failed to obtain sync committee")
-// 	}
-// 	if len(committee.Validators) == 0 {
-// 		return errors.Wrap(err, "empty sync committee")
-// 	}
-//
-// 	missed := make(map[phase0.ValidatorIndex]int)
-// 	for _, index := range committee.Validators {
-// 		missed[index] = 0
-// 	}
-//
-// 	for slot := c.summary.FirstSlot; slot <= c.summary.LastSlot; slot++ {
-// 		block, err := c.blocksProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
-// 		if err != nil {
-// 			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
-// 		}
-// 		if block == nil {
-// 			// If the block is missed we don't count the sync aggregate miss.
-// 			continue
-// 		}
-// 		var aggregate *altair.SyncAggregate
-// 		switch block.Version {
-// 		case spec.DataVersionPhase0:
-// 			// No sync committees in this fork.
-// 			return nil
-// 		case spec.DataVersionAltair:
-// 			aggregate = block.Altair.Message.Body.SyncAggregate
-// 		case spec.DataVersionBellatrix:
-// 			aggregate = block.Bellatrix.Message.Body.SyncAggregate
-// 		default:
-// 			return fmt.Errorf("unhandled block version %v", block.Version)
-// 		}
-// 		for i := uint64(0); i < aggregate.SyncCommitteeBits.Len(); i++ {
-// 			if !aggregate.SyncCommitteeBits.BitAt(i) {
-// 				missed[committee.Validators[int(i)]]++
-// 			}
-// 		}
-// 	}
-//
-// 	c.summary.SyncCommittee = make([]*epochSyncCommittee, 0, len(missed))
-// 	for index, count := range missed {
-// 		if count > 0 {
-// 			c.summary.SyncCommittee = append(c.summary.SyncCommittee, &epochSyncCommittee{
-// 				Index:  index,
-// 				Missed: count,
-// 			})
-// 		}
-// 	}
-//
-// 	sort.Slice(c.summary.SyncCommittee, func(i int, j int) bool {
-// 		missedDiff := c.summary.SyncCommittee[i].Missed - c.summary.SyncCommittee[j].Missed
-// 		if missedDiff != 0 {
-// 			// Actually want to order by missed descending, so invert the expected condition.
-// 			return missedDiff > 0
-// 		}
-// 		// Then order by validator index.
-// 		return c.summary.SyncCommittee[i].Index < c.summary.SyncCommittee[j].Index
-// 	})
-//
-// 	return nil
-// }
+func (c *command) processSyncCommitteeDuties(ctx context.Context) error {
+	if c.summary.Epoch < c.chainTime.AltairInitialEpoch() {
+		// The epoch is pre-Altair.  No info but no error.
+		return nil
+	}
+
+	committeeResponse, err := c.syncCommitteesProvider.SyncCommittee(ctx, &api.SyncCommitteeOpts{
+		State: fmt.Sprintf("%d", c.summary.FirstSlot),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain sync committee")
+	}
+	committee := committeeResponse.Data
+
+	// A validator can hold more than one position in the sync committee.
+	activeValidators, _ := c.activeValidators()
+	positions := make(map[phase0.ValidatorIndex][]uint64)
+	for i, index := range committee.Validators {
+		if _, exists := activeValidators[index]; exists {
+			positions[index] = append(positions[index], uint64(i))
+		}
+	}
+	if len(positions) == 0 {
+		// None of our validators are in the sync committee.
+		return nil
+	}
+
+	syncCommittee := make(map[phase0.ValidatorIndex]*epochSyncCommittee, len(positions))
+	for index := range positions {
+		syncCommittee[index] = &epochSyncCommittee{
+			Index: index,
+		}
+	}
+
+	for slot := c.summary.FirstSlot; slot <= c.summary.LastSlot; slot++ {
+		blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			if isNotFound(err) {
+				// If the block is missed we don't count the sync aggregate miss.
+				continue
+			}
+
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		block := blockResponse.Data
+		if block.Version == spec.DataVersionPhase0 {
+			// No sync committees in this fork.
+			return nil
+		}
+
+		aggregate, err := block.SyncAggregate()
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain sync aggregate for slot %d", slot)
+		}
+		for index, validatorPositions := range positions {
+			for _, position := range validatorPositions {
+				syncCommittee[index].Expected++
+				if !aggregate.SyncCommitteeBits.BitAt(position) {
+					syncCommittee[index].Missed++
+				}
+			}
+		}
+	}
+
+	c.summary.SyncCommittee = make([]*epochSyncCommittee, 0, len(syncCommittee))
+	for _, validatorSyncCommittee := range syncCommittee {
+		c.summary.SyncCommittee = append(c.summary.SyncCommittee, validatorSyncCommittee)
+	}
+	sort.Slice(c.summary.SyncCommittee, func(i int, j int) bool {
+		return c.summary.SyncCommittee[i].Index < c.summary.SyncCommittee[j].Index
+	})
+
+	return nil
+}
 
 func (c *command) setup(ctx context.Context) error {
 	var err error
@@ -424,6 +550,11 @@ func (c *command) setup(ctx context.Context) error {
 		return errors.Wrap(err, "failed to set up chaintime service")
 	}
 
+	return c.setupProviders()
+}
+
+// setupProviders obtains the providers required from the beacon node connection.
+func (c *command) setupProviders() error {
 	var isProvider bool
 	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
 	if !isProvider {
@@ -456,3 +587,10 @@ func (c *command) setup(ctx context.Context) error {
 
 	return nil
 }
+
+// isNotFound returns true if the error is a beacon node response for a missing item.
+func isNotFound(err error) bool {
+	var apiErr *api.Error
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
@@ -15,9 +15,16 @@ package validatorsummary
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"os"
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 )
@@ -60,3 +67,177 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestSummariseEpochs(t *testing.T) {
+	summaries := []*validatorSummary{
+		{
+			Epoch:                   10,
+			ActiveValidators:        3,
+			ParticipatingValidators: 2,
+			NonParticipatingValidators: []*nonParticipatingValidator{
+				{Validator: 3, Slot: 325, Committee: 1},
+			},
+			IncorrectHeadValidators: []*validatorFault{
+				{Validator: 2, InclusionDistance: 1},
+			},
+			Slots: []*slot{
+				{
+					Slot: 320,
+					Attestations: &slotAttestations{
+						Expected:      3,
+						Included:      2,
+						CorrectHead:   1,
+						TimelyHead:    1,
+						CorrectTarget: 2,
+						TimelyTarget:  2,
+						TimelySource:  2,
+					},
+				},
+				{
+					Slot: 321,
+				},
+			},
+			InclusionDelays: map[phase0.Slot]int{1: 2},
+			Proposals: []*epochProposal{
+				{Slot: 322, Proposer: 1, Block: true},
+			},
+			SyncCommittee: []*epochSyncCommittee{
+				{Index: 2, Expected: 31, Missed: 1},
+			},
+		},
+		{
+			Epoch:                   11,
+			ActiveValidators:        3,
+			ParticipatingValidators: 3,
+			UntimelyTargetValidators: []*validatorFault{
+				{Validator: 3, InclusionDistance: 33},
+			},
+			Slots: []*slot{
+				{
+					Slot: 352,
+					Attestations: &slotAttestations{
+						Expected:      3,
+						Included:      3,
+						CorrectHead:   3,
+						TimelyHead:    2,
+						CorrectTarget: 3,
+						TimelyTarget:  2,
+						TimelySource:  3,
+					},
+				},
+			},
+			InclusionDelays: map[phase0.Slot]int{1: 2, 33: 1},
+			Proposals: []*epochProposal{
+				{Slot: 360, Proposer: 3, Block: false},
+			},
+			SyncCommittee: []*epochSyncCommittee{
+				{Index: 2, Expected: 32, Missed: 0},
+			},
+		},
+	}
+
+	res := summariseEpochs(10, 11, summaries)
+	require.Equal(t, 6, res.ActiveValidators)
+	require.Equal(t, 5, res.ParticipatingValidators)
+	require.Equal(t, &slotAttestations{
+		Expected:      6,
+		Included:      5,
+		CorrectHead:   4,
+		TimelyHead:    3,
+		CorrectTarget: 5,
+		TimelyTarget:  4,
+		TimelySource:  5,
+	}, res.Attestations)
+	require.Equal(t, map[phase0.Slot]int{1: 4, 33: 1}, res.InclusionDelays)
+	require.Equal(t, 2, res.Proposals)
+	require.Len(t, res.MissedProposals, 1)
+	require.Equal(t, 63, res.SyncCommitteeExpected)
+	require.Equal(t, 1, res.SyncCommitteeMissed)
+	require.Equal(t, map[phase0.ValidatorIndex]*validatorFaults{
+		2: {IncorrectHead: 1, MissedSync: 1},
+		3: {NonParticipating: 1, UntimelyTarget: 1, MissedProposals: 1},
+	}, res.ValidatorFaults)
+}
+
+// proposalsProvider provides proposer duties, with blocks present for only some slots.
+type proposalsProvider struct {
+	duties []*apiv1.ProposerDuty
+	blocks map[phase0.Slot]bool
+}
+
+func (p *proposalsProvider) ProposerDuties(_ context.Context,
+	_ *api.ProposerDutiesOpts,
+) (
+	*api.Response[[]*apiv1.ProposerDuty],
+	error,
+) {
+	return &api.Response[[]*apiv1.ProposerDuty]{Data: p.duties}, nil
+}
+
+func (p *proposalsProvider) SignedBeaconBlock(_ context.Context,
+	opts *api.SignedBeaconBlockOpts,
+) (
+	*api.Response[*spec.VersionedSignedBeaconBlock],
+	error,
+) {
+	for slot, present := range p.blocks {
+		if opts.Block == fmt.Sprintf("%d", slot) && present {
+			return &api.Response[*spec.VersionedSignedBeaconBlock]{Data: &spec.VersionedSignedBeaconBlock{}}, nil
+		}
+	}
+
+	return nil, &api.Error{StatusCode: http.StatusNotFound}
+}
+
+func TestProcessProposerDuties(t *testing.T) {
+	provider := &proposalsProvider{
+		duties: []*apiv1.ProposerDuty{
+			{Slot: 320, ValidatorIndex: 1},
+			{Slot: 321, ValidatorIndex: 2},
+			{Slot: 322, ValidatorIndex: 1},
+		},
+		blocks: map[phase0.Slot]bool{
+			320: true,
+			322: true,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		allDuties bool
+		proposals []*epochProposal
+	}{
+		{
+			name: "SingleEpoch",
+			proposals: []*epochProposal{
+				{Slot: 320, Proposer: 1, Block: true},
+			},
+		},
+		{
+			name:      "AllDuties",
+			allDuties: true,
+			proposals: []*epochProposal{
+				{Slot: 320, Proposer: 1, Block: true},
+				{Slot: 321, Proposer: 2, Block: false},
+				{Slot: 322, Proposer: 1, Block: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				allDuties:              test.allDuties,
+				proposerDutiesProvider: provider,
+				blocksProvider:         provider,
+			}
+			c.initEpochState()
+			c.summary.Epoch = 10
+			c.validatorsByIndex[1] = &apiv1.Validator{Index: 1}
+			c.validatorsByIndex[2] = &apiv1.Validator{Index: 2}
+
+			require.NoError(t, c.processProposerDuties(context.Background()))
+			require.Equal(t, test.proposals, c.summary.Proposals)
+		})
+	}
+}
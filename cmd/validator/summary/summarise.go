@@ -0,0 +1,128 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsummary
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+// EpochSummary is the summary of the performance of a set of validators in an epoch.
+type EpochSummary struct {
+	Epoch                   phase0.Epoch
+	ActiveValidators        int
+	ParticipatingValidators int
+	Attestations            *AttestationTotals
+	InclusionDelays         map[phase0.Slot]int
+	Proposals               []*Proposal
+	SyncCommittee           []*SyncCommitteeParticipation
+	Faults                  []*Fault
+}
+
+// AttestationTotals are the attestation totals for an epoch.
+type AttestationTotals struct {
+	Expected      int
+	Included      int
+	CorrectHead   int
+	TimelyHead    int
+	CorrectTarget int
+	TimelyTarget  int
+	TimelySource  int
+}
+
+// Proposal is the outcome of a block proposal duty.
+type Proposal struct {
+	Slot           phase0.Slot
+	ValidatorIndex phase0.ValidatorIndex
+	Proposed       bool
+}
+
+// SyncCommitteeParticipation is the participation of a validator in the sync committee for an epoch.
+type SyncCommitteeParticipation struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Expected       int
+	Missed         int
+}
+
+// Summarise summarises the performance of the given validators in the given epoch.
+func Summarise(ctx context.Context,
+	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+	validators []string,
+	epoch phase0.Epoch,
+) (
+	*EpochSummary,
+	error,
+) {
+	c := &command{
+		quiet:      true,
+		eth2Client: eth2Client,
+		chainTime:  chainTime,
+		validators: validators,
+		allDuties:  true,
+	}
+	c.initEpochState()
+	if err := c.setupProviders(); err != nil {
+		return nil, err
+	}
+
+	if err := c.processEpoch(ctx, epoch); err != nil {
+		return nil, err
+	}
+
+	return c.summary.export(), nil
+}
+
+// export converts the internal summary to its exported form.
+func (s *validatorSummary) export() *EpochSummary {
+	totals := summariseEpochs(s.Epoch, s.Epoch, []*validatorSummary{s})
+
+	res := &EpochSummary{
+		Epoch:                   s.Epoch,
+		ActiveValidators:        s.ActiveValidators,
+		ParticipatingValidators: s.ParticipatingValidators,
+		Attestations: &AttestationTotals{
+			Expected:      totals.Attestations.Expected,
+			Included:      totals.Attestations.Included,
+			CorrectHead:   totals.Attestations.CorrectHead,
+			TimelyHead:    totals.Attestations.TimelyHead,
+			CorrectTarget: totals.Attestations.CorrectTarget,
+			TimelyTarget:  totals.Attestations.TimelyTarget,
+			TimelySource:  totals.Attestations.TimelySource,
+		},
+		InclusionDelays: totals.InclusionDelays,
+		Proposals:       make([]*Proposal, 0, len(s.Proposals)),
+		SyncCommittee:   make([]*SyncCommitteeParticipation, 0, len(s.SyncCommittee)),
+		Faults:          s.faults(),
+	}
+	for _, proposal := range s.Proposals {
+		res.Proposals = append(res.Proposals, &Proposal{
+			Slot:           proposal.Slot,
+			ValidatorIndex: proposal.Proposer,
+			Proposed:       proposal.Block,
+		})
+	}
+	for _, syncCommittee := range s.SyncCommittee {
+		res.SyncCommittee = append(res.SyncCommittee, &SyncCommitteeParticipation{
+			ValidatorIndex: syncCommittee.Index,
+			Expected:       syncCommittee.Expected,
+			Missed:         syncCommittee.Missed,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,55 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsummary
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	summary := &validatorSummary{
+		Epoch:                   10,
+		FirstSlot:               320,
+		ActiveValidators:        3,
+		ParticipatingValidators: 2,
+		NonParticipatingValidators: []*nonParticipatingValidator{
+			{Validator: 5, Slot: 325, Committee: 1},
+		},
+		Slots: []*slot{
+			{Slot: 321, Attestations: &slotAttestations{Expected: 2, Included: 2, CorrectHead: 1, TimelyHead: 1, CorrectTarget: 2, TimelyTarget: 2, TimelySource: 2}},
+			{Slot: 325, Attestations: &slotAttestations{Expected: 1}},
+			{Slot: 326},
+		},
+		InclusionDelays: map[phase0.Slot]int{1: 2},
+		Proposals: []*epochProposal{
+			{Slot: 330, Proposer: 4, Block: false},
+		},
+		SyncCommittee: []*epochSyncCommittee{
+			{Index: 7, Expected: 32, Missed: 2},
+		},
+	}
+
+	res := summary.export()
+	require.Equal(t, phase0.Epoch(10), res.Epoch)
+	require.Equal(t, 3, res.ActiveValidators)
+	require.Equal(t, 2, res.ParticipatingValidators)
+	require.Equal(t, &AttestationTotals{Expected: 3, Included: 2, CorrectHead: 1, TimelyHead: 1, CorrectTarget: 2, TimelyTarget: 2, TimelySource: 2}, res.Attestations)
+	require.Equal(t, map[phase0.Slot]int{1: 2}, res.InclusionDelays)
+	require.Equal(t, []*Proposal{{Slot: 330, ValidatorIndex: 4, Proposed: false}}, res.Proposals)
+	require.Equal(t, []*SyncCommitteeParticipation{{ValidatorIndex: 7, Expected: 32, Missed: 2}}, res.SyncCommittee)
+	require.Len(t, res.Faults, 3)
+}
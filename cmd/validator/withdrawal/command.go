@@ -1,4 +1,4 @@
-// Copyright © 2023 Weald Technology Trading.
+// Copyright © 2023, 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -23,14 +23,16 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type command struct {
-	quiet   bool
-	verbose bool
-	debug   bool
-	offline bool
-	json    bool
+	quiet    bool
+	verbose  bool
+	debug    bool
+	offline  bool
+	format   string
+	template string
 
 	// Input.
 	validator string
@@ -41,10 +43,9 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Processing.
-	consensusClient          consensusclient.Service
-	chainTime                chaintime.Service
-	maxWithdrawalsPerPayload uint64
-	maxEffectiveBalance      phase0.Gwei
+	consensusClient consensusclient.Service
+	chainTime       chaintime.Service
+	sweep           *sweep
 
 	// Output.
 	res *res
@@ -56,7 +57,8 @@ func newCommand(_ context.Context) (*command, error) {
 		verbose:                  viper.GetBool("verbose"),
 		debug:                    viper.GetBool("debug"),
 		offline:                  viper.GetBool("offline"),
-		json:                     viper.GetBool("json"),
+		format:                   util.OutputFormat(),
+		template:                 viper.GetString("template"),
 		timeout:                  viper.GetDuration("timeout"),
 		connection:               viper.GetString("connection"),
 		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
@@ -77,6 +79,8 @@ func newCommand(_ context.Context) (*command, error) {
 }
 
 type res struct {
+	Amount          phase0.Gwei
+	Pending         bool
 	WithdrawalsToGo uint64
 	BlocksToGo      uint64
 	Block           uint64
@@ -85,6 +89,8 @@ type res struct {
 }
 
 type resJSON struct {
+	Amount            uint64 `json:"amount"`
+	Pending           bool   `json:"pending_partial_withdrawal"`
 	WithdrawalsToGo   uint64 `json:"withdrawals_to_go"`
 	BlocksToGo        uint64 `json:"blocks_to_go"`
 	Block             uint64 `json:"block"`
@@ -96,6 +102,8 @@ type resJSON struct {
 
 func (r *res) MarshalJSON() ([]byte, error) {
 	data := resJSON{
+		Amount:            uint64(r.Amount),
+		Pending:           r.Pending,
 		WithdrawalsToGo:   r.WithdrawalsToGo,
 		BlocksToGo:        r.BlocksToGo,
 		Block:             r.Block,
@@ -1,4 +1,4 @@
-// Copyright © 2023 Weald Technology Trading.
+// Copyright © 2023, 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -19,21 +19,42 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
 )
 
-//nolint:unparam
-func (c *command) output(_ context.Context) (string, error) {
+func (c *command) output(ctx context.Context) (string, error) {
 	if c.quiet {
 		return "", nil
 	}
 
-	if c.json {
-		data, err := json.Marshal(c.res)
-		if err != nil {
-			return "", errors.Wrap(err, "failed to marshal results")
-		}
-		return string(data), nil
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputTxt,
+	})
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.res)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal results")
+	}
+	return string(data), nil
+}
+
+//nolint:unparam
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	description := "Withdrawal"
+	if c.res.Pending {
+		description = "Requested partial withdrawal"
 	}
 
-	return fmt.Sprintf("Withdrawal expected at %s in block %d", c.res.Expected.Format("2006-01-02T15:04:05"), c.res.Block), nil
+	return fmt.Sprintf("%s of %s expected at %s in block %d",
+		description,
+		string2eth.GWeiToString(uint64(c.res.Amount), true),
+		c.res.Expected.Format("2006-01-02T15:04:05"),
+		c.res.Block,
+	), nil
 }
@@ -1,4 +1,4 @@
-// Copyright © 2023, 2024 Weald Technology Trading.
+// Copyright © 2023 - 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -22,16 +22,13 @@ import (
 	consensusclient "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
 )
 
-const (
-	ethWithdrawalPrefix = 0x01
-)
-
 func (c *command) process(ctx context.Context) error {
 	if err := c.setup(ctx); err != nil {
 		return err
@@ -42,7 +39,7 @@ func (c *command) process(ctx context.Context) error {
 		return errors.Wrap(err, "failed to parse validator")
 	}
 
-	if validator.Validator.WithdrawalCredentials[0] != ethWithdrawalPrefix {
+	if !c.sweep.hasExecutionWithdrawalCredential(validator.Validator) {
 		return errors.New("validator does not have suitable withdrawal credentials")
 	}
 	if validator.Balance == 0 {
@@ -63,9 +60,10 @@ func (c *command) process(ctx context.Context) error {
 	if c.debug {
 		fmt.Fprintf(os.Stderr, "Current slot is %d\n", slot)
 	}
+	stateID := fmt.Sprintf("%d", slot)
 
 	response, err := c.consensusClient.(consensusclient.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{
-		State: fmt.Sprintf("%d", slot),
+		State: stateID,
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain validators")
@@ -75,6 +73,14 @@ func (c *command) process(ctx context.Context) error {
 		validators[validator.Index] = validator
 	}
 
+	pendingPartialWithdrawals, err := c.obtainPendingPartialWithdrawals(ctx, stateID)
+	if err != nil {
+		return err
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "There are %d pending partial withdrawals\n", len(pendingPartialWithdrawals))
+	}
+
 	withdrawals, err := block.Withdrawals()
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain withdrawals from block")
@@ -88,43 +94,49 @@ func (c *command) process(ctx context.Context) error {
 		fmt.Fprintf(os.Stderr, "Next withdrawal validator index is %d\n", nextWithdrawalValidatorIndex)
 	}
 
-	withdrawalSlot := slot + 1
-	withdrawalsInSlot := 0
-	index := int(nextWithdrawalValidatorIndex)
-	for {
-		if index == len(validators) {
-			index = 0
-		}
-		if index == int(validator.Index) {
-			break
-		}
-		if validators[index].Validator.WithdrawalCredentials[0] == ethWithdrawalPrefix &&
-			validators[index].Validator.EffectiveBalance == c.maxEffectiveBalance &&
-			validators[index].Validator.ActivationEpoch <= c.chainTime.SlotToEpoch(withdrawalSlot) {
-			c.res.WithdrawalsToGo++
-			withdrawalsInSlot++
-			if withdrawalsInSlot == int(c.maxWithdrawalsPerPayload) {
-				withdrawalSlot++
-				withdrawalsInSlot = 0
-			}
-		}
-		index++
+	next, err := c.sweep.next(validators, pendingPartialWithdrawals, nextWithdrawalValidatorIndex, slot+1, validator.Index)
+	if err != nil {
+		return err
 	}
+	c.res.WithdrawalsToGo = next.withdrawalsToGo
 	if c.debug {
 		fmt.Fprintf(os.Stderr, "There are %d withdrawals to go until this validator\n", c.res.WithdrawalsToGo)
 	}
 
-	c.res.BlocksToGo = c.res.WithdrawalsToGo / c.maxWithdrawalsPerPayload
-	if c.res.WithdrawalsToGo%c.maxWithdrawalsPerPayload != 0 {
-		c.res.BlocksToGo++
-	}
-	c.res.Block = uint64(slot) + c.res.BlocksToGo
-	c.res.Expected = c.chainTime.StartOfSlot(phase0.Slot(c.res.Block))
+	c.res.Amount = next.amount
+	c.res.Pending = next.pending
+	c.res.Block = uint64(next.slot)
+	c.res.BlocksToGo = uint64(next.slot - slot)
+	c.res.Expected = c.chainTime.StartOfSlot(next.slot)
 	c.res.Wait = time.Until(c.res.Expected)
 
 	return nil
 }
 
+func (c *command) obtainPendingPartialWithdrawals(ctx context.Context,
+	stateID string,
+) (
+	[]*electra.PendingPartialWithdrawal,
+	error,
+) {
+	if !c.sweep.electra {
+		return nil, nil
+	}
+
+	provider, isProvider := c.consensusClient.(consensusclient.PendingPartialWithdrawalsProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide pending partial withdrawals")
+	}
+	response, err := provider.PendingPartialWithdrawals(ctx, &api.PendingPartialWithdrawalsOpts{
+		State: stateID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain pending partial withdrawals")
+	}
+
+	return response.Data, nil
+}
+
 func (c *command) setup(ctx context.Context) error {
 	// Connect to the consensus node.
 	var err error
@@ -152,16 +164,37 @@ func (c *command) setup(ctx context.Context) error {
 		return errors.Wrap(err, "failed to obtain spec")
 	}
 
-	if val, exists := specResponse.Data["MAX_WITHDRAWALS_PER_PAYLOAD"]; !exists {
-		c.maxWithdrawalsPerPayload = 16
-	} else {
-		c.maxWithdrawalsPerPayload = val.(uint64)
+	c.sweep = &sweep{
+		maxWithdrawalsPerPayload:              16,
+		maxValidatorsPerWithdrawalsSweep:      16384,
+		maxPendingPartialsPerWithdrawalsSweep: 8,
+		maxEffectiveBalance:                   32000000000,
+		maxEffectiveBalanceElectra:            2048000000000,
+		minActivationBalance:                  32000000000,
+		slotToEpoch:                           c.chainTime.SlotToEpoch,
 	}
-
-	if val, exists := specResponse.Data["MAX_EFFECTIVE_BALANCE"]; !exists {
-		c.maxEffectiveBalance = 32000000000
-	} else {
-		c.maxEffectiveBalance = phase0.Gwei(val.(uint64))
+	if val, exists := specResponse.Data["MAX_WITHDRAWALS_PER_PAYLOAD"].(uint64); exists {
+		c.sweep.maxWithdrawalsPerPayload = val
+	}
+	if val, exists := specResponse.Data["MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP"].(uint64); exists {
+		c.sweep.maxValidatorsPerWithdrawalsSweep = val
+	}
+	if val, exists := specResponse.Data["MAX_PENDING_PARTIALS_PER_WITHDRAWALS_SWEEP"].(uint64); exists {
+		c.sweep.maxPendingPartialsPerWithdrawalsSweep = val
+	}
+	if val, exists := specResponse.Data["MAX_EFFECTIVE_BALANCE"].(uint64); exists {
+		c.sweep.maxEffectiveBalance = phase0.Gwei(val)
+	}
+	if val, exists := specResponse.Data["MAX_EFFECTIVE_BALANCE_ELECTRA"].(uint64); exists {
+		c.sweep.maxEffectiveBalanceElectra = phase0.Gwei(val)
+	}
+	if val, exists := specResponse.Data["MIN_ACTIVATION_BALANCE"].(uint64); exists {
+		c.sweep.minActivationBalance = phase0.Gwei(val)
+	}
+	for _, fork := range c.chainTime.Forks() {
+		if fork.Name == "electra" && fork.Epoch <= c.chainTime.CurrentEpoch() {
+			c.sweep.electra = true
+		}
 	}
 
 	return nil
@@ -0,0 +1,197 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawl
+
+import (
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+const (
+	farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+)
+
+// sweep simulates the withdrawals made by the chain's withdrawal sweep.
+type sweep struct {
+	electra                               bool
+	maxWithdrawalsPerPayload              uint64
+	maxValidatorsPerWithdrawalsSweep      uint64
+	maxPendingPartialsPerWithdrawalsSweep uint64
+	maxEffectiveBalance                   phase0.Gwei
+	maxEffectiveBalanceElectra            phase0.Gwei
+	minActivationBalance                  phase0.Gwei
+	slotToEpoch                           func(phase0.Slot) phase0.Epoch
+}
+
+// withdrawal is a withdrawal found by the sweep.
+type withdrawal struct {
+	slot            phase0.Slot
+	amount          phase0.Gwei
+	pending         bool
+	withdrawalsToGo uint64
+}
+
+// next simulates the sweep from the given slot, returning the next withdrawal for the target validator.
+// validators must be indexed by validator index.
+func (s *sweep) next(validators []*apiv1.Validator,
+	pendingPartialWithdrawals []*electra.PendingPartialWithdrawal,
+	nextWithdrawalValidatorIndex phase0.ValidatorIndex,
+	slot phase0.Slot,
+	target phase0.ValidatorIndex,
+) (
+	*withdrawal,
+	error,
+) {
+	if int(target) >= len(validators) {
+		return nil, errors.New("unknown validator")
+	}
+	if s.maxWithdrawalsPerPayload == 0 || s.maxValidatorsPerWithdrawalsSweep == 0 {
+		return nil, errors.New("withdrawal sweep parameters missing")
+	}
+
+	balances := make([]phase0.Gwei, len(validators))
+	for i := range validators {
+		balances[i] = validators[i].Balance
+	}
+	pending := pendingPartialWithdrawals
+	if !s.electra {
+		pending = nil
+	}
+
+	// The sweep never scans more validators than there are in the chain.
+	maxValidatorsPerWithdrawalsSweep := min(uint64(len(validators)), s.maxValidatorsPerWithdrawalsSweep)
+
+	withdrawalsToGo := uint64(0)
+	index := int(nextWithdrawalValidatorIndex) % len(validators)
+	for {
+		epoch := s.slotToEpoch(slot)
+		withdrawals := uint64(0)
+
+		// Pending partial withdrawals are processed first.
+		processed := 0
+		for _, pendingPartialWithdrawal := range pending {
+			if pendingPartialWithdrawal.WithdrawableEpoch > epoch || withdrawals == s.maxPendingPartialsPerWithdrawalsSweep {
+				break
+			}
+			processed++
+			validatorIndex := pendingPartialWithdrawal.ValidatorIndex
+			if int(validatorIndex) >= len(validators) {
+				continue
+			}
+			validator := validators[validatorIndex].Validator
+			if validator.ExitEpoch != farFutureEpoch ||
+				validator.EffectiveBalance < s.minActivationBalance ||
+				balances[validatorIndex] <= s.minActivationBalance {
+				continue
+			}
+			amount := min(balances[validatorIndex]-s.minActivationBalance, pendingPartialWithdrawal.Amount)
+			if validatorIndex == target {
+				return &withdrawal{
+					slot:            slot,
+					amount:          amount,
+					pending:         true,
+					withdrawalsToGo: withdrawalsToGo,
+				}, nil
+			}
+			balances[validatorIndex] -= amount
+			withdrawals++
+			withdrawalsToGo++
+		}
+		pending = pending[processed:]
+
+		// Then the sweep.
+		startIndex := index
+		lastIndex := -1
+		for scanned := uint64(0); scanned < maxValidatorsPerWithdrawalsSweep && withdrawals < s.maxWithdrawalsPerPayload; scanned++ {
+			amount := s.sweepAmount(validators[index].Validator, balances[index], epoch)
+			if index == int(target) {
+				if amount > 0 {
+					return &withdrawal{
+						slot:            slot,
+						amount:          amount,
+						withdrawalsToGo: withdrawalsToGo,
+					}, nil
+				}
+				if !hasPendingPartialWithdrawal(pending, target) {
+					return nil, errors.New("validator has nothing to withdraw")
+				}
+			}
+			if amount > 0 {
+				balances[index] -= amount
+				withdrawals++
+				withdrawalsToGo++
+				lastIndex = index
+			}
+			index = (index + 1) % len(validators)
+		}
+		if withdrawals == s.maxWithdrawalsPerPayload && lastIndex >= 0 {
+			index = (lastIndex + 1) % len(validators)
+		} else {
+			// The chain moves on by the full sweep, even if there are fewer validators.
+			index = int((uint64(startIndex) + s.maxValidatorsPerWithdrawalsSweep) % uint64(len(validators)))
+		}
+
+		slot++
+	}
+}
+
+func hasPendingPartialWithdrawal(pendingPartialWithdrawals []*electra.PendingPartialWithdrawal, index phase0.ValidatorIndex) bool {
+	for _, pendingPartialWithdrawal := range pendingPartialWithdrawals {
+		if pendingPartialWithdrawal.ValidatorIndex == index {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sweepAmount returns the amount the sweep would withdraw from the validator.
+func (s *sweep) sweepAmount(validator *phase0.Validator, balance phase0.Gwei, epoch phase0.Epoch) phase0.Gwei {
+	if !s.hasExecutionWithdrawalCredential(validator) || balance == 0 {
+		return 0
+	}
+	if validator.WithdrawableEpoch <= epoch {
+		// Fully withdrawable.
+		return balance
+	}
+	maxEffectiveBalance := s.validatorMaxEffectiveBalance(validator)
+	if validator.EffectiveBalance == maxEffectiveBalance && balance > maxEffectiveBalance {
+		// Partially withdrawable.
+		return balance - maxEffectiveBalance
+	}
+
+	return 0
+}
+
+func (s *sweep) hasExecutionWithdrawalCredential(validator *phase0.Validator) bool {
+	switch validator.WithdrawalCredentials[0] {
+	case util.EthWithdrawalPrefix:
+		return true
+	case util.CompoundingWithdrawalPrefix:
+		return s.electra
+	default:
+		return false
+	}
+}
+
+func (s *sweep) validatorMaxEffectiveBalance(validator *phase0.Validator) phase0.Gwei {
+	if s.electra && validator.WithdrawalCredentials[0] == util.CompoundingWithdrawalPrefix {
+		return s.maxEffectiveBalanceElectra
+	}
+
+	return s.maxEffectiveBalance
+}
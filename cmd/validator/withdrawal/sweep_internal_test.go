@@ -0,0 +1,189 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawl
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func testValidator(index phase0.ValidatorIndex, prefix byte, effectiveBalance phase0.Gwei, balance phase0.Gwei) *apiv1.Validator {
+	return &apiv1.Validator{
+		Index:   index,
+		Balance: balance,
+		Validator: &phase0.Validator{
+			WithdrawalCredentials: append([]byte{prefix}, make([]byte, 31)...),
+			EffectiveBalance:      effectiveBalance,
+			ExitEpoch:             farFutureEpoch,
+			WithdrawableEpoch:     farFutureEpoch,
+		},
+	}
+}
+
+func TestSweepNext(t *testing.T) {
+	exited := testValidator(4, util.EthWithdrawalPrefix, 32000000000, 31000000000)
+	exited.Validator.ExitEpoch = 1
+	exited.Validator.WithdrawableEpoch = 2
+	validators := []*apiv1.Validator{
+		testValidator(0, util.EthWithdrawalPrefix, 32000000000, 32010000000),
+		testValidator(1, 0x00, 32000000000, 32010000000),
+		testValidator(2, util.CompoundingWithdrawalPrefix, 32000000000, 32010000000),
+		testValidator(3, util.CompoundingWithdrawalPrefix, 2048000000000, 2048010000000),
+		exited,
+		testValidator(5, util.EthWithdrawalPrefix, 32000000000, 32020000000),
+		testValidator(6, util.CompoundingWithdrawalPrefix, 64000000000, 64000000000),
+	}
+
+	tests := []struct {
+		name    string
+		electra bool
+		pending []*electra.PendingPartialWithdrawal
+		next    phase0.ValidatorIndex
+		target  phase0.ValidatorIndex
+		res     *withdrawal
+		err     string
+	}{
+		{
+			name:   "Immediate",
+			target: 0,
+			res:    &withdrawal{slot: 100, amount: 10000000},
+		},
+		{
+			name:   "NoCredentials",
+			target: 1,
+			err:    "validator has nothing to withdraw",
+		},
+		{
+			name:   "CompoundingPreElectra",
+			target: 3,
+			err:    "validator has nothing to withdraw",
+		},
+		{
+			name:    "Compounding",
+			electra: true,
+			target:  3,
+			res:     &withdrawal{slot: 100, amount: 10000000, withdrawalsToGo: 1},
+		},
+		{
+			name:    "CompoundingBelowMax",
+			electra: true,
+			target:  2,
+			err:     "validator has nothing to withdraw",
+		},
+		{
+			name:    "FullWithdrawal",
+			electra: true,
+			target:  5,
+			res:     &withdrawal{slot: 101, amount: 20000000, withdrawalsToGo: 3},
+		},
+		{
+			name:    "Wrap",
+			electra: true,
+			next:    4,
+			target:  0,
+			res:     &withdrawal{slot: 101, amount: 10000000, withdrawalsToGo: 2},
+		},
+		{
+			name:    "PendingPartial",
+			electra: true,
+			pending: []*electra.PendingPartialWithdrawal{
+				{ValidatorIndex: 6, Amount: 5000000000, WithdrawableEpoch: 5},
+			},
+			target: 6,
+			res:    &withdrawal{slot: 160, amount: 5000000000, pending: true, withdrawalsToGo: 4},
+		},
+		{
+			name:    "PendingPartialCapped",
+			electra: true,
+			pending: []*electra.PendingPartialWithdrawal{
+				{ValidatorIndex: 6, Amount: 40000000000},
+			},
+			target: 6,
+			res:    &withdrawal{slot: 100, amount: 32000000000, pending: true},
+		},
+		{
+			name: "PendingPartialPreElectra",
+			pending: []*electra.PendingPartialWithdrawal{
+				{ValidatorIndex: 6, Amount: 5000000000},
+			},
+			target: 6,
+			err:    "validator has nothing to withdraw",
+		},
+		{
+			name:   "Unknown",
+			target: 10,
+			err:    "unknown validator",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &sweep{
+				electra:                               test.electra,
+				maxWithdrawalsPerPayload:              2,
+				maxValidatorsPerWithdrawalsSweep:      4,
+				maxPendingPartialsPerWithdrawalsSweep: 1,
+				maxEffectiveBalance:                   32000000000,
+				maxEffectiveBalanceElectra:            2048000000000,
+				minActivationBalance:                  32000000000,
+				slotToEpoch: func(slot phase0.Slot) phase0.Epoch {
+					return phase0.Epoch(slot / 32)
+				},
+			}
+			res, err := s.next(validators, test.pending, test.next, 100, test.target)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestSweepNextSmallValidatorSet(t *testing.T) {
+	validators := make([]*apiv1.Validator, 3)
+	for i := range validators {
+		validators[i] = testValidator(phase0.ValidatorIndex(i), util.EthWithdrawalPrefix, 32000000000, 32000000000)
+		validators[i].Validator.ExitEpoch = 1
+		validators[i].Validator.WithdrawableEpoch = 4
+	}
+	// A pending partial withdrawal that is never reached keeps the target in the sweep.
+	pending := []*electra.PendingPartialWithdrawal{
+		{ValidatorIndex: 2, Amount: 1000000000, WithdrawableEpoch: 1000},
+	}
+
+	s := &sweep{
+		electra:                               true,
+		maxWithdrawalsPerPayload:              1,
+		maxValidatorsPerWithdrawalsSweep:      4,
+		maxPendingPartialsPerWithdrawalsSweep: 1,
+		maxEffectiveBalance:                   32000000000,
+		maxEffectiveBalanceElectra:            2048000000000,
+		minActivationBalance:                  32000000000,
+		slotToEpoch: func(slot phase0.Slot) phase0.Epoch {
+			return phase0.Epoch(slot / 32)
+		},
+	}
+	// Each slot scans the 3 validators once, but the next slot starts 4 validators on,
+	// so validator 1 is first when the validators become withdrawable in slot 128.
+	res, err := s.next(validators, pending, 0, 100, 2)
+	require.NoError(t, err)
+	require.Equal(t, &withdrawal{slot: 129, amount: 32000000000, withdrawalsToGo: 1}, res)
+}
@@ -0,0 +1,168 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawalrequest
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type command struct {
+	quiet    bool
+	verbose  bool
+	debug    bool
+	format   string
+	template string
+
+	// Input.
+	validator            string
+	withdrawalAddressStr string
+	amountStr            string
+	feeStr               string
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Information required to generate the request.
+	withdrawalAddress bellatrix.ExecutionAddress
+	amount            phase0.Gwei
+	fee               *big.Int
+
+	// Processing.
+	consensusClient      consensusclient.Service
+	chainTime            chaintime.Service
+	minActivationBalance phase0.Gwei
+	shardCommitteePeriod phase0.Epoch
+	validatorInfo        *apiv1.Validator
+	pendingWithdrawals   phase0.Gwei
+	currentEpoch         phase0.Epoch
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		format:                   util.OutputFormat(),
+		template:                 viper.GetString("template"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		validator:                viper.GetString("validator"),
+		withdrawalAddressStr:     viper.GetString("withdrawal-address"),
+		amountStr:                viper.GetString("amount"),
+		feeStr:                   viper.GetString("fee"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+
+	if err := c.parseWithdrawalAddress(); err != nil {
+		return nil, errors.Wrap(err, "invalid withdrawal address")
+	}
+
+	if err := c.parseAmount(); err != nil {
+		return nil, err
+	}
+
+	if err := c.parseFee(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *command) parseWithdrawalAddress() error {
+	// Check that a withdrawal address has been provided.
+	if c.withdrawalAddressStr == "" {
+		return errors.New("no withdrawal address provided")
+	}
+	// Check that the withdrawal address contains a 0x prefix.
+	if !strings.HasPrefix(c.withdrawalAddressStr, "0x") {
+		return fmt.Errorf("withdrawal address %s does not contain a 0x prefix", c.withdrawalAddressStr)
+	}
+	withdrawalAddressBytes, err := hex.DecodeString(strings.TrimPrefix(c.withdrawalAddressStr, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain execution address")
+	}
+	if len(withdrawalAddressBytes) != bellatrix.ExecutionAddressLength {
+		return errors.New("withdrawal address must be exactly 20 bytes in length")
+	}
+	// Ensure the address is properly checksummed.
+	checksummedAddress := util.AddressBytesToEIP55(withdrawalAddressBytes)
+	if checksummedAddress != c.withdrawalAddressStr {
+		return fmt.Errorf("withdrawal address checksum does not match (expected %s)", checksummedAddress)
+	}
+	copy(c.withdrawalAddress[:], withdrawalAddressBytes)
+
+	return nil
+}
+
+func (c *command) parseAmount() error {
+	if c.amountStr == "" {
+		return errors.New("amount is required; use 0 for a full exit")
+	}
+	amount, err := string2eth.StringToWei(c.amountStr)
+	if err != nil {
+		return errors.Wrap(err, "invalid amount")
+	}
+	// An amount that rounds down to 0 Gwei would be a full exit, so ensure
+	// that any non-zero amount is not below the request granularity.
+	if amount.Sign() != 0 && amount.Cmp(big.NewInt(1_000_000_000)) < 0 {
+		return errors.New("amount must be 0 for a full exit, or at least 1 Gwei")
+	}
+	c.amount = phase0.Gwei(amount.Div(amount, big.NewInt(1_000_000_000)).Uint64())
+
+	return nil
+}
+
+func (c *command) parseFee() error {
+	// The fee varies with demand and can only be obtained from the execution
+	// layer, so it must be supplied rather than defaulting to the minimum.
+	if c.feeStr == "" {
+		return fmt.Errorf("fee is required; the current fee can be obtained by calling %s with empty data", withdrawalRequestPredeployAddress)
+	}
+	fee, err := string2eth.StringToWei(c.feeStr)
+	if err != nil {
+		return errors.Wrap(err, "invalid fee")
+	}
+	if fee.Cmp(big.NewInt(minWithdrawalRequestFee)) < 0 {
+		return fmt.Errorf("fee must be at least %d wei", minWithdrawalRequestFee)
+	}
+	c.fee = fee
+
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawalrequest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"validator":          "1",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+				"amount":             "0",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ValidatorMissing",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+				"amount":             "0",
+			},
+			err: "validator is required",
+		},
+		{
+			name: "WithdrawalAddressMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"validator": "1",
+				"amount":    "0",
+			},
+			err: "invalid withdrawal address: no withdrawal address provided",
+		},
+		{
+			name: "WithdrawalAddressChecksumBad",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"validator":          "1",
+				"withdrawal-address": "0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15",
+				"amount":             "0",
+			},
+			err: "invalid withdrawal address: withdrawal address checksum does not match (expected 0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15)",
+		},
+		{
+			name: "AmountMissing",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"validator":          "1",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+			},
+			err: "amount is required; use 0 for a full exit",
+		},
+		{
+			name: "AmountInvalid",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"validator":          "1",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+				"amount":             "-1 Ether",
+			},
+			err: "invalid amount: value resulted in negative number of Wei",
+		},
+		{
+			name: "AmountTooSmall",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"validator":          "1",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+				"amount":             "1",
+			},
+			err: "amount must be 0 for a full exit, or at least 1 Gwei",
+		},
+		{
+			name: "FeeInvalid",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"validator":          "1",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+				"amount":             "0",
+				"fee":                "0",
+			},
+			err: "fee must be at least 1 wei",
+		},
+		{
+			name: "FeeMissing",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"validator":          "1",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+				"amount":             "0",
+			},
+			err: "fee is required; the current fee can be obtained by calling 0x00000961Ef480Eb55e80D19ad83579A64c007002 with empty data",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"validator":          "1",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+				"amount":             "1.5 Ether",
+				"fee":                "1 gwei",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawalrequest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type transactionJSON struct {
+	To      string `json:"to"`
+	Data    string `json:"data"`
+	Value   string `json:"value"`
+	FeeHint string `json:"fee_hint"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputText,
+	})
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&transactionJSON{
+		To:      withdrawalRequestPredeployAddress,
+		Data:    fmt.Sprintf("%#x", withdrawalRequestCalldata(c.validatorInfo.Validator.PublicKey, c.amount)),
+		Value:   c.fee.String(),
+		FeeHint: c.feeHint(),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal transaction")
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verbose {
+		if c.amount == 0 {
+			builder.WriteString(fmt.Sprintf("Full exit request for validator %d\n", c.validatorInfo.Index))
+		} else {
+			builder.WriteString(fmt.Sprintf("Partial withdrawal request of %s for validator %d\n", string2eth.GWeiToString(uint64(c.amount), true), c.validatorInfo.Index))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("To: %s\n", withdrawalRequestPredeployAddress))
+	builder.WriteString(fmt.Sprintf("Data: %#x\n", withdrawalRequestCalldata(c.validatorInfo.Validator.PublicKey, c.amount)))
+	builder.WriteString(fmt.Sprintf("Value: %s\n", string2eth.WeiToString(c.fee, true)))
+	builder.WriteString(fmt.Sprintf("Note: %s", c.feeHint()))
+
+	return builder.String(), nil
+}
+
+// feeHint explains the fee supplied with the request.
+func (c *command) feeHint() string {
+	return fmt.Sprintf("the request fee varies with demand, and the request fails if the value of %s is below the fee when the transaction is processed; the current fee can be obtained by calling %s with empty data, and any value above the fee is not refunded", string2eth.WeiToString(c.fee, true), withdrawalRequestPredeployAddress)
+}
@@ -0,0 +1,211 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawalrequest
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+const (
+	// withdrawalRequestPredeployAddress is the address of the EIP-7002 withdrawal request contract.
+	withdrawalRequestPredeployAddress = "0x00000961Ef480Eb55e80D19ad83579A64c007002"
+	// minWithdrawalRequestFee is the minimum fee, in wei, for a withdrawal request.
+	minWithdrawalRequestFee = 1
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	c.validatorInfo, err = util.ParseValidator(ctx, c.consensusClient.(consensusclient.ValidatorsProvider), c.validator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validator")
+	}
+	c.currentEpoch = c.chainTime.CurrentEpoch()
+
+	if err := c.fetchPendingWithdrawals(ctx); err != nil {
+		return err
+	}
+
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Validator %d has balance %d and pending withdrawals of %d\n", c.validatorInfo.Index, c.validatorInfo.Balance, c.pendingWithdrawals)
+	}
+
+	return c.checkRequest()
+}
+
+// checkRequest checks that the request will be acted upon by the
+// consensus layer, given the current state of the validator.
+func (c *command) checkRequest() error {
+	validator := c.validatorInfo.Validator
+
+	switch validator.WithdrawalCredentials[0] {
+	case util.EthWithdrawalPrefix, util.CompoundingWithdrawalPrefix:
+	default:
+		return util.ErrorWithCode(errors.New("validator does not have execution withdrawal credentials"),
+			util.ErrorCodeWrongCredentialsType,
+			map[string]any{"index": c.validatorInfo.Index},
+		)
+	}
+	if !bytes.Equal(validator.WithdrawalCredentials[12:], c.withdrawalAddress[:]) {
+		return fmt.Errorf("validator withdrawal address %s does not match supplied address %s",
+			util.AddressBytesToEIP55(validator.WithdrawalCredentials[12:]),
+			util.AddressBytesToEIP55(c.withdrawalAddress[:]),
+		)
+	}
+
+	// A withdrawal request for the full balance is an exit.
+	ineligibleCode := util.ErrorCodeGeneral
+	if c.amount == 0 {
+		ineligibleCode = util.ErrorCodeNotEligibleToExit
+	}
+	if c.validatorInfo.Status != apiv1.ValidatorStateActiveOngoing {
+		return util.ErrorWithCode(fmt.Errorf("validator is in state %v, not suitable to generate a withdrawal request", c.validatorInfo.Status),
+			ineligibleCode,
+			map[string]any{"index": c.validatorInfo.Index, "state": c.validatorInfo.Status.String()},
+		)
+	}
+	if c.currentEpoch < validator.ActivationEpoch+c.shardCommitteePeriod {
+		return util.ErrorWithCode(fmt.Errorf("validator cannot generate a withdrawal request until epoch %d", validator.ActivationEpoch+c.shardCommitteePeriod),
+			ineligibleCode,
+			map[string]any{"index": c.validatorInfo.Index, "eligible_epoch": validator.ActivationEpoch + c.shardCommitteePeriod},
+		)
+	}
+
+	if c.amount == 0 {
+		// Full exit.
+		if c.pendingWithdrawals != 0 {
+			return util.ErrorWithCode(errors.New("validator has pending partial withdrawals; a full exit would be ignored until they have completed"),
+				util.ErrorCodeNotEligibleToExit,
+				map[string]any{"index": c.validatorInfo.Index},
+			)
+		}
+
+		return nil
+	}
+
+	// Partial withdrawal.
+	if validator.WithdrawalCredentials[0] != util.CompoundingWithdrawalPrefix {
+		return util.ErrorWithCode(errors.New("partial withdrawals require compounding (0x02) withdrawal credentials"),
+			util.ErrorCodeWrongCredentialsType,
+			map[string]any{"index": c.validatorInfo.Index},
+		)
+	}
+	if validator.EffectiveBalance < c.minActivationBalance {
+		return errors.New("validator effective balance is below the minimum activation balance")
+	}
+	if c.validatorInfo.Balance <= c.minActivationBalance+c.pendingWithdrawals {
+		return errors.New("validator has no excess balance to withdraw")
+	}
+	excess := c.validatorInfo.Balance - c.minActivationBalance - c.pendingWithdrawals
+	if c.amount > excess {
+		return fmt.Errorf("amount %s is more than the withdrawable balance of %s",
+			string2eth.GWeiToString(uint64(c.amount), true),
+			string2eth.GWeiToString(uint64(excess), true),
+		)
+	}
+
+	return nil
+}
+
+func (c *command) fetchPendingWithdrawals(ctx context.Context) error {
+	provider, isProvider := c.consensusClient.(consensusclient.PendingPartialWithdrawalsProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide pending partial withdrawals")
+	}
+
+	response, err := provider.PendingPartialWithdrawals(ctx, &api.PendingPartialWithdrawalsOpts{
+		State: "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain pending partial withdrawals")
+	}
+
+	c.pendingWithdrawals = 0
+	for _, withdrawal := range response.Data {
+		if withdrawal.ValidatorIndex == c.validatorInfo.Index {
+			c.pendingWithdrawals += withdrawal.Amount
+		}
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	// Connect to the consensus node.
+	var err error
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisProvider(c.consensusClient.(consensusclient.GenesisProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	specResponse, err := c.consensusClient.(consensusclient.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+
+	if val, exists := specResponse.Data["MIN_ACTIVATION_BALANCE"]; !exists {
+		c.minActivationBalance = 32000000000
+	} else {
+		c.minActivationBalance = phase0.Gwei(val.(uint64))
+	}
+
+	if val, exists := specResponse.Data["SHARD_COMMITTEE_PERIOD"]; !exists {
+		c.shardCommitteePeriod = 256
+	} else {
+		c.shardCommitteePeriod = phase0.Epoch(val.(uint64))
+	}
+
+	return nil
+}
+
+// withdrawalRequestCalldata generates the calldata for a withdrawal request,
+// which is the validator's public key followed by the amount as a big-endian
+// uint64.
+func withdrawalRequestCalldata(pubkey phase0.BLSPubKey, amount phase0.Gwei) []byte {
+	data := make([]byte, 0, phase0.PublicKeyLength+8)
+	data = append(data, pubkey[:]...)
+	data = binary.BigEndian.AppendUint64(data, uint64(amount))
+
+	return data
+}
@@ -0,0 +1,219 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawalrequest
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func withdrawalCredentials(t *testing.T, prefix byte, address string) []byte {
+	t.Helper()
+
+	addressBytes, err := hex.DecodeString(strings.TrimPrefix(address, "0x"))
+	require.NoError(t, err)
+	credentials := make([]byte, 32)
+	credentials[0] = prefix
+	copy(credentials[12:], addressBytes)
+
+	return credentials
+}
+
+func TestCheckRequest(t *testing.T) {
+	address := bellatrix.ExecutionAddress{0x8c, 0x1f, 0xf9, 0x78, 0x03, 0x6f, 0x2e, 0x9d, 0x7c, 0xc3, 0x82, 0xef, 0xf7, 0xb4, 0xc8, 0xc5, 0x3c, 0x22, 0xac, 0x15}
+
+	tests := []struct {
+		name               string
+		credentials        []byte
+		state              apiv1.ValidatorState
+		activationEpoch    phase0.Epoch
+		balance            phase0.Gwei
+		effectiveBalance   phase0.Gwei
+		pendingWithdrawals phase0.Gwei
+		amount             phase0.Gwei
+		err                string
+		code               util.ErrorCode
+	}{
+		{
+			name:             "BLSCredentials",
+			credentials:      withdrawalCredentials(t, 0x00, "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15"),
+			state:            apiv1.ValidatorStateActiveOngoing,
+			balance:          32000000000,
+			effectiveBalance: 32000000000,
+			err:              "validator does not have execution withdrawal credentials",
+			code:             util.ErrorCodeWrongCredentialsType,
+		},
+		{
+			name:             "AddressMismatch",
+			credentials:      withdrawalCredentials(t, 0x01, "0x0000000000000000000000000000000000000001"),
+			state:            apiv1.ValidatorStateActiveOngoing,
+			balance:          32000000000,
+			effectiveBalance: 32000000000,
+			err:              "validator withdrawal address 0x0000000000000000000000000000000000000001 does not match supplied address 0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+		},
+		{
+			name:             "Exiting",
+			credentials:      withdrawalCredentials(t, 0x01, "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15"),
+			state:            apiv1.ValidatorStateActiveExiting,
+			balance:          32000000000,
+			effectiveBalance: 32000000000,
+			err:              "validator is in state active_exiting, not suitable to generate a withdrawal request",
+			code:             util.ErrorCodeNotEligibleToExit,
+		},
+		{
+			name:             "TooYoung",
+			credentials:      withdrawalCredentials(t, 0x01, "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15"),
+			state:            apiv1.ValidatorStateActiveOngoing,
+			activationEpoch:  900,
+			balance:          32000000000,
+			effectiveBalance: 32000000000,
+			err:              "validator cannot generate a withdrawal request until epoch 1156",
+			code:             util.ErrorCodeNotEligibleToExit,
+		},
+		{
+			name:               "ExitPendingWithdrawals",
+			credentials:        withdrawalCredentials(t, 0x01, "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15"),
+			state:              apiv1.ValidatorStateActiveOngoing,
+			balance:            32000000000,
+			effectiveBalance:   32000000000,
+			pendingWithdrawals: 1000000000,
+			err:                "validator has pending partial withdrawals; a full exit would be ignored until they have completed",
+			code:               util.ErrorCodeNotEligibleToExit,
+		},
+		{
+			name:             "Exit",
+			credentials:      withdrawalCredentials(t, 0x01, "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15"),
+			state:            apiv1.ValidatorStateActiveOngoing,
+			balance:          32000000000,
+			effectiveBalance: 32000000000,
+		},
+		{
+			name:             "PartialNotCompounding",
+			credentials:      withdrawalCredentials(t, 0x01, "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15"),
+			state:            apiv1.ValidatorStateActiveOngoing,
+			balance:          33000000000,
+			effectiveBalance: 32000000000,
+			amount:           1000000000,
+			err:              "partial withdrawals require compounding (0x02) withdrawal credentials",
+			code:             util.ErrorCodeWrongCredentialsType,
+		},
+		{
+			name:             "PartialEffectiveBalanceLow",
+			credentials:      withdrawalCredentials(t, 0x02, "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15"),
+			state:            apiv1.ValidatorStateActiveOngoing,
+			balance:          33000000000,
+			effectiveBalance: 31000000000,
+			amount:           1000000000,
+			err:              "validator effective balance is below the minimum activation balance",
+		},
+		{
+			name:               "PartialNoExcess",
+			credentials:        withdrawalCredentials(t, 0x02, "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15"),
+			state:              apiv1.ValidatorStateActiveOngoing,
+			balance:            33000000000,
+			effectiveBalance:   33000000000,
+			pendingWithdrawals: 1000000000,
+			amount:             1000000000,
+			err:                "validator has no excess balance to withdraw",
+		},
+		{
+			name:             "PartialTooLarge",
+			credentials:      withdrawalCredentials(t, 0x02, "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15"),
+			state:            apiv1.ValidatorStateActiveOngoing,
+			balance:          33000000000,
+			effectiveBalance: 33000000000,
+			amount:           2000000000,
+			err:              "amount 2 Ether is more than the withdrawable balance of 1 Ether",
+		},
+		{
+			name:             "Partial",
+			credentials:      withdrawalCredentials(t, 0x02, "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15"),
+			state:            apiv1.ValidatorStateActiveOngoing,
+			balance:          33000000000,
+			effectiveBalance: 33000000000,
+			amount:           1000000000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				withdrawalAddress:    address,
+				amount:               test.amount,
+				minActivationBalance: 32000000000,
+				shardCommitteePeriod: 256,
+				pendingWithdrawals:   test.pendingWithdrawals,
+				currentEpoch:         1000,
+				validatorInfo: &apiv1.Validator{
+					Index:   1,
+					Balance: test.balance,
+					Status:  test.state,
+					Validator: &phase0.Validator{
+						WithdrawalCredentials: test.credentials,
+						EffectiveBalance:      test.effectiveBalance,
+						ActivationEpoch:       test.activationEpoch,
+					},
+				},
+			}
+			err := c.checkRequest()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				if test.code != "" {
+					require.Equal(t, test.code, util.ErrorCodeOf(err))
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWithdrawalRequestCalldata(t *testing.T) {
+	pubkey := phase0.BLSPubKey{}
+	for i := range pubkey {
+		pubkey[i] = byte(i)
+	}
+
+	tests := []struct {
+		name   string
+		amount phase0.Gwei
+		res    string
+	}{
+		{
+			name:   "Exit",
+			amount: 0,
+			res:    "0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f0000000000000000",
+		},
+		{
+			name:   "Partial",
+			amount: 1000000000,
+			res:    "0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f000000003b9aca00",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := withdrawalRequestCalldata(pubkey, test.amount)
+			require.Len(t, res, 56)
+			require.Equal(t, test.res, "0x"+hex.EncodeToString(res))
+		})
+	}
+}
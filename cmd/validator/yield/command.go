@@ -21,13 +21,15 @@ import (
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type command struct {
-	quiet   bool
-	verbose bool
-	debug   bool
-	json    bool
+	quiet    bool
+	verbose  bool
+	debug    bool
+	format   string
+	template string
 
 	// Beacon node connection.
 	timeout                  time.Duration
@@ -60,12 +62,13 @@ type output struct {
 
 func newCommand(_ context.Context) (*command, error) {
 	c := &command{
-		quiet:   viper.GetBool("quiet"),
-		verbose: viper.GetBool("verbose"),
-		debug:   viper.GetBool("debug"),
-		json:    viper.GetBool("json"),
-		epoch:   viper.GetString("epoch"),
-		results: &output{},
+		quiet:    viper.GetBool("quiet"),
+		verbose:  viper.GetBool("verbose"),
+		debug:    viper.GetBool("debug"),
+		format:   util.OutputFormat(),
+		template: viper.GetString("template"),
+		epoch:    viper.GetString("epoch"),
+		results:  &output{},
 	}
 
 	// Timeout.
@@ -19,22 +19,32 @@ import (
 	"strings"
 
 	"github.com/shopspring/decimal"
+	"github.com/wealdtech/ethdo/util"
 	"github.com/wealdtech/go-string2eth"
 )
 
-func (c *command) output(_ context.Context) (string, error) {
+func (c *command) output(ctx context.Context) (string, error) {
 	if c.quiet {
 		return "", nil
 	}
 
-	if c.json {
-		data, err := json.Marshal(c.results)
-		if err != nil {
-			return "", err
-		}
-		return string(data), nil
+	return util.FormatOutput(ctx, &util.FormatOpts{
+		Format:   c.format,
+		Template: c.template,
+		JSON:     c.outputJSON,
+		Text:     c.outputTxt,
+	})
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
 	}
+	return string(data), nil
+}
 
+func (c *command) outputTxt(_ context.Context) (string, error) {
 	builder := strings.Builder{}
 
 	if c.verbose {
@@ -0,0 +1,77 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorconsolidate "github.com/wealdtech/ethdo/cmd/validator/consolidate"
+)
+
+var validatorConsolidateCmd = &cobra.Command{
+	Use:   "consolidate",
+	Short: "Generate execution layer consolidation requests for validators",
+	Long: `Generate execution layer consolidation requests for validators, as per EIP-7251.  For example:
+
+    ethdo validator consolidate --source-validator=12345 --target-validator=23456
+
+The source validator's balance is moved to the target validator, and the source validator exits.  If the source and target validators are the same then a request to switch the validator to compounding withdrawal credentials is generated.  If the target validator does not yet have compounding withdrawal credentials then only a request to switch it is generated; once that request has been included in a block the command should be run again to generate the consolidation request, as a consolidation processed before the switch would fail.
+
+The requests are not sent by this command.  Instead, the details of the transactions are output, and the transactions should be signed and sent from the validators' withdrawal address.
+
+In quiet mode this will return 0 if the requests are valid for the validators, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorconsolidate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorConsolidateCmd)
+	validatorFlags(validatorConsolidateCmd)
+	validatorConsolidateCmd.Flags().Bool("prepare-offline", false, "Create files for offline use")
+	validatorConsolidateCmd.Flags().Bool("offline", false, "Do not attempt to connect to a beacon node to obtain information for the requests")
+	validatorConsolidateCmd.Flags().String("source-validator", "", "Validator to consolidate in to the target validator")
+	validatorConsolidateCmd.Flags().String("target-validator", "", "Validator in to which to consolidate the source validator")
+	validatorConsolidateCmd.Flags().String("fee", "", "Fee to send with each request (defaults to the minimum fee)")
+}
+
+func validatorConsolidateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("prepare-offline", cmd.Flags().Lookup("prepare-offline")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("offline", cmd.Flags().Lookup("offline")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("source-validator", cmd.Flags().Lookup("source-validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("target-validator", cmd.Flags().Lookup("target-validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("fee", cmd.Flags().Lookup("fee")); err != nil {
+		panic(err)
+	}
+}
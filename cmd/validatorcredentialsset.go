@@ -39,7 +39,7 @@ The validator account can be specified in one of a number of ways:
   - validator and existing BLS withdrawal private key using --validator and --private-key; this will generate a single operation
   - account and existing BLS withdrawal account using --account and --withdrawal-account; this will generate a single operation
 
-In quiet mode this will return 0 if the credentials operation has been generated (and successfully broadcast if online), otherwise 1.`,
+In quiet mode this will return 0 if the credentials operation has been generated (and successfully broadcast if online), otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := validatorcredentialsset.Run(cmd)
 		if err != nil {
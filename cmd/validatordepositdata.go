@@ -30,9 +30,13 @@ var validatorDepositDataCmd = &cobra.Command{
 
 If validatoraccount is provided with an account path it will generate deposit data for all matching accounts.
 
+With --topup the deposit data is for a top-up of an existing validator.  The validator is looked up on chain, and its existing withdrawal credentials are used.  For example:
+
+    ethdo validator depositdata --validatoraccount=primary/validator --depositvalue="100 Ether" --topup --raw
+
 The information generated can be passed to ethereal to create a deposit from the Ethereum 1 chain.
 
-In quiet mode this will return 0 if the data can be generated correctly, otherwise 1.`,
+In quiet mode this will return 0 if the data can be generated correctly, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := validatordepositdata.Run(cmd)
 		if err != nil {
@@ -55,9 +59,11 @@ func init() {
 	validatorDepositDataCmd.Flags().String("withdrawaladdress", "", "Ethereum 1 address of the account to which the validator funds will be withdrawn")
 	validatorDepositDataCmd.Flags().String("depositvalue", "", "Value of the amount to be deposited")
 	validatorDepositDataCmd.Flags().Bool("raw", false, "Print raw deposit data transaction data")
-	validatorDepositDataCmd.Flags().String("forkversion", "", "Use a hard-coded fork version (default is to use mainnet value)")
+	validatorDepositDataCmd.Flags().String("forkversion", "", "Use a hard-coded fork version (default is to use the value from --network or --network-config if supplied, otherwise mainnet)")
 	validatorDepositDataCmd.Flags().Bool("launchpad", false, "Print launchpad-compatible JSON")
 	validatorDepositDataCmd.Flags().Bool("compounding", false, "Create a compounding (max 2048 ETH) validator")
+	validatorDepositDataCmd.Flags().Bool("topup", false, "Create a top-up deposit for an existing validator")
+	validatorDepositDataCmd.Flags().Bool("allow-ineffective-topup", false, "Create a top-up deposit even if it cannot increase the validator's effective balance")
 }
 
 func validatorDepositdataBindings(cmd *cobra.Command) {
@@ -88,4 +94,10 @@ func validatorDepositdataBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("compounding", cmd.Flags().Lookup("compounding")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("topup", cmd.Flags().Lookup("topup")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("allow-ineffective-topup", cmd.Flags().Lookup("allow-ineffective-topup")); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,69 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatordiscover "github.com/wealdtech/ethdo/cmd/validator/discover"
+)
+
+var validatorDiscoverCmd = &cobra.Command{
+	Use:   "discover",
+	Short: "Discover the validators derived from a mnemonic",
+	Long: `Discover the validators derived from a mnemonic.  For example:
+
+    ethdo validator discover --mnemonic="..." --start-index=0 --count=1024
+
+Validator keys are derived using the EIP-2334 path m/12381/3600/i/0/0 for each index in the range, and every validator found on chain is listed.  Unlike other commands, scanning does not stop at the first gap in the indices.
+
+For validators with BLS (0x00) withdrawal credentials this also reports if the withdrawal key derived at m/12381/3600/i/0 matches the credentials.
+
+In quiet mode this will return 0 if the scan completes, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatordiscover.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorDiscoverCmd)
+	validatorFlags(validatorDiscoverCmd)
+	validatorDiscoverCmd.Flags().Uint64("start-index", 0, "The first index from which to derive keys")
+	validatorDiscoverCmd.Flags().Uint64("count", 1024, "The number of indices from which to derive keys")
+	validatorDiscoverCmd.Flags().Int("workers", 0, "The number of workers deriving keys in parallel (defaults to the number of CPUs)")
+}
+
+func validatorDiscoverBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("start-index", cmd.Flags().Lookup("start-index")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("count", cmd.Flags().Lookup("count")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("workers", cmd.Flags().Lookup("workers")); err != nil {
+		panic(err)
+	}
+}
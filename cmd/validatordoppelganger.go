@@ -0,0 +1,65 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatordoppelganger "github.com/wealdtech/ethdo/cmd/validator/doppelganger"
+)
+
+var validatorDoppelgangerCmd = &cobra.Command{
+	Use:   "doppelganger",
+	Short: "Check that validator(s) are not active elsewhere",
+	Long: `Check that one or more validators are not active elsewhere before starting them.  For example:
+
+    ethdo validator doppelganger --validators=Validators/1,0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b,12345
+
+Validators can be supplied as indices, ranges of indices, public keys or accounts.  The previous epoch is checked immediately, and then each of the following --epochs epochs once it has completed.  Activity is obtained from the beacon node's validator liveness endpoint if available, otherwise from the attestations and proposals in blocks; as attestations for an epoch can be included in blocks up to the end of the following epoch, each epoch is then only checked once the following epoch has completed.
+
+Validators that were stopped elsewhere during the previous epoch will show activity in that epoch.  In this situation wait until the epoch after the validators were stopped, and run with --ignore-previous-epoch to start with the current epoch.
+
+In quiet mode this will return 0 if no activity is found, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatordoppelganger.Run(cmd)
+		// The results are output even if there is an error, as they explain the error.
+		if res != "" && !viper.GetBool("quiet") {
+			fmt.Println(res)
+		}
+		return err
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorDoppelgangerCmd)
+	validatorFlags(validatorDoppelgangerCmd)
+	validatorDoppelgangerCmd.Flags().StringSlice("validators", nil, "the list of validators to check")
+	validatorDoppelgangerCmd.Flags().Uint64("epochs", 2, "the number of epochs to watch")
+	validatorDoppelgangerCmd.Flags().Bool("ignore-previous-epoch", false, "do not check the previous epoch, for validators stopped elsewhere during it")
+}
+
+func validatorDoppelgangerBindings(cmd *cobra.Command) {
+	validatorBindings()
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epochs", cmd.Flags().Lookup("epochs")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("ignore-previous-epoch", cmd.Flags().Lookup("ignore-previous-epoch")); err != nil {
+		panic(err)
+	}
+}
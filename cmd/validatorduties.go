@@ -30,7 +30,7 @@ var validatorDutiesCmd = &cobra.Command{
 
 Attester duties are known for the current and next epoch.  Proposer duties are known for the current epoch.
 
-In quiet mode this will return 0 if the duties have been obtained, otherwise 1.`,
+In quiet mode this will return 0 if the duties have been obtained, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := validatorduties.Run(cmd)
 		if err != nil {
@@ -1,4 +1,4 @@
-// Copyright © 2020, 2023 Weald Technology Trading
+// Copyright © 2020, 2023, 2025 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -37,20 +37,17 @@ The validator and key can be specified in one of a number of ways:
   - mnemonic and validator index or public key using --mnemonic and --validator
   - validator private key using --private-key
   - validator account using --validator
+  - a file containing one validator index, public key or account per line using --validators-file, optionally with --mnemonic; this will generate an operation for each validator
 
-In quiet mode this will return 0 if the exit operation has been generated (and successfully broadcast if online), otherwise 1.`,
+When exiting multiple validators, broadcasts can be staggered using --churn-share so that each epoch only uses the given percentage of the chain's exit churn.  A report of the projected exit epoch for each validator is provided when using --validators-file or --verbose, or if any validator fails.  With --validators-file the JSON output, and the exit-operations.json file generated in offline mode, contain the result for each validator including any failure; either can be supplied to --signed-operations.
+
+In quiet mode this will return 0 if the exit operations have been generated (and successfully broadcast if online) for all validators, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := validatorexit.Run(cmd)
-		if err != nil {
-			return err
-		}
-		if viper.GetBool("quiet") {
-			return nil
-		}
-		if res != "" {
+		if res != "" && !viper.GetBool("quiet") {
 			fmt.Println(res)
 		}
-		return nil
+		return err
 	},
 }
 
@@ -60,6 +57,8 @@ func init() {
 	validatorExitCmd.Flags().String("epoch", "", "Epoch at which to exit (defaults to current epoch)")
 	validatorExitCmd.Flags().Bool("prepare-offline", false, "Create files for offline use")
 	validatorExitCmd.Flags().String("validator", "", "Validator to exit")
+	validatorExitCmd.Flags().String("validators-file", "", "File containing validators to exit, one per line")
+	validatorExitCmd.Flags().Uint64("churn-share", 0, "Maximum percentage of the exit churn to use per epoch when broadcasting (0 for no limit)")
 	validatorExitCmd.Flags().String("signed-operations", "", "Use pre-defined JSON signed operation as created by --json to transmit the exit operations (reads from exit-operations.json if not present)")
 	validatorExitCmd.Flags().Bool("offline", false, "Do not attempt to connect to a beacon node to obtain information for the operation")
 	validatorExitCmd.Flags().String("fork-version", "", "Fork version to use for signing (overrides fetching from beacon node)")
@@ -77,6 +76,12 @@ func validatorExitBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("validators-file", cmd.Flags().Lookup("validators-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("churn-share", cmd.Flags().Lookup("churn-share")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("signed-operations", cmd.Flags().Lookup("signed-operations")); err != nil {
 		panic(err)
 	}
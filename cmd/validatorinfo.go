@@ -42,7 +42,7 @@ var validatorInfoCmd = &cobra.Command{
 
     ethdo validator info --validator=primary/validator
 
-In quiet mode this will return 0 if the validator information can be obtained, otherwise 1.`,
+In quiet mode this will return 0 if the validator information can be obtained, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx := context.Background()
 
@@ -62,18 +62,26 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 			errCheck(err, "failed to set up chaintime service")
 		}
 
-		if viper.GetString("validator") == "" {
-			fmt.Println("validator is required")
-			os.Exit(_exitFailure)
-		}
+		assertInput(viper.GetString("validator") != "", "validator is required")
 
 		validator, err := util.ParseValidator(ctx, eth2Client.(eth2client.ValidatorsProvider), viper.GetString("validator"), viper.GetString("blockid"))
 		errCheck(err, "Failed to obtain validator")
 
-		if viper.GetBool("json") {
-			data, err := json.Marshal(validator)
-			errCheck(err, "failed to marshal JSON")
-			fmt.Fprintf(os.Stdout, "%s", string(data))
+		if util.OutputFormat() != util.OutputFormatText || viper.GetString("template") != "" {
+			res, err := util.FormatOutput(ctx, &util.FormatOpts{
+				Format:   util.OutputFormat(),
+				Template: viper.GetString("template"),
+				JSON: func(_ context.Context) (string, error) {
+					data, err := json.Marshal(validator)
+					if err != nil {
+						return "", errors.Wrap(err, "failed to marshal JSON")
+					}
+
+					return string(data), nil
+				},
+			})
+			errCheck(err, "failed to generate output")
+			fmt.Fprintf(os.Stdout, "%s", res)
 
 			return
 		}
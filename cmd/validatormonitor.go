@@ -0,0 +1,72 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatormonitor "github.com/wealdtech/ethdo/cmd/validator/monitor"
+)
+
+var validatorMonitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "Monitor validator(s) for missed duties",
+	Long: `Continuously monitor one or more validators for missed or late attestations, missed proposals and missed sync committee contributions.  For example:
+
+    ethdo validator monitor --validators=1,2,3 --webhook=https://alerts.example.com/ethdo
+
+Each epoch is evaluated once all of its attestations could have been included, and faults are output as newline-delimited JSON.  If a webhook is supplied the faults for each epoch are also sent to it as an HTTP POST.
+
+With --exit-on-problem the command will exit with a non-zero code as soon as a fault is found.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatormonitor.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorMonitorCmd)
+	validatorFlags(validatorMonitorCmd)
+	validatorMonitorCmd.Flags().StringSlice("validators", nil, "the list of validators to monitor")
+	validatorMonitorCmd.Flags().String("webhook", "", "URL to which to POST faults")
+	validatorMonitorCmd.Flags().Bool("finalized", false, "evaluate epochs only once they are finalized")
+	validatorMonitorCmd.Flags().Bool("exit-on-problem", false, "exit with a non-zero code when a fault is found")
+}
+
+func validatorMonitorBindings(cmd *cobra.Command) {
+	validatorBindings()
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("webhook", cmd.Flags().Lookup("webhook")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("finalized", cmd.Flags().Lookup("finalized")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("exit-on-problem", cmd.Flags().Lookup("exit-on-problem")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatoroperationstatus "github.com/wealdtech/ethdo/cmd/validator/operation/status"
+)
+
+var validatorOperationStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Obtain the status of submitted validator operations",
+	Long: `Obtain the status of submitted exit and credential change operations.  For example:
+
+    ethdo validator operation status --operations=exit-operations.json
+
+If no operations are supplied they are read from exit-operations.json or change-operations.json in the current directory.
+
+Each operation is reported as in the pool, included in a block, applied to the validator's state, superseded, invalid or not found.  Use --wait to wait until all operations have been included, up to the supplied duration.
+
+In quiet mode this will return 0 if all operations have been included, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatoroperationstatus.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			fmt.Println(res)
+		}
+		return err
+	},
+}
+
+func init() {
+	validatorOperationCmd.AddCommand(validatorOperationStatusCmd)
+	validatorOperationFlags(validatorOperationStatusCmd)
+	validatorOperationStatusCmd.Flags().String("operations", "", "Signed operations as created by --json or --offline, either as JSON or the name of a file")
+	validatorOperationStatusCmd.Flags().Duration("wait", 0, "Time to wait for all operations to be included (e.g. 10m)")
+	validatorOperationStatusCmd.Flags().Uint64("search-slots", 64, "Number of recent slots to search for included operations")
+}
+
+func validatorOperationStatusBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("operations", cmd.Flags().Lookup("operations")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("wait", cmd.Flags().Lookup("wait")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("search-slots", cmd.Flags().Lookup("search-slots")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorqueueposition "github.com/wealdtech/ethdo/cmd/validator/queueposition"
+)
+
+var validatorQueuePositionCmd = &cobra.Command{
+	Use:   "queue-position",
+	Short: "Estimate when a validator's pending operations will be processed",
+	Long: `Estimate when a validator's pending deposits, activation, exit and consolidations will be processed.  For example:
+
+    ethdo validator queue-position --validator=primary/validator
+
+For an active validator that is not exiting this also estimates when the validator would exit if an exit were requested now.
+
+Estimates are based on the current state of the chain and its churn limits, and will change as other operations enter the queues.
+
+In quiet mode this will return 0 if the validator has been found, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorqueueposition.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorQueuePositionCmd)
+	validatorFlags(validatorQueuePositionCmd)
+	validatorQueuePositionCmd.Flags().String("validator", "", "Validator for which to estimate queue positions")
+}
+
+func validatorQueuePositionBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+}
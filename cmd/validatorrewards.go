@@ -0,0 +1,72 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorrewards "github.com/wealdtech/ethdo/cmd/validator/rewards"
+)
+
+var validatorRewardsCmd = &cobra.Command{
+	Use:   "rewards",
+	Short: "Obtain a breakdown of rewards for validator(s) over a range of epochs",
+	Long: `Obtain a breakdown of rewards and penalties for one or more validators over a range of epochs.  For example:
+
+    ethdo validator rewards --validators=1,2,3 --from-epoch=12340 --to-epoch=12345
+
+If no epochs are supplied the rewards are for the latest epoch for which they are available.
+
+In quiet mode this will return 0 if rewards for the epochs are obtained, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorrewards.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorRewardsCmd)
+	validatorFlags(validatorRewardsCmd)
+	validatorRewardsCmd.Flags().StringSlice("validators", nil, "the list of validators for which to obtain rewards")
+	validatorRewardsCmd.Flags().String("from-epoch", "", "the first epoch for which to obtain rewards (defaults to the to epoch)")
+	validatorRewardsCmd.Flags().String("to-epoch", "", "the last epoch for which to obtain rewards (defaults to the latest available epoch)")
+	validatorRewardsCmd.Flags().Bool("csv", false, "output per-epoch rewards as CSV")
+}
+
+func validatorRewardsBindings(cmd *cobra.Command) {
+	validatorBindings()
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", cmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
+}
@@ -28,7 +28,11 @@ var validatorSummaryCmd = &cobra.Command{
 
     ethdo validator summary --validators=1,2,3 --epoch=12345
 
-In quiet mode this will return 0 if information for the epoch is found, otherwise 1.`,
+A range of epochs can be summarised with --from-epoch and --to-epoch, or with --since to cover a period of time up to the last complete epoch.  For example:
+
+    ethdo validator summary --validators=1,2,3 --since=7d
+
+In quiet mode this will return 0 if information for the epoch is found, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := validatorsummary.Run(cmd)
 		if err != nil {
@@ -49,6 +53,11 @@ func init() {
 	validatorFlags(validatorSummaryCmd)
 	validatorSummaryCmd.Flags().String("epoch", "", "the epoch for which to obtain information ()")
 	validatorSummaryCmd.Flags().StringSlice("validators", nil, "the list of validators for which to obtain information")
+	validatorSummaryCmd.Flags().String("from-epoch", "", "the first epoch of a range to summarise")
+	validatorSummaryCmd.Flags().String("to-epoch", "", "the last epoch of a range to summarise (defaults to the last complete epoch)")
+	validatorSummaryCmd.Flags().String("since", "", "the period of time to summarise, for example 12h or 7d")
+	validatorSummaryCmd.Flags().Int("workers", 0, "the number of epochs in a range to process in parallel (defaults to 8)")
+	validatorSummaryCmd.Flags().Bool("csv", false, "output per-epoch summaries as CSV")
 }
 
 func validatorSummaryBindings(cmd *cobra.Command) {
@@ -59,4 +68,19 @@ func validatorSummaryBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("since", cmd.Flags().Lookup("since")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("workers", cmd.Flags().Lookup("workers")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", cmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
 }
@@ -1,4 +1,4 @@
-// Copyright © 2023 Weald Technology Trading
+// Copyright © 2023, 2025 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -28,7 +28,9 @@ var validatorWithdrawalCmd = &cobra.Command{
 
     ethdo validator withdrawal --validator=primary/validator
 
-In quiet mode this will return 0 if the validator exists, otherwise 1.`,
+This takes into account both execution (0x01) and compounding (0x02) withdrawal credentials, and pending partial withdrawals requested from the execution layer.
+
+In quiet mode this will return 0 if the validator exists, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := validatorwithdrawal.Run(cmd)
 		if err != nil {
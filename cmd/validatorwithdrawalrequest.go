@@ -0,0 +1,75 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorwithdrawalrequest "github.com/wealdtech/ethdo/cmd/validator/withdrawalrequest"
+)
+
+var validatorWithdrawalRequestCmd = &cobra.Command{
+	Use:   "withdrawal-request",
+	Short: "Generate an execution layer withdrawal request for a validator",
+	Long: `Generate an execution layer withdrawal request for a validator, as per EIP-7002.  For example:
+
+    ethdo validator withdrawal-request --validator=primary/validator --withdrawal-address=0x00...13 --amount="1 Ether" --fee="1 gwei"
+
+An amount of 0 requests a full exit of the validator.  Partial withdrawals require the validator to have compounding (0x02) withdrawal credentials.
+
+The fee varies with demand, and must be supplied.  The current fee can be obtained by calling the withdrawal request contract with empty data.
+
+The request is not sent by this command.  Instead, the details of the transaction are output, and the transaction should be signed and sent from the validator's withdrawal address.
+
+In quiet mode this will return 0 if the request is valid for the validator, otherwise non-zero.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorwithdrawalrequest.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorWithdrawalRequestCmd)
+	validatorFlags(validatorWithdrawalRequestCmd)
+	validatorWithdrawalRequestCmd.Flags().String("validator", "", "Validator for which to generate the withdrawal request")
+	validatorWithdrawalRequestCmd.Flags().String("withdrawal-address", "", "Execution address of the validator's withdrawal credentials")
+	validatorWithdrawalRequestCmd.Flags().String("amount", "", "Amount to withdraw (e.g. \"1 Ether\"); 0 for a full exit")
+	validatorWithdrawalRequestCmd.Flags().String("fee", "", "Fee to send with the request; this should be the current fee of the withdrawal request contract")
+}
+
+func validatorWithdrawalRequestBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawal-address", cmd.Flags().Lookup("withdrawal-address")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("amount", cmd.Flags().Lookup("amount")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("fee", cmd.Flags().Lookup("fee")); err != nil {
+		panic(err)
+	}
+}
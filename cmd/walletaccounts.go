@@ -33,12 +33,12 @@ var walletAccountsCmd = &cobra.Command{
 
     ethdo wallet accounts --wallet=primary
 
-In quiet mode this will return 0 if the wallet holds any addresses, otherwise 1.`,
+In quiet mode this will return 0 if the wallet holds any addresses, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 		defer cancel()
 
-		assert(viper.GetString("wallet") != "", "wallet is required")
+		assertInput(viper.GetString("wallet") != "", "wallet is required")
 
 		wallet, err := walletFromInput(ctx)
 		errCheck(err, "Failed to obtain wallet")
@@ -28,7 +28,7 @@ var walletCreateCmd = &cobra.Command{
 
     ethdo wallet create --wallet="Primary wallet" --type=non-deterministic
 
-In quiet mode this will return 0 if the wallet is created successfully, otherwise 1.`,
+In quiet mode this will return 0 if the wallet is created successfully, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := walletcreate.Run(cmd)
 		if err != nil {
@@ -27,7 +27,7 @@ var walletExportCmd = &cobra.Command{
 
     ethdo wallet export --wallet=primary --passphrase="my export secret"
 
-In quiet mode this will return 0 if the wallet is able to be exported, otherwise 1.`,
+In quiet mode this will return 0 if the wallet is able to be exported, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := walletexport.Run(cmd)
 		if err != nil {
@@ -31,13 +31,13 @@ var walletInfoCmd = &cobra.Command{
 
     ethdo wallet info --wallet=primary
 
-In quiet mode this will return 0 if the wallet exists, otherwise 1.`,
+In quiet mode this will return 0 if the wallet exists, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 		defer cancel()
 
-		assert(viper.GetString("remote") == "", "wallet info not available with remote wallets")
-		assert(viper.GetString("wallet") != "", "--wallet is required")
+		assertInput(viper.GetString("remote") == "", "wallet info not available with remote wallets")
+		assertInput(viper.GetString("wallet") != "", "--wallet is required")
 
 		wallet, err := walletFromPath(ctx, viper.GetString("wallet"))
 		errCheck(err, "unknown wallet")
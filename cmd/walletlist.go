@@ -29,10 +29,10 @@ var walletListCmd = &cobra.Command{
 
     ethdo wallet list
 
-In quiet mode this will return 0 if any wallets are found, otherwise 1.`,
+In quiet mode this will return 0 if any wallets are found, otherwise non-zero.`,
 	Run: func(_ *cobra.Command, _ []string) {
-		assert(viper.GetString("remote") == "", "wallet list not available with remote wallets")
-		assert(viper.GetString("wallet") == "", "wallet list does not take a --wallet parameter")
+		assertInput(viper.GetString("remote") == "", "wallet list not available with remote wallets")
+		assertInput(viper.GetString("wallet") == "", "wallet list does not take a --wallet parameter")
 
 		walletsFound := false
 		for w := range e2wallet.Wallets() {
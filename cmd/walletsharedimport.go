@@ -28,7 +28,7 @@ var walletSharedImportCmd = &cobra.Command{
 
 	ethdo wallet sharedimport --file=backup.dat --shares="1234 2345 3456"
 
-In quiet mode this will return 0 if the wallet is imported successfully, otherwise 1.`,
+In quiet mode this will return 0 if the wallet is imported successfully, otherwise non-zero.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := walletsharedimport.Run(cmd)
 		if err != nil {
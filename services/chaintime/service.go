@@ -1,4 +1,4 @@
-// Copyright © 2021 - 2023 Weald Technology Trading.
+// Copyright © 2021 - 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -19,6 +19,21 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
+// Fork provides information about a fork in the chain's schedule.
+type Fork struct {
+	// Name is the name of the fork, for example "electra" or "bpo1".
+	Name string
+	// Epoch is the epoch at which the fork takes place.
+	Epoch phase0.Epoch
+	// Version is the fork version in operation from the fork.
+	// Blob-parameter-only forks retain the version of the preceding fork.
+	Version phase0.Version
+	// BlobParametersOnly is true if the fork only changes blob parameters.
+	BlobParametersOnly bool
+	// MaxBlobsPerBlock is the maximum number of blobs per block from the fork.
+	MaxBlobsPerBlock uint64
+}
+
 // Service provides a number of functions for calculating chain-related times.
 //
 //nolint:interfacebloat
@@ -64,4 +79,12 @@ type Service interface {
 	DenebInitialEpoch() phase0.Epoch
 	// ElectraInitialEpoch provides the epoch at which the Electra hard fork takes place.
 	ElectraInitialEpoch() phase0.Epoch
+	// Forks provides the scheduled forks of the chain, ordered by epoch.
+	Forks() []*Fork
+	// ForkAtEpoch provides the fork in operation at the given epoch.
+	ForkAtEpoch(epoch phase0.Epoch) *Fork
+	// ForkVersionAtEpoch provides the fork version in operation at the given epoch.
+	ForkVersionAtEpoch(epoch phase0.Epoch) phase0.Version
+	// ForkDigestAtEpoch provides the fork digest in operation at the given epoch.
+	ForkDigestAtEpoch(epoch phase0.Epoch) phase0.ForkDigest
 }
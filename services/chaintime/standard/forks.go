@@ -0,0 +1,284 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+// farFutureEpoch is the epoch used for forks that are not scheduled.
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+// blobParameters are the blob parameters in operation from a given epoch.
+type blobParameters struct {
+	epoch            phase0.Epoch
+	maxBlobsPerBlock uint64
+}
+
+// populateForks populates the fork schedule from the chain's spec.
+// Forks are found generically from pairs of *_FORK_EPOCH and *_FORK_VERSION
+// values, so new forks do not require changes here.
+func (s *Service) populateForks(spec map[string]any, genesisForkVersion phase0.Version) error {
+	if version, isVersion := spec["GENESIS_FORK_VERSION"].(phase0.Version); isVersion {
+		genesisForkVersion = version
+	}
+
+	forks := []*chaintime.Fork{
+		{
+			Name:    "phase0",
+			Epoch:   0,
+			Version: genesisForkVersion,
+		},
+	}
+	for k, v := range spec {
+		if !strings.HasSuffix(k, "_FORK_EPOCH") {
+			continue
+		}
+		prefix := strings.TrimSuffix(k, "_FORK_EPOCH")
+		epoch, isEpoch := v.(uint64)
+		if !isEpoch {
+			return fmt.Errorf("%s of unexpected type", k)
+		}
+		version, isVersion := spec[prefix+"_FORK_VERSION"].(phase0.Version)
+		if !isVersion {
+			// Not a fork with its own version.
+			continue
+		}
+		if phase0.Epoch(epoch) == farFutureEpoch {
+			// Not scheduled.
+			continue
+		}
+		forks = append(forks, &chaintime.Fork{
+			Name:    strings.ToLower(prefix),
+			Epoch:   phase0.Epoch(epoch),
+			Version: version,
+		})
+	}
+	// Multiple forks can occur at the same epoch, in which case the order is given by their versions.
+	sort.SliceStable(forks, func(i int, j int) bool {
+		if forks[i].Epoch != forks[j].Epoch {
+			return forks[i].Epoch < forks[j].Epoch
+		}
+		if forks[i].Name == "phase0" {
+			return true
+		}
+		if forks[j].Name == "phase0" {
+			return false
+		}
+
+		return bytes.Compare(forks[i].Version[:], forks[j].Version[:]) < 0
+	})
+
+	s.altairForkEpoch = forkEpoch(forks, "altair")
+	s.bellatrixForkEpoch = forkEpoch(forks, "bellatrix")
+	s.capellaForkEpoch = forkEpoch(forks, "capella")
+	s.denebForkEpoch = forkEpoch(forks, "deneb")
+	s.electraForkEpoch = forkEpoch(forks, "electra")
+	s.fuluForkEpoch = forkEpoch(forks, "fulu")
+
+	if tmp, exists := spec["MAX_BLOBS_PER_BLOCK"]; exists {
+		maxBlobs, isUint := tmp.(uint64)
+		if !isUint {
+			return errors.New("MAX_BLOBS_PER_BLOCK of unexpected type")
+		}
+		s.maxBlobsPerBlockDeneb = maxBlobs
+	}
+	if tmp, exists := spec["MAX_BLOBS_PER_BLOCK_ELECTRA"]; exists {
+		maxBlobs, isUint := tmp.(uint64)
+		if !isUint {
+			return errors.New("MAX_BLOBS_PER_BLOCK_ELECTRA of unexpected type")
+		}
+		s.maxBlobsPerBlockElectra = maxBlobs
+	}
+
+	blobSchedule, err := parseBlobSchedule(spec)
+	if err != nil {
+		return err
+	}
+	s.blobSchedule = blobSchedule
+
+	// Blob schedule entries that do not coincide with a fork are blob-parameter-only forks.
+	bpo := 0
+	for _, entry := range blobSchedule {
+		if entry.epoch == farFutureEpoch {
+			continue
+		}
+		coincides := false
+		for _, fork := range forks {
+			if fork.Epoch == entry.epoch {
+				coincides = true
+				break
+			}
+		}
+		if coincides {
+			continue
+		}
+		bpo++
+		forks = append(forks, &chaintime.Fork{
+			Name:               fmt.Sprintf("bpo%d", bpo),
+			Epoch:              entry.epoch,
+			BlobParametersOnly: true,
+		})
+	}
+	sort.SliceStable(forks, func(i int, j int) bool {
+		return forks[i].Epoch < forks[j].Epoch
+	})
+
+	for i, fork := range forks {
+		if fork.BlobParametersOnly {
+			fork.Version = forks[i-1].Version
+		}
+		fork.MaxBlobsPerBlock = s.maxBlobsPerBlock(fork.Epoch)
+	}
+	s.forks = forks
+
+	return nil
+}
+
+// parseBlobSchedule parses the blob schedule from the chain's spec, ordered by epoch.
+func parseBlobSchedule(spec map[string]any) ([]*blobParameters, error) {
+	tmp, exists := spec["BLOB_SCHEDULE"]
+	if !exists {
+		return []*blobParameters{}, nil
+	}
+	entries, isArray := tmp.([]any)
+	if !isArray {
+		return nil, errors.New("BLOB_SCHEDULE of unexpected type")
+	}
+
+	res := make([]*blobParameters, 0, len(entries))
+	for _, entry := range entries {
+		entryMap, isMap := entry.(map[string]any)
+		if !isMap {
+			return nil, errors.New("BLOB_SCHEDULE entry of unexpected type")
+		}
+		epoch, isUint := entryMap["EPOCH"].(uint64)
+		if !isUint {
+			return nil, errors.New("BLOB_SCHEDULE entry EPOCH missing or of unexpected type")
+		}
+		maxBlobsPerBlock, isUint := entryMap["MAX_BLOBS_PER_BLOCK"].(uint64)
+		if !isUint {
+			return nil, errors.New("BLOB_SCHEDULE entry MAX_BLOBS_PER_BLOCK missing or of unexpected type")
+		}
+		res = append(res, &blobParameters{
+			epoch:            phase0.Epoch(epoch),
+			maxBlobsPerBlock: maxBlobsPerBlock,
+		})
+	}
+	sort.Slice(res, func(i int, j int) bool {
+		return res[i].epoch < res[j].epoch
+	})
+
+	return res, nil
+}
+
+// forkEpoch returns the epoch of the named fork, or the far future epoch if it is not scheduled.
+func forkEpoch(forks []*chaintime.Fork, name string) phase0.Epoch {
+	for _, fork := range forks {
+		if fork.Name == name {
+			return fork.Epoch
+		}
+	}
+
+	return farFutureEpoch
+}
+
+// blobParametersAtEpoch returns the blob parameters in operation at the given epoch,
+// as per get_blob_parameters in the specification.
+func (s *Service) blobParametersAtEpoch(epoch phase0.Epoch) *blobParameters {
+	for i := len(s.blobSchedule) - 1; i >= 0; i-- {
+		if epoch >= s.blobSchedule[i].epoch {
+			return s.blobSchedule[i]
+		}
+	}
+
+	return &blobParameters{
+		epoch:            s.electraForkEpoch,
+		maxBlobsPerBlock: s.maxBlobsPerBlockElectra,
+	}
+}
+
+// maxBlobsPerBlock returns the maximum number of blobs per block at the given epoch.
+func (s *Service) maxBlobsPerBlock(epoch phase0.Epoch) uint64 {
+	switch {
+	case epoch >= s.fuluForkEpoch:
+		return s.blobParametersAtEpoch(epoch).maxBlobsPerBlock
+	case epoch >= s.electraForkEpoch:
+		return s.maxBlobsPerBlockElectra
+	case epoch >= s.denebForkEpoch:
+		return s.maxBlobsPerBlockDeneb
+	default:
+		return 0
+	}
+}
+
+// Forks provides the scheduled forks of the chain, ordered by epoch.
+func (s *Service) Forks() []*chaintime.Fork {
+	return s.forks
+}
+
+// ForkAtEpoch provides the fork in operation at the given epoch.
+func (s *Service) ForkAtEpoch(epoch phase0.Epoch) *chaintime.Fork {
+	res := s.forks[0]
+	for _, fork := range s.forks {
+		if fork.Epoch > epoch {
+			break
+		}
+		res = fork
+	}
+
+	return res
+}
+
+// ForkVersionAtEpoch provides the fork version in operation at the given epoch.
+func (s *Service) ForkVersionAtEpoch(epoch phase0.Epoch) phase0.Version {
+	return s.ForkAtEpoch(epoch).Version
+}
+
+// ForkDigestAtEpoch provides the fork digest in operation at the given epoch.
+// From Fulu the digest also commits to the blob parameters, as per compute_fork_digest
+// in the specification.
+func (s *Service) ForkDigestAtEpoch(epoch phase0.Epoch) phase0.ForkDigest {
+	forkData := &phase0.ForkData{
+		CurrentVersion:        s.ForkVersionAtEpoch(epoch),
+		GenesisValidatorsRoot: s.genesisValidatorsRoot,
+	}
+	// Hash tree root of a fixed-size container cannot fail.
+	root, _ := forkData.HashTreeRoot()
+
+	if epoch >= s.fuluForkEpoch {
+		blobParameters := s.blobParametersAtEpoch(epoch)
+		data := make([]byte, 16)
+		binary.LittleEndian.PutUint64(data[0:8], uint64(blobParameters.epoch))
+		binary.LittleEndian.PutUint64(data[8:16], blobParameters.maxBlobsPerBlock)
+		hash := sha256.Sum256(data)
+		for i := range root {
+			root[i] ^= hash[i]
+		}
+	}
+
+	var res phase0.ForkDigest
+	copy(res[:], root[:4])
+
+	return res
+}
@@ -1,4 +1,4 @@
-// Copyright © 2021 - 2023 Weald Technology Trading.
+// Copyright © 2021 - 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -17,12 +17,12 @@ import (
 	"context"
 	"time"
 
-	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/ethdo/services/chaintime"
 )
 
 // Service provides chain time services.
@@ -31,11 +31,17 @@ type Service struct {
 	slotDuration                 time.Duration
 	slotsPerEpoch                uint64
 	epochsPerSyncCommitteePeriod uint64
+	genesisValidatorsRoot        phase0.Root
+	forks                        []*chaintime.Fork
+	blobSchedule                 []*blobParameters
+	maxBlobsPerBlockDeneb        uint64
+	maxBlobsPerBlockElectra      uint64
 	altairForkEpoch              phase0.Epoch
 	bellatrixForkEpoch           phase0.Epoch
 	capellaForkEpoch             phase0.Epoch
 	denebForkEpoch               phase0.Epoch
 	electraForkEpoch             phase0.Epoch
+	fuluForkEpoch                phase0.Epoch
 }
 
 // module-wide log.
@@ -89,51 +95,19 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		epochsPerSyncCommitteePeriod = tmp2
 	}
 
-	altairForkEpoch, err := fetchAltairForkEpoch(ctx, parameters.specProvider)
-	if err != nil {
-		// Set to far future epoch.
-		altairForkEpoch = 0xffffffffffffffff
-	}
-	log.Trace().Uint64("epoch", uint64(altairForkEpoch)).Msg("Obtained Altair fork epoch")
-
-	bellatrixForkEpoch, err := fetchBellatrixForkEpoch(ctx, parameters.specProvider)
-	if err != nil {
-		// Set to far future epoch.
-		bellatrixForkEpoch = 0xffffffffffffffff
-	}
-	log.Trace().Uint64("epoch", uint64(bellatrixForkEpoch)).Msg("Obtained Bellatrix fork epoch")
-
-	capellaForkEpoch, err := fetchCapellaForkEpoch(ctx, parameters.specProvider)
-	if err != nil {
-		// Set to far future epoch.
-		capellaForkEpoch = 0xffffffffffffffff
-	}
-	log.Trace().Uint64("epoch", uint64(capellaForkEpoch)).Msg("Obtained Capella fork epoch")
-
-	denebForkEpoch, err := fetchDenebForkEpoch(ctx, parameters.specProvider)
-	if err != nil {
-		// Set to far future epoch.
-		denebForkEpoch = 0xffffffffffffffff
-	}
-	log.Trace().Uint64("epoch", uint64(denebForkEpoch)).Msg("Obtained Deneb fork epoch")
-
-	electraForkEpoch, err := fetchElectraForkEpoch(ctx, parameters.specProvider)
-	if err != nil {
-		// Set to far future epoch.
-		electraForkEpoch = 0xffffffffffffffff
-	}
-	log.Trace().Uint64("epoch", uint64(electraForkEpoch)).Msg("Obtained Electra fork epoch")
-
 	s := &Service{
 		genesisTime:                  genesisResponse.Data.GenesisTime,
 		slotDuration:                 slotDuration,
 		slotsPerEpoch:                slotsPerEpoch,
 		epochsPerSyncCommitteePeriod: epochsPerSyncCommitteePeriod,
-		altairForkEpoch:              altairForkEpoch,
-		bellatrixForkEpoch:           bellatrixForkEpoch,
-		capellaForkEpoch:             capellaForkEpoch,
-		denebForkEpoch:               denebForkEpoch,
-		electraForkEpoch:             electraForkEpoch,
+		genesisValidatorsRoot:        genesisResponse.Data.GenesisValidatorsRoot,
+	}
+
+	if err := s.populateForks(specResponse.Data, genesisResponse.Data.GenesisForkVersion); err != nil {
+		return nil, err
+	}
+	for _, fork := range s.forks {
+		log.Trace().Str("fork", fork.Name).Uint64("epoch", uint64(fork.Epoch)).Msg("Obtained fork")
 	}
 
 	return s, nil
@@ -240,142 +214,22 @@ func (s *Service) AltairInitialSyncCommitteePeriod() uint64 {
 	return uint64(s.altairForkEpoch) / s.epochsPerSyncCommitteePeriod
 }
 
-func fetchAltairForkEpoch(ctx context.Context,
-	specProvider eth2client.SpecProvider,
-) (
-	phase0.Epoch,
-	error,
-) {
-	// Fetch the fork version.
-	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
-	if err != nil {
-		return 0, errors.Wrap(err, "failed to obtain spec")
-	}
-	tmp, exists := specResponse.Data["ALTAIR_FORK_EPOCH"]
-	if !exists {
-		return 0, errors.New("altair fork version not known by chain")
-	}
-	epoch, isEpoch := tmp.(uint64)
-	if !isEpoch {
-		//nolint:revive
-		return 0, errors.New("ALTAIR_FORK_EPOCH is not a uint64!")
-	}
-
-	return phase0.Epoch(epoch), nil
-}
-
 // BellatrixInitialEpoch provides the epoch at which the Bellatrix hard fork takes place.
 func (s *Service) BellatrixInitialEpoch() phase0.Epoch {
 	return s.bellatrixForkEpoch
 }
 
-func fetchBellatrixForkEpoch(ctx context.Context,
-	specProvider eth2client.SpecProvider,
-) (
-	phase0.Epoch,
-	error,
-) {
-	// Fetch the fork version.
-	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
-	if err != nil {
-		return 0, errors.Wrap(err, "failed to obtain spec")
-	}
-	tmp, exists := specResponse.Data["BELLATRIX_FORK_EPOCH"]
-	if !exists {
-		return 0, errors.New("bellatrix fork version not known by chain")
-	}
-	epoch, isEpoch := tmp.(uint64)
-	if !isEpoch {
-		//nolint:revive
-		return 0, errors.New("BELLATRIX_FORK_EPOCH is not a uint64!")
-	}
-
-	return phase0.Epoch(epoch), nil
-}
-
 // CapellaInitialEpoch provides the epoch at which the Capella hard fork takes place.
 func (s *Service) CapellaInitialEpoch() phase0.Epoch {
 	return s.capellaForkEpoch
 }
 
-func fetchCapellaForkEpoch(ctx context.Context,
-	specProvider eth2client.SpecProvider,
-) (
-	phase0.Epoch,
-	error,
-) {
-	// Fetch the fork version.
-	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
-	if err != nil {
-		return 0, errors.Wrap(err, "failed to obtain spec")
-	}
-	tmp, exists := specResponse.Data["CAPELLA_FORK_EPOCH"]
-	if !exists {
-		return 0, errors.New("capella fork version not known by chain")
-	}
-	epoch, isEpoch := tmp.(uint64)
-	if !isEpoch {
-		//nolint:revive
-		return 0, errors.New("CAPELLA_FORK_EPOCH is not a uint64!")
-	}
-
-	return phase0.Epoch(epoch), nil
-}
-
 // DenebInitialEpoch provides the epoch at which the Deneb hard fork takes place.
 func (s *Service) DenebInitialEpoch() phase0.Epoch {
 	return s.denebForkEpoch
 }
 
-func fetchDenebForkEpoch(ctx context.Context,
-	specProvider eth2client.SpecProvider,
-) (
-	phase0.Epoch,
-	error,
-) {
-	// Fetch the fork version.
-	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
-	if err != nil {
-		return 0, errors.Wrap(err, "failed to obtain spec")
-	}
-	tmp, exists := specResponse.Data["DENEB_FORK_EPOCH"]
-	if !exists {
-		return 0, errors.New("deneb fork version not known by chain")
-	}
-	epoch, isEpoch := tmp.(uint64)
-	if !isEpoch {
-		//nolint:revive
-		return 0, errors.New("DENEB_FORK_EPOCH is not a uint64!")
-	}
-
-	return phase0.Epoch(epoch), nil
-}
-
 // ElectraInitialEpoch provides the epoch at which the Electra hard fork takes place.
 func (s *Service) ElectraInitialEpoch() phase0.Epoch {
 	return s.electraForkEpoch
 }
-
-func fetchElectraForkEpoch(ctx context.Context,
-	specProvider eth2client.SpecProvider,
-) (
-	phase0.Epoch,
-	error,
-) {
-	// Fetch the fork version.
-	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
-	if err != nil {
-		return 0, errors.Wrap(err, "failed to obtain spec")
-	}
-	tmp, exists := specResponse.Data["ELECTRA_FORK_EPOCH"]
-	if !exists {
-		return 0, errors.New("deneb fork version not known by chain")
-	}
-	epoch, isEpoch := tmp.(uint64)
-	if !isEpoch {
-		//nolint:revive
-		return 0, errors.New("ELECTRA_FORK_EPOCH is not a uint64!")
-	}
-
-	return phase0.Epoch(epoch), nil
-}
@@ -1,4 +1,4 @@
-// Copyright © 2021 Weald Technology Trading.
+// Copyright © 2021, 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -278,3 +278,116 @@ func TestTimestampToEpoch(t *testing.T) {
 		})
 	}
 }
+
+func TestForks(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient, err := mock.New(ctx)
+	require.NoError(t, err)
+	mockClient.GenesisFunc = func(context.Context, *api.GenesisOpts) (*api.Response[*apiv1.Genesis], error) {
+		return &api.Response[*apiv1.Genesis]{
+			Data: &apiv1.Genesis{
+				GenesisTime:           time.Unix(1606824023, 0),
+				GenesisValidatorsRoot: phase0.Root{0x4b, 0x36, 0x3d, 0xb9, 0x4e, 0x28, 0x61, 0x20, 0xd7, 0x6e, 0xb9, 0x05, 0x34, 0x0f, 0xdd, 0x4e, 0x54, 0xbf, 0xe9, 0xf0, 0x6b, 0xf3, 0x3f, 0xf6, 0xcf, 0x5a, 0xd2, 0x7f, 0x51, 0x1b, 0xfe, 0x95},
+			},
+			Metadata: make(map[string]any),
+		}, nil
+	}
+	mockClient.SpecFunc = func(context.Context, *api.SpecOpts) (*api.Response[map[string]any], error) {
+		return &api.Response[map[string]any]{
+			Data: map[string]any{
+				"SECONDS_PER_SLOT":                 time.Second * 12,
+				"SLOTS_PER_EPOCH":                  uint64(32),
+				"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": uint64(256),
+				"GENESIS_FORK_VERSION":             phase0.Version{0x00, 0x00, 0x00, 0x00},
+				"ALTAIR_FORK_VERSION":              phase0.Version{0x01, 0x00, 0x00, 0x00},
+				"ALTAIR_FORK_EPOCH":                uint64(0),
+				"DENEB_FORK_VERSION":               phase0.Version{0x04, 0x00, 0x00, 0x00},
+				"DENEB_FORK_EPOCH":                 uint64(269568),
+				"ELECTRA_FORK_VERSION":             phase0.Version{0x05, 0x00, 0x00, 0x00},
+				"ELECTRA_FORK_EPOCH":               uint64(364032),
+				"FULU_FORK_VERSION":                phase0.Version{0x06, 0x00, 0x00, 0x00},
+				"FULU_FORK_EPOCH":                  uint64(411392),
+				"GLOAS_FORK_VERSION":               phase0.Version{0x07, 0x00, 0x00, 0x00},
+				"GLOAS_FORK_EPOCH":                 uint64(0xffffffffffffffff),
+				"MAX_BLOBS_PER_BLOCK":              uint64(6),
+				"MAX_BLOBS_PER_BLOCK_ELECTRA":      uint64(9),
+				"BLOB_SCHEDULE": []any{
+					map[string]any{"EPOCH": uint64(419072), "MAX_BLOBS_PER_BLOCK": uint64(21)},
+					map[string]any{"EPOCH": uint64(412672), "MAX_BLOBS_PER_BLOCK": uint64(15)},
+				},
+			},
+			Metadata: make(map[string]any),
+		}, nil
+	}
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithGenesisProvider(mockClient),
+		standard.WithSpecProvider(mockClient),
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, []*chaintime.Fork{
+		{Name: "phase0", Epoch: 0, Version: phase0.Version{0x00, 0x00, 0x00, 0x00}},
+		{Name: "altair", Epoch: 0, Version: phase0.Version{0x01, 0x00, 0x00, 0x00}},
+		{Name: "deneb", Epoch: 269568, Version: phase0.Version{0x04, 0x00, 0x00, 0x00}, MaxBlobsPerBlock: 6},
+		{Name: "electra", Epoch: 364032, Version: phase0.Version{0x05, 0x00, 0x00, 0x00}, MaxBlobsPerBlock: 9},
+		{Name: "fulu", Epoch: 411392, Version: phase0.Version{0x06, 0x00, 0x00, 0x00}, MaxBlobsPerBlock: 9},
+		{Name: "bpo1", Epoch: 412672, Version: phase0.Version{0x06, 0x00, 0x00, 0x00}, BlobParametersOnly: true, MaxBlobsPerBlock: 15},
+		{Name: "bpo2", Epoch: 419072, Version: phase0.Version{0x06, 0x00, 0x00, 0x00}, BlobParametersOnly: true, MaxBlobsPerBlock: 21},
+	}, s.Forks())
+	require.Equal(t, phase0.Epoch(364032), s.ElectraInitialEpoch())
+
+	tests := []struct {
+		name    string
+		epoch   phase0.Epoch
+		fork    string
+		version phase0.Version
+		digest  phase0.ForkDigest
+	}{
+		{
+			name:    "Genesis",
+			epoch:   0,
+			fork:    "altair",
+			version: phase0.Version{0x01, 0x00, 0x00, 0x00},
+			digest:  phase0.ForkDigest{0xaf, 0xca, 0xab, 0xa0},
+		},
+		{
+			name:    "Deneb",
+			epoch:   300000,
+			fork:    "deneb",
+			version: phase0.Version{0x04, 0x00, 0x00, 0x00},
+			digest:  phase0.ForkDigest{0x6a, 0x95, 0xa1, 0xa9},
+		},
+		{
+			name:    "Electra",
+			epoch:   364032,
+			fork:    "electra",
+			version: phase0.Version{0x05, 0x00, 0x00, 0x00},
+			digest:  phase0.ForkDigest{0xad, 0x53, 0x2c, 0xeb},
+		},
+		{
+			name:    "Fulu",
+			epoch:   411392,
+			fork:    "fulu",
+			version: phase0.Version{0x06, 0x00, 0x00, 0x00},
+			digest:  phase0.ForkDigest{0xcc, 0x2c, 0x5c, 0xdb},
+		},
+		{
+			name:    "BPO2",
+			epoch:   500000,
+			fork:    "bpo2",
+			version: phase0.Version{0x06, 0x00, 0x00, 0x00},
+			digest:  phase0.ForkDigest{0x8c, 0x9f, 0x62, 0xfe},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.fork, s.ForkAtEpoch(test.epoch).Name)
+			require.Equal(t, test.version, s.ForkVersionAtEpoch(test.epoch))
+			require.Equal(t, test.digest, s.ForkDigestAtEpoch(test.epoch))
+		})
+	}
+}
@@ -229,7 +229,7 @@ func parseAccountFromKeystore(ctx context.Context,
 				}
 			}
 			if !unlocked {
-				return nil, errors.New("failed to unlock account")
+				return nil, NewError(ErrorCodeInvalidPassphrase, "failed to unlock account")
 			}
 		}
 	}
@@ -313,7 +313,7 @@ func UnlockAccount(ctx context.Context, account e2wtypes.Account, passphrases []
 	}
 
 	// Failed to unlock it.
-	return false, errors.New("failed to unlock account")
+	return false, NewError(ErrorCodeInvalidPassphrase, "failed to unlock account")
 }
 
 // LockAccount attempts to lock an account.
@@ -1,4 +1,4 @@
-// Copyright © 2020, 2023 Weald Technology Trading
+// Copyright © 2020, 2023, 2025 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -15,7 +15,10 @@ package util
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
+	nethttp "net/http"
 	"net/url"
 	"os"
 	"strings"
@@ -23,8 +26,10 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/multi"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 )
 
 // defaultBeaconNodeAddresses are default REST endpoint addresses for beacon nodes.
@@ -38,10 +43,16 @@ var defaultBeaconNodeAddresses = []string{
 var fallbackBeaconNode = "http://mainnet-consensus.attestant.io/"
 
 type ConnectOpts struct {
+	// Address is the address of the beacon node.  Multiple addresses
+	// can be supplied separated by commas, in which case requests will
+	// fail over between the nodes.
 	Address       string
 	Timeout       time.Duration
 	AllowInsecure bool
 	LogFallback   bool
+	// Quorum is the number of beacon nodes that must return matching
+	// data for calls made through RequireQuorum.  0 disables the check.
+	Quorum int
 }
 
 // ConnectToBeaconNode connects to a beacon node at the given address.
@@ -54,14 +65,50 @@ func ConnectToBeaconNode(ctx context.Context, opts *ConnectOpts) (eth2client.Ser
 		return nil, errors.New("no timeout specified")
 	}
 
+	if opts.Quorum < 0 {
+		return nil, NewError(ErrorCodeInvalidInput, "quorum cannot be negative")
+	}
+
+	if viper.GetString("replay") != "" {
+		if viper.GetString("record") != "" {
+			return nil, errors.New("cannot both record and replay beacon node responses")
+		}
+		// Responses come from the replay directory, so the address is irrelevant.
+		address := "localhost"
+		if opts.Address != "" {
+			address = beaconNodeAddresses(opts.Address)[0]
+		}
+
+		return connectToBeaconNode(ctx, address, opts.Timeout, true, nil)
+	}
+
 	if opts.Address != "" {
-		// We have an explicit address; use it.
-		return connectToBeaconNode(ctx, opts.Address, opts.Timeout, opts.AllowInsecure)
+		// We have explicit addresses; use them.
+		addresses := beaconNodeAddresses(opts.Address)
+		if opts.Quorum > len(addresses) {
+			return nil, NewError(ErrorCodeInvalidInput,
+				fmt.Sprintf("quorum of %d requires at least as many beacon nodes, but only %d supplied", opts.Quorum, len(addresses)),
+			)
+		}
+		auth, err := beaconNodeAuthFromConfig()
+		if err != nil {
+			return nil, err
+		}
+		if len(addresses) == 1 {
+			return connectToBeaconNode(ctx, addresses[0], opts.Timeout, opts.AllowInsecure, auth)
+		}
+
+		return connectToBeaconNodes(ctx, addresses, opts, auth)
+	}
+
+	if opts.Quorum > 1 {
+		return nil, NewError(ErrorCodeInvalidInput, "quorum requires multiple beacon nodes to be supplied with --connection")
 	}
 
-	// Try the defaults.
+	// Try the defaults.  Authentication is only used for explicit connections,
+	// to avoid leaking credentials to nodes that the user did not specify.
 	for _, address := range defaultBeaconNodeAddresses {
-		client, err := connectToBeaconNode(ctx, address, opts.Timeout, opts.AllowInsecure)
+		client, err := connectToBeaconNode(ctx, address, opts.Timeout, opts.AllowInsecure, nil)
 		if err == nil {
 			return client, nil
 		}
@@ -71,15 +118,109 @@ func ConnectToBeaconNode(ctx context.Context, opts *ConnectOpts) (eth2client.Ser
 	if opts.LogFallback {
 		fmt.Fprintf(os.Stderr, "No connection supplied with --connection parameter and no local beacon node found, attempting to use mainnet fallback\n")
 	}
-	client, err := connectToBeaconNode(ctx, fallbackBeaconNode, opts.Timeout, true)
+	client, err := connectToBeaconNode(ctx, fallbackBeaconNode, opts.Timeout, true, nil)
 	if err == nil {
 		return client, nil
 	}
 
-	return nil, errors.New("failed to connect to any beacon node")
+	return nil, NewError(ErrorCodeConnectionFailed, "failed to connect to any beacon node")
+}
+
+// beaconNodeAddresses splits a comma-separated list of addresses.
+func beaconNodeAddresses(input string) []string {
+	addresses := make([]string, 0)
+	for _, address := range strings.Split(input, ",") {
+		address = strings.TrimSpace(address)
+		if address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses
+}
+
+// redactAddresses removes any passwords from beacon node addresses, so that
+// they can be safely included in error output.
+func redactAddresses(addresses []string) []string {
+	res := make([]string, len(addresses))
+	for i, address := range addresses {
+		res[i] = address
+		prefix := ""
+		if !strings.Contains(address, "://") {
+			// Need a scheme to parse user information.
+			prefix = "http://"
+		}
+		if addressURL, err := url.Parse(prefix + address); err == nil {
+			res[i] = strings.TrimPrefix(addressURL.Redacted(), prefix)
+		}
+	}
+
+	return res
+}
+
+// connectToBeaconNodes connects to multiple beacon nodes, providing a single
+// service that fails over between them.
+func connectToBeaconNodes(ctx context.Context,
+	addresses []string,
+	opts *ConnectOpts,
+	auth *beaconNodeAuth,
+) (
+	eth2client.Service,
+	error,
+) {
+	clients := make([]eth2client.Service, 0, len(addresses))
+	for _, address := range addresses {
+		client, err := connectToBeaconNode(ctx, address, opts.Timeout, opts.AllowInsecure, auth)
+		if err != nil {
+			// Failover is automatic, so we ignore this node.
+			continue
+		}
+		clients = append(clients, client)
+	}
+	if len(clients) == 0 {
+		return nil, ErrorWithCode(errors.New("failed to connect to any beacon node"),
+			ErrorCodeConnectionFailed,
+			map[string]any{"addresses": redactAddresses(addresses)},
+		)
+	}
+	if opts.Quorum > len(clients) {
+		return nil, NewError(ErrorCodeConnectionFailed,
+			fmt.Sprintf("only %d of %d beacon nodes available; cannot meet quorum of %d", len(clients), len(addresses), opts.Quorum),
+		)
+	}
+
+	service, err := multi.New(ctx,
+		multi.WithLogLevel(zerolog.Disabled),
+		multi.WithTimeout(opts.Timeout),
+		multi.WithClients(clients),
+	)
+	if err != nil {
+		if errors.Is(err, eth2client.ErrNotActive) {
+			return nil, NewError(ErrorCodeNodeSyncing, "no synced beacon node available")
+		}
+		return nil, ErrorWithCode(errors.Wrap(err, "failed to connect to beacon nodes"), ErrorCodeConnectionFailed, nil)
+	}
+	multiService, isMultiService := service.(*multi.Service)
+	if !isMultiService {
+		return nil, errors.New("unexpected beacon node service type")
+	}
+
+	return &multiNodeService{
+		Service: multiService,
+		clients: clients,
+		quorum:  opts.Quorum,
+	}, nil
 }
 
-func connectToBeaconNode(ctx context.Context, address string, timeout time.Duration, allowInsecure bool) (eth2client.Service, error) {
+func connectToBeaconNode(ctx context.Context,
+	address string,
+	timeout time.Duration,
+	allowInsecure bool,
+	auth *beaconNodeAuth,
+) (
+	eth2client.Service,
+	error,
+) {
 	if !strings.HasPrefix(address, "http") {
 		address = fmt.Sprintf("http://%s", address)
 	}
@@ -90,21 +231,70 @@ func connectToBeaconNode(ctx context.Context, address string, timeout time.Durat
 			return nil, errors.Wrap(err, "failed to parse connection")
 		}
 		if connectionURL.Scheme == "http" &&
-			connectionURL.Host != "localhost" &&
-			!strings.HasPrefix(connectionURL.Host, "localhost:") &&
-			connectionURL.Host != "127.0.0.1" &&
-			!strings.HasPrefix(connectionURL.Host, "127.0.0.1:") {
+			connectionURL.Hostname() != "localhost" &&
+			connectionURL.Hostname() != "127.0.0.1" {
+			if connectionURL.User != nil {
+				return nil, errors.New("refusing to send credentials to a remote beacon node over an insecure connection.  This check can be disabled with --allow-insecure-connections")
+			}
 			fmt.Println("Connections to remote beacon nodes should be secure.  This warning can be silenced with --allow-insecure-connections")
 		}
 	}
+	if auth == nil {
+		auth = &beaconNodeAuth{}
+	}
+	httpClient, err := beaconNodeHTTPClient(timeout, auth.tlsConfig)
+	if err != nil {
+		return nil, err
+	}
 	eth2Client, err := http.New(ctx,
 		http.WithLogLevel(zerolog.Disabled),
 		http.WithAddress(address),
 		http.WithTimeout(timeout),
+		http.WithHTTPClient(httpClient),
+		http.WithExtraHeaders(auth.headers),
 	)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to connect to beacon node")
+		return nil, ErrorWithCode(errors.Wrap(err, "failed to connect to beacon node"),
+			ErrorCodeConnectionFailed,
+			map[string]any{"address": redactAddresses([]string{address})[0]},
+		)
 	}
 
 	return eth2Client, nil
 }
+
+// beaconNodeHTTPClient creates the HTTP client used to talk to beacon nodes,
+// caching, recording or replaying responses if requested.
+func beaconNodeHTTPClient(timeout time.Duration, tlsConfig *tls.Config) (*nethttp.Client, error) {
+	var transport nethttp.RoundTripper = &nethttp.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   timeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:     tlsConfig,
+		MaxIdleConns:        64,
+		MaxConnsPerHost:     64,
+		MaxIdleConnsPerHost: 64,
+		IdleConnTimeout:     600 * time.Second,
+	}
+
+	if viper.GetBool("cache") {
+		transport = newCachingTransport(BeaconNodeCacheDir(), BeaconNodeCacheMaxSize(), transport)
+	}
+
+	// Recording takes place after caching, so that cached responses are also recorded.
+	var err error
+	switch {
+	case viper.GetString("replay") != "":
+		transport, err = newReplayingTransport(viper.GetString("replay"))
+	case viper.GetString("record") != "":
+		transport, err = newRecordingTransport(viper.GetString("record"), transport)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &nethttp.Client{
+		Transport: transport,
+	}, nil
+}
@@ -0,0 +1,120 @@
+// Copyright © 2025 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// beaconNodeAuth contains the authentication information for beacon node connections.
+type beaconNodeAuth struct {
+	headers   map[string]string
+	tlsConfig *tls.Config
+}
+
+// beaconNodeAuthFromConfig obtains beacon node authentication information
+// from the configuration.
+func beaconNodeAuthFromConfig() (*beaconNodeAuth, error) {
+	headers, err := parseConnectionHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := composeConnectionTLSConfig(viper.GetString("connection-client-cert"),
+		viper.GetString("connection-client-key"),
+		viper.GetString("connection-ca-cert"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &beaconNodeAuth{
+		headers:   headers,
+		tlsConfig: tlsConfig,
+	}, nil
+}
+
+// parseConnectionHeaders parses the additional headers for beacon node connections.
+// Headers can be supplied as a map in the configuration file, or as a list of
+// "name: value" entries.
+func parseConnectionHeaders() (map[string]string, error) {
+	headers := make(map[string]string)
+	if _, isMap := viper.Get("connection-headers").(map[string]any); isMap {
+		for k, v := range viper.GetStringMapString("connection-headers") {
+			headers[k] = v
+		}
+
+		return headers, nil
+	}
+
+	for _, header := range viper.GetStringSlice("connection-headers") {
+		name, value, found := strings.Cut(header, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid connection header %q; must be of the form \"name: value\"", header)
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid connection header %q; name missing", header)
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+
+	return headers, nil
+}
+
+// composeConnectionTLSConfig composes the TLS configuration for beacon node connections.
+// It returns nil if no custom configuration is required.
+func composeConnectionTLSConfig(certPath string, keyPath string, caCertPath string) (*tls.Config, error) {
+	if certPath == "" && keyPath == "" && caCertPath == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	switch {
+	case certPath != "" && keyPath != "":
+		clientPair, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load connection client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientPair}
+	case certPath != "":
+		return nil, errors.New("connection client certificate requires connection client key")
+	case keyPath != "":
+		return nil, errors.New("connection client key requires connection client certificate")
+	}
+
+	if caCertPath != "" {
+		caCert, err := os.ReadFile(caCertPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain connection CA certificate")
+		}
+		cp := x509.NewCertPool()
+		if !cp.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("failed to add connection CA certificate")
+		}
+		tlsConfig.RootCAs = cp
+	}
+
+	return tlsConfig, nil
+}
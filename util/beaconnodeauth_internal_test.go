@@ -0,0 +1,149 @@
+// Copyright © 2025 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConnectionHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers any
+		res     map[string]string
+		err     string
+	}{
+		{
+			name: "Empty",
+			res:  map[string]string{},
+		},
+		{
+			name:    "List",
+			headers: []string{"Authorization: Bearer abc", "X-Custom:value"},
+			res: map[string]string{
+				"Authorization": "Bearer abc",
+				"X-Custom":      "value",
+			},
+		},
+		{
+			name: "Map",
+			headers: map[string]any{
+				"Authorization": "Bearer abc",
+			},
+			res: map[string]string{
+				"authorization": "Bearer abc",
+			},
+		},
+		{
+			name:    "SeparatorMissing",
+			headers: []string{"Authorization"},
+			err:     `invalid connection header "Authorization"; must be of the form "name: value"`,
+		},
+		{
+			name:    "NameMissing",
+			headers: []string{": value"},
+			err:     `invalid connection header ": value"; name missing`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			if test.headers != nil {
+				viper.Set("connection-headers", test.headers)
+			}
+			res, err := parseConnectionHeaders()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestComposeConnectionTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caCertPath := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caCertPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+	badCACertPath := filepath.Join(dir, "bad.crt")
+	require.NoError(t, os.WriteFile(badCACertPath, []byte("bad"), 0o600))
+
+	tests := []struct {
+		name       string
+		certPath   string
+		keyPath    string
+		caCertPath string
+		err        string
+	}{
+		{
+			name:     "KeyMissing",
+			certPath: filepath.Join(dir, "client.crt"),
+			err:      "connection client certificate requires connection client key",
+		},
+		{
+			name:    "CertMissing",
+			keyPath: filepath.Join(dir, "client.key"),
+			err:     "connection client key requires connection client certificate",
+		},
+		{
+			name:       "CACertMissing",
+			caCertPath: filepath.Join(dir, "missing.crt"),
+			err:        "failed to obtain connection CA certificate: open " + filepath.Join(dir, "missing.crt") + ": no such file or directory",
+		},
+		{
+			name:       "CACertBad",
+			caCertPath: badCACertPath,
+			err:        "failed to add connection CA certificate",
+		},
+		{
+			name:       "Good",
+			caCertPath: caCertPath,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tlsConfig, err := composeConnectionTLSConfig(test.certPath, test.keyPath, test.caCertPath)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			viper.Reset()
+			client, err := beaconNodeHTTPClient(time.Second, tlsConfig)
+			require.NoError(t, err)
+			resp, err := client.Get(server.URL)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
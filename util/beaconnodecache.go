@@ -0,0 +1,499 @@
+// Copyright © 2025 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+)
+
+// cacheablePath is a beacon API path whose response is immutable once the
+// slot or epoch it references is finalized.
+type cacheablePath struct {
+	re *regexp.Regexp
+	// epoch is true if the path references an epoch rather than a slot.
+	epoch bool
+	// notFound is true if a 404 response for a finalized slot is also
+	// cacheable, for example an empty slot.  As a beacon node can also return
+	// a 404 for data it does not hold, for example before it has backfilled
+	// after a checkpoint sync, these responses are only cached for
+	// cacheNotFoundTTL.
+	notFound bool
+}
+
+// cacheNotFoundTTL is the time for which a 404 response is cached.
+const cacheNotFoundTTL = time.Hour
+
+var cacheablePaths = []*cacheablePath{
+	{re: regexp.MustCompile(`^/eth/v[12]/beacon/blocks/(\d+)(/[a-z_]+)?$`), notFound: true},
+	{re: regexp.MustCompile(`^/eth/v[12]/beacon/blinded_blocks/(\d+)$`), notFound: true},
+	{re: regexp.MustCompile(`^/eth/v1/beacon/headers/(\d+)$`), notFound: true},
+	{re: regexp.MustCompile(`^/eth/v1/beacon/blob_sidecars/(\d+)$`), notFound: true},
+	{re: regexp.MustCompile(`^/eth/v1/beacon/states/(\d+)/[a-z_]+$`)},
+	{re: regexp.MustCompile(`^/eth/v2/debug/beacon/states/(\d+)$`)},
+	{re: regexp.MustCompile(`^/eth/v1/beacon/rewards/blocks/(\d+)$`)},
+	{re: regexp.MustCompile(`^/eth/v1/beacon/rewards/sync_committee/(\d+)$`)},
+	{re: regexp.MustCompile(`^/eth/v1/beacon/rewards/attestations/(\d+)$`), epoch: true},
+	{re: regexp.MustCompile(`^/eth/v1/validator/duties/(?:attester|proposer|sync)/(\d+)$`), epoch: true},
+}
+
+// BeaconNodeCacheDir returns the directory used for the on-disk cache of
+// finalized beacon node data.
+func BeaconNodeCacheDir() string {
+	baseDir := GetBaseDir()
+	if baseDir == "" {
+		if store, isStore := filesystem.New().(*filesystem.Store); isStore {
+			baseDir = store.Location()
+		}
+	}
+
+	return filepath.Join(baseDir, "cache")
+}
+
+// BeaconNodeCacheMaxSize returns the maximum size of the on-disk cache, in bytes.
+func BeaconNodeCacheMaxSize() int64 {
+	return viper.GetInt64("cache-max-size") * 1024 * 1024
+}
+
+// cachingTransport is an HTTP transport that stores responses for finalized
+// data on disk, and returns them in place of contacting the beacon node.
+type cachingTransport struct {
+	dir         string
+	maxSize     int64
+	base        http.RoundTripper
+	notFoundTTL time.Duration
+
+	mu sync.Mutex
+	// setupDone is true once chain information has been requested, and
+	// setupErr is the result.
+	setupDone bool
+	setupErr  error
+	// networkDir is the directory for the network to which the beacon node belongs.
+	networkDir string
+	// finalizedSlot is the slot of the latest finalized block.
+	finalizedSlot uint64
+	// slotsPerEpoch is the number of slots in an epoch.
+	slotsPerEpoch uint64
+	// size is the current size of the cache, or -1 if not yet calculated.
+	size int64
+}
+
+func newCachingTransport(dir string, maxSize int64, base http.RoundTripper) *cachingTransport {
+	return &cachingTransport{
+		dir:         dir,
+		maxSize:     maxSize,
+		base:        base,
+		notFoundTTL: cacheNotFoundTTL,
+		size:        -1,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodPost {
+		return t.base.RoundTrip(req)
+	}
+	// The connection address may include a path prefix before the API path.
+	prefix, apiPath, found := strings.Cut(req.URL.Path, "/eth/")
+	if !found {
+		return t.base.RoundTrip(req)
+	}
+	apiPath = "/eth/" + apiPath
+	var match *cacheablePath
+	var value uint64
+	for _, path := range cacheablePaths {
+		if matches := path.re.FindStringSubmatch(apiPath); matches != nil {
+			var err error
+			value, err = strconv.ParseUint(matches[1], 10, 64)
+			if err == nil {
+				match = path
+			}
+			break
+		}
+	}
+	if match == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	if err := t.setup(req, prefix); err != nil {
+		// Without chain information we cannot cache safely.
+		return t.base.RoundTrip(req)
+	}
+	if !t.finalized(match, value) {
+		return t.base.RoundTrip(req)
+	}
+
+	reqBody, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := fixtureKey(req, reqBody)
+	filename := filepath.Join(t.networkDir, key[:2], fmt.Sprintf("%s.json", key))
+	if resp := t.cached(req, filename); resp != nil {
+		return resp, nil
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && !(match.notFound && resp.StatusCode == http.StatusNotFound) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+	if err := resp.Body.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close response body")
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// Failure to store the response is not fatal.
+	_ = t.store(req, resp, body, filename)
+
+	return resp, nil
+}
+
+// setup obtains the information about the chain required to cache data.
+func (t *cachingTransport) setup(req *http.Request, prefix string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.setupDone {
+		t.setupErr = t.obtainChainInfo(req, prefix)
+		t.setupDone = true
+	}
+
+	return t.setupErr
+}
+
+// obtainChainInfo obtains the network and finality information from the beacon node.
+func (t *cachingTransport) obtainChainInfo(req *http.Request, prefix string) error {
+	genesis := struct {
+		Data struct {
+			GenesisValidatorsRoot string `json:"genesis_validators_root"`
+		} `json:"data"`
+	}{}
+	if err := t.lookup(req, prefix+"/eth/v1/beacon/genesis", &genesis); err != nil {
+		return err
+	}
+	if genesis.Data.GenesisValidatorsRoot == "" {
+		return errors.New("genesis validators root not available")
+	}
+
+	spec := struct {
+		Data struct {
+			SlotsPerEpoch string `json:"SLOTS_PER_EPOCH"`
+		} `json:"data"`
+	}{}
+	if err := t.lookup(req, prefix+"/eth/v1/config/spec", &spec); err != nil {
+		return err
+	}
+	slotsPerEpoch, err := strconv.ParseUint(spec.Data.SlotsPerEpoch, 10, 64)
+	if err != nil || slotsPerEpoch == 0 {
+		return errors.New("slots per epoch not available")
+	}
+
+	header := struct {
+		Data struct {
+			Header struct {
+				Message struct {
+					Slot string `json:"slot"`
+				} `json:"message"`
+			} `json:"header"`
+		} `json:"data"`
+	}{}
+	if err := t.lookup(req, prefix+"/eth/v1/beacon/headers/finalized", &header); err != nil {
+		return err
+	}
+	finalizedSlot, err := strconv.ParseUint(header.Data.Header.Message.Slot, 10, 64)
+	if err != nil {
+		return errors.New("finalized slot not available")
+	}
+
+	t.slotsPerEpoch = slotsPerEpoch
+	t.finalizedSlot = finalizedSlot
+	t.networkDir = filepath.Join(t.dir, strings.TrimPrefix(strings.ToLower(genesis.Data.GenesisValidatorsRoot), "0x"))
+
+	return nil
+}
+
+// lookup makes a request to the beacon node for chain information.
+func (t *cachingTransport) lookup(orig *http.Request, path string, res any) error {
+	req := orig.Clone(orig.Context())
+	req.Method = http.MethodGet
+	req.URL.Path = path
+	req.URL.RawQuery = ""
+	req.Body = nil
+	req.ContentLength = 0
+	req.Header.Set("Accept", "application/json")
+	req.Header.Del("Content-Type")
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request for %s returned status %d", path, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(res)
+}
+
+// finalized returns true if the slot or epoch is at or before the finalized checkpoint.
+func (t *cachingTransport) finalized(path *cacheablePath, value uint64) bool {
+	if path.epoch {
+		// Epoch-based data can depend on the following epoch, so require
+		// that to be finalized as well.
+		return (value+2)*t.slotsPerEpoch <= t.finalizedSlot+1
+	}
+
+	return value <= t.finalizedSlot
+}
+
+// cached returns the cached response for the given file, or nil if not present
+// or expired.
+func (t *cachingTransport) cached(req *http.Request, filename string) *http.Response {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil
+	}
+	recorded := &recordedResponse{}
+	if err := json.Unmarshal(data, recorded); err != nil {
+		return nil
+	}
+	if recorded.StatusCode == http.StatusNotFound &&
+		(recorded.Stored == nil || time.Since(*recorded.Stored) > t.notFoundTTL) {
+		// Expired; it will be replaced by a fresh response.
+		return nil
+	}
+
+	// Touch the file, so that pruning removes the least recently used entries.
+	now := time.Now()
+	_ = os.Chtimes(filename, now, now)
+
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+	for k, v := range recorded.Headers {
+		resp.Header.Set(k, v)
+	}
+
+	return resp
+}
+
+// store stores a response in the cache.
+func (t *cachingTransport) store(req *http.Request, resp *http.Response, body []byte, filename string) error {
+	stored := time.Now()
+	recorded := &recordedResponse{
+		Method:     req.Method,
+		Path:       req.URL.RequestURI(),
+		StatusCode: resp.StatusCode,
+		Headers:    make(map[string]string),
+		Body:       body,
+		Stored:     &stored,
+	}
+	for _, header := range recordedHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			recorded.Headers[header] = value
+		}
+	}
+	data, err := json.Marshal(recorded)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, data, 0o600); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.size == -1 {
+		info, err := BeaconNodeCacheInfo(t.dir)
+		if err != nil {
+			return err
+		}
+		t.size = 0
+		for _, network := range info {
+			t.size += network.Size
+		}
+	} else {
+		t.size += int64(len(data))
+	}
+	if t.maxSize > 0 && t.size > t.maxSize {
+		// Prune to below the maximum size to avoid pruning on every write.
+		t.size, err = PruneBeaconNodeCache(t.dir, t.maxSize*9/10)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BeaconNodeCacheNetworkInfo provides information about the cache for a network.
+type BeaconNodeCacheNetworkInfo struct {
+	GenesisValidatorsRoot string
+	Entries               int
+	Size                  int64
+	Oldest                time.Time
+	Newest                time.Time
+}
+
+// cacheEntry is an entry in the on-disk cache.
+type cacheEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// cacheEntries returns all entries in the on-disk cache.
+func cacheEntries(dir string) ([]*cacheEntry, error) {
+	entries := make([]*cacheEntry, 0)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".json") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		entries = append(entries, &cacheEntry{
+			path:    path,
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read cache")
+	}
+
+	return entries, nil
+}
+
+// BeaconNodeCacheInfo returns information about the on-disk cache, by network.
+func BeaconNodeCacheInfo(dir string) ([]*BeaconNodeCacheNetworkInfo, error) {
+	entries, err := cacheEntries(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	networks := make(map[string]*BeaconNodeCacheNetworkInfo)
+	for _, entry := range entries {
+		rel, err := filepath.Rel(dir, entry.path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain cache entry path")
+		}
+		gvr := strings.Split(rel, string(filepath.Separator))[0]
+		network, exists := networks[gvr]
+		if !exists {
+			network = &BeaconNodeCacheNetworkInfo{
+				GenesisValidatorsRoot: fmt.Sprintf("0x%s", gvr),
+				Oldest:                entry.modTime,
+				Newest:                entry.modTime,
+			}
+			networks[gvr] = network
+		}
+		network.Entries++
+		network.Size += entry.size
+		if entry.modTime.Before(network.Oldest) {
+			network.Oldest = entry.modTime
+		}
+		if entry.modTime.After(network.Newest) {
+			network.Newest = entry.modTime
+		}
+	}
+
+	res := make([]*BeaconNodeCacheNetworkInfo, 0, len(networks))
+	for _, network := range networks {
+		res = append(res, network)
+	}
+	sort.Slice(res, func(i int, j int) bool {
+		return res[i].GenesisValidatorsRoot < res[j].GenesisValidatorsRoot
+	})
+
+	return res, nil
+}
+
+// PruneBeaconNodeCache removes the least recently used entries from the
+// on-disk cache until it is no larger than the given size, returning the
+// resultant size of the cache.
+func PruneBeaconNodeCache(dir string, maxSize int64) (int64, error) {
+	entries, err := cacheEntries(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	size := int64(0)
+	for _, entry := range entries {
+		size += entry.size
+	}
+	if size <= maxSize {
+		return size, nil
+	}
+
+	sort.Slice(entries, func(i int, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+	for _, entry := range entries {
+		if size <= maxSize {
+			break
+		}
+		if err := os.Remove(entry.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return size, errors.Wrap(err, "failed to remove cache entry")
+		}
+		size -= entry.size
+	}
+
+	return size, nil
+}
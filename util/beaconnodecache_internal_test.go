@@ -0,0 +1,196 @@
+// Copyright © 2025 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachingTransport(t *testing.T) {
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/eth/v1/beacon/genesis":
+			fmt.Fprint(w, `{"data":{"genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"}}`)
+		case "/eth/v1/config/spec":
+			fmt.Fprint(w, `{"data":{"SLOTS_PER_EPOCH":"32"}}`)
+		case "/eth/v1/beacon/headers/finalized":
+			fmt.Fprint(w, `{"data":{"header":{"message":{"slot":"320"}}}}`)
+		case "/eth/v1/beacon/headers/300", "/eth/v1/beacon/headers/330":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			fmt.Fprintf(w, `{"data":%d}`, calls[r.URL.Path])
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	transport := newCachingTransport(dir, 0, http.DefaultTransport)
+	client := &http.Client{Transport: transport}
+
+	tests := []struct {
+		name        string
+		path        string
+		notFoundTTL time.Duration
+		status      int
+		body        string
+		calls       int
+	}{
+		{
+			name:   "Finalized",
+			path:   "/eth/v2/beacon/blocks/100",
+			status: http.StatusOK,
+			body:   `{"data":1}`,
+			calls:  1,
+		},
+		{
+			name:   "FinalizedCached",
+			path:   "/eth/v2/beacon/blocks/100",
+			status: http.StatusOK,
+			body:   `{"data":1}`,
+			calls:  1,
+		},
+		{
+			name:   "NotFinalized",
+			path:   "/eth/v2/beacon/blocks/321",
+			status: http.StatusOK,
+			body:   `{"data":1}`,
+			calls:  1,
+		},
+		{
+			name:   "NotFinalizedNotCached",
+			path:   "/eth/v2/beacon/blocks/321",
+			status: http.StatusOK,
+			body:   `{"data":2}`,
+			calls:  2,
+		},
+		{
+			name:   "EmptySlot",
+			path:   "/eth/v1/beacon/headers/300",
+			status: http.StatusNotFound,
+			calls:  1,
+		},
+		{
+			name:   "EmptySlotCached",
+			path:   "/eth/v1/beacon/headers/300",
+			status: http.StatusNotFound,
+			calls:  1,
+		},
+		{
+			name:        "EmptySlotExpired",
+			path:        "/eth/v1/beacon/headers/300",
+			notFoundTTL: -time.Second,
+			status:      http.StatusNotFound,
+			calls:       2,
+		},
+		{
+			name:   "EmptySlotNotFinalized",
+			path:   "/eth/v1/beacon/headers/330",
+			status: http.StatusNotFound,
+			calls:  1,
+		},
+		{
+			name:   "EmptySlotNotFinalizedNotCached",
+			path:   "/eth/v1/beacon/headers/330",
+			status: http.StatusNotFound,
+			calls:  2,
+		},
+		{
+			name:   "EpochFinalized",
+			path:   "/eth/v1/validator/duties/proposer/8",
+			status: http.StatusOK,
+			body:   `{"data":1}`,
+			calls:  1,
+		},
+		{
+			name:   "EpochFinalizedCached",
+			path:   "/eth/v1/validator/duties/proposer/8",
+			status: http.StatusOK,
+			body:   `{"data":1}`,
+			calls:  1,
+		},
+		{
+			name:   "EpochNotFinalized",
+			path:   "/eth/v1/validator/duties/proposer/9",
+			status: http.StatusOK,
+			body:   `{"data":1}`,
+			calls:  1,
+		},
+		{
+			name:   "EpochNotFinalizedNotCached",
+			path:   "/eth/v1/validator/duties/proposer/9",
+			status: http.StatusOK,
+			body:   `{"data":2}`,
+			calls:  2,
+		},
+		{
+			name:   "Uncacheable",
+			path:   "/eth/v1/node/version",
+			status: http.StatusOK,
+			body:   `{"data":1}`,
+			calls:  1,
+		},
+		{
+			name:   "UncacheableNotCached",
+			path:   "/eth/v1/node/version",
+			status: http.StatusOK,
+			body:   `{"data":2}`,
+			calls:  2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transport.notFoundTTL = cacheNotFoundTTL
+			if test.notFoundTTL != 0 {
+				transport.notFoundTTL = test.notFoundTTL
+			}
+			resp, err := client.Get(server.URL + test.path)
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, test.status, resp.StatusCode)
+			if test.body != "" {
+				require.Equal(t, test.body, string(body))
+			}
+			require.Equal(t, test.calls, calls[test.path])
+		})
+	}
+
+	// Chain information should only have been fetched once.
+	require.Equal(t, 1, calls["/eth/v1/beacon/genesis"])
+
+	info, err := BeaconNodeCacheInfo(dir)
+	require.NoError(t, err)
+	require.Len(t, info, 1)
+	require.Equal(t, "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95", info[0].GenesisValidatorsRoot)
+	require.Equal(t, 3, info[0].Entries)
+
+	size, err := PruneBeaconNodeCache(dir, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), size)
+	info, err = BeaconNodeCacheInfo(dir)
+	require.NoError(t, err)
+	require.Empty(t, info)
+}
@@ -0,0 +1,156 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// ObtainBeaconNodePool obtains the contents of an operation pool from the
+// beacon node, for pools that are not available through the client library.
+// The path is relative to the beacon node's address, for example
+// "/eth/v1/beacon/pool/proposer_slashings", and the data element of the
+// response is unmarshalled into res.
+func ObtainBeaconNodePool(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	path string,
+	res any,
+) error {
+	body, err := beaconNodePoolRequest(ctx, eth2Client, timeout, nethttp.MethodGet, path, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	data := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return errors.Wrap(err, "failed to parse pool response")
+	}
+	if err := json.Unmarshal(data.Data, res); err != nil {
+		return errors.Wrap(err, "failed to parse pool data")
+	}
+
+	return nil
+}
+
+// SubmitBeaconNodePool submits an operation to a pool of the beacon node, for
+// submissions that are not available through the client library.  The path is
+// relative to the beacon node's address, for example
+// "/eth/v2/beacon/pool/attester_slashings", and version, if supplied, is sent
+// as the consensus version of the operation.
+func SubmitBeaconNodePool(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	path string,
+	version string,
+	operation any,
+) error {
+	data, err := json.Marshal(operation)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal operation")
+	}
+
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	if version != "" {
+		headers["Eth-Consensus-Version"] = version
+	}
+
+	_, err = beaconNodePoolRequest(ctx, eth2Client, timeout, nethttp.MethodPost, path, headers, data)
+
+	return err
+}
+
+// beaconNodePoolRequest carries out a request against the beacon node, returning
+// the body of the response.
+func beaconNodePoolRequest(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	method string,
+	path string,
+	headers map[string]string,
+	body []byte,
+) (
+	[]byte,
+	error,
+) {
+	address := eth2Client.Address()
+	if address == "" {
+		return nil, errors.New("beacon node address not available")
+	}
+	if !strings.HasPrefix(address, "http") {
+		address = fmt.Sprintf("http://%s", address)
+	}
+
+	// Authentication is only used for explicit connections, as per
+	// ConnectToBeaconNode.
+	auth := &beaconNodeAuth{}
+	if viper.GetString("connection") != "" {
+		var err error
+		auth, err = beaconNodeAuthFromConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+	client, err := beaconNodeHTTPClient(timeout, auth.tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = timeout
+
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := nethttp.NewRequestWithContext(ctx, method, strings.TrimSuffix(address, "/")+path, reqBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Accept", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	for k, v := range auth.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response")
+	}
+	if resp.StatusCode != nethttp.StatusOK {
+		return nil, fmt.Errorf("beacon node returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return respBody, nil
+}
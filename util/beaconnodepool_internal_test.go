@@ -0,0 +1,147 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObtainBeaconNodePool(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/eth/v1/beacon/pool/proposer_slashings":
+			fmt.Fprint(w, `{"data":[{"signed_header_1":{"message":{"slot":"1","proposer_index":"2","parent_root":"0x0000000000000000000000000000000000000000000000000000000000000000","state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","body_root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"signature":"0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},"signed_header_2":{"message":{"slot":"1","proposer_index":"2","parent_root":"0x0000000000000000000000000000000000000000000000000000000000000000","state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","body_root":"0x0101010101010101010101010101010101010101010101010101010101010101"},"signature":"0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}}]}`)
+		case "/eth/v1/beacon/pool/bad":
+			fmt.Fprint(w, `{"data":true}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":404,"message":"not found"}`)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		address string
+		path    string
+		entries int
+		err     string
+	}{
+		{
+			name: "AddressMissing",
+			path: "/eth/v1/beacon/pool/proposer_slashings",
+			err:  "beacon node address not available",
+		},
+		{
+			name:    "NotFound",
+			address: server.URL,
+			path:    "/eth/v1/beacon/pool/missing",
+			err:     `beacon node returned status 404: {"code":404,"message":"not found"}`,
+		},
+		{
+			name:    "BadData",
+			address: server.URL,
+			path:    "/eth/v1/beacon/pool/bad",
+			err:     "failed to parse pool data: json: cannot unmarshal bool into Go value of type []*phase0.ProposerSlashing",
+		},
+		{
+			name:    "Good",
+			address: server.URL + "/",
+			path:    "/eth/v1/beacon/pool/proposer_slashings",
+			entries: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			res := make([]*phase0.ProposerSlashing, 0)
+			err := ObtainBeaconNodePool(context.Background(), &quorumClient{address: test.address}, time.Second, test.path, &res)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, test.entries)
+			}
+		})
+	}
+}
+
+func TestSubmitBeaconNodePool(t *testing.T) {
+	var version string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/eth/v2/beacon/pool/attester_slashings" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":404,"message":"not found"}`)
+			return
+		}
+		version = r.Header.Get("Eth-Consensus-Version")
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		address string
+		path    string
+		version string
+		err     string
+	}{
+		{
+			name: "AddressMissing",
+			path: "/eth/v2/beacon/pool/attester_slashings",
+			err:  "beacon node address not available",
+		},
+		{
+			name:    "NotFound",
+			address: server.URL,
+			path:    "/eth/v1/beacon/pool/attester_slashings",
+			err:     `beacon node returned status 404: {"code":404,"message":"not found"}`,
+		},
+		{
+			name:    "Good",
+			address: server.URL,
+			path:    "/eth/v2/beacon/pool/attester_slashings",
+			version: "electra",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			version = ""
+			body = nil
+			err := SubmitBeaconNodePool(context.Background(), &quorumClient{address: test.address}, time.Second, test.path, test.version, map[string]string{"slot": "1"})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.version, version)
+				require.JSONEq(t, `{"slot":"1"}`, string(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright © 2025 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/multi"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+)
+
+// multiNodeService is a beacon node service backed by multiple beacon nodes.
+// Calls fail over between nodes, and calls made through RequireQuorum are
+// checked against all nodes.
+type multiNodeService struct {
+	*multi.Service
+	clients []eth2client.Service
+	quorum  int
+}
+
+// SubmitBLSToExecutionChanges submits BLS to execution address change operations.
+// This is not provided by the multi client, so is implemented here by trying
+// each node in turn until one accepts the operations.
+func (s *multiNodeService) SubmitBLSToExecutionChanges(ctx context.Context,
+	blsToExecutionChanges []*capella.SignedBLSToExecutionChange,
+) error {
+	return s.submitToAny(ctx, "BLS to execution changes", func(ctx context.Context, client eth2client.Service) (bool, error) {
+		submitter, isSubmitter := client.(eth2client.BLSToExecutionChangesSubmitter)
+		if !isSubmitter {
+			return false, nil
+		}
+
+		return true, submitter.SubmitBLSToExecutionChanges(ctx, blsToExecutionChanges)
+	})
+}
+
+// SubmitAttesterSlashing submits an attester slashing.
+// This is not provided by the multi client, so is implemented here by trying
+// each node in turn until one accepts the slashing.
+func (s *multiNodeService) SubmitAttesterSlashing(ctx context.Context,
+	slashing *phase0.AttesterSlashing,
+) error {
+	return s.submitToAny(ctx, "attester slashings", func(ctx context.Context, client eth2client.Service) (bool, error) {
+		submitter, isSubmitter := client.(eth2client.AttesterSlashingSubmitter)
+		if !isSubmitter {
+			return false, nil
+		}
+
+		return true, submitter.SubmitAttesterSlashing(ctx, slashing)
+	})
+}
+
+// SubmitProposalSlashing submits a proposer slashing.
+// This is not provided by the multi client, so is implemented here by trying
+// each node in turn until one accepts the slashing.
+func (s *multiNodeService) SubmitProposalSlashing(ctx context.Context,
+	slashing *phase0.ProposerSlashing,
+) error {
+	return s.submitToAny(ctx, "proposer slashings", func(ctx context.Context, client eth2client.Service) (bool, error) {
+		submitter, isSubmitter := client.(eth2client.ProposalSlashingSubmitter)
+		if !isSubmitter {
+			return false, nil
+		}
+
+		return true, submitter.SubmitProposalSlashing(ctx, slashing)
+	})
+}
+
+// submitToAny calls submit against each node in turn until one succeeds.  submit
+// returns false if the node does not support the submission.
+func (s *multiNodeService) submitToAny(ctx context.Context,
+	name string,
+	submit func(ctx context.Context, client eth2client.Service) (bool, error),
+) error {
+	var err error
+	for _, client := range s.clients {
+		supported, submitErr := submit(ctx, client)
+		if !supported {
+			continue
+		}
+		if submitErr == nil {
+			return nil
+		}
+		err = submitErr
+	}
+	if err == nil {
+		err = fmt.Errorf("no beacon node supports submitting %s", name)
+	}
+
+	return err
+}
+
+// quorumResult is the result of a call to an individual beacon node.
+type quorumResult[T any] struct {
+	address string
+	data    T
+	err     error
+}
+
+// SetupConnectionQuorum checks the connection quorum option.  supported is true
+// if the command checks its critical information with RequireQuorum; other
+// commands reject a quorum rather than silently ignoring it.
+func SetupConnectionQuorum(supported bool) error {
+	if viper.GetInt("connection-quorum") != 0 && !supported {
+		return errors.New("connection quorum is not supported by this command")
+	}
+
+	return nil
+}
+
+// RequireQuorum carries out a call against the supplied service.  If the
+// service is connected to multiple beacon nodes with a quorum configured then
+// the call is made against each node, and an error is returned if fewer than
+// the quorum of nodes respond or if any of the responses disagree.
+func RequireQuorum[T any](ctx context.Context,
+	service eth2client.Service,
+	name string,
+	call func(ctx context.Context, client eth2client.Service) (T, error),
+	equal func(a T, b T) bool,
+) (
+	T,
+	error,
+) {
+	multiNode, isMultiNode := service.(*multiNodeService)
+	if !isMultiNode || multiNode.quorum < 2 {
+		return call(ctx, service)
+	}
+
+	results := make([]*quorumResult[T], len(multiNode.clients))
+	var wg sync.WaitGroup
+	for i, client := range multiNode.clients {
+		wg.Add(1)
+		go func(i int, client eth2client.Service) {
+			defer wg.Done()
+			data, err := call(ctx, client)
+			results[i] = &quorumResult[T]{
+				address: client.Address(),
+				data:    data,
+				err:     err,
+			}
+		}(i, client)
+	}
+	wg.Wait()
+
+	var res *quorumResult[T]
+	responses := 0
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+		responses++
+		if res == nil {
+			res = result
+			continue
+		}
+		if !equal(res.data, result.data) {
+			var empty T
+			return empty, fmt.Errorf("beacon nodes %s and %s disagree on %s; refusing to continue", res.address, result.address, name)
+		}
+	}
+	if responses < multiNode.quorum {
+		var empty T
+		return empty, fmt.Errorf("only %d beacon nodes returned %s; quorum of %d not met", responses, name, multiNode.quorum)
+	}
+
+	return res.data, nil
+}
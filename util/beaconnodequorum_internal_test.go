@@ -0,0 +1,254 @@
+// Copyright © 2025 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+// quorumClient is a mock client that returns a fixed value.
+type quorumClient struct {
+	address string
+	value   int
+	err     error
+}
+
+// Name returns the name of the client implementation.
+func (*quorumClient) Name() string {
+	return "quorum mock"
+}
+
+// Address returns the address of the client.
+func (c *quorumClient) Address() string {
+	return c.address
+}
+
+// IsActive returns true if the client is active.
+func (*quorumClient) IsActive() bool {
+	return true
+}
+
+// IsSynced returns true if the client is synced.
+func (*quorumClient) IsSynced() bool {
+	return true
+}
+
+func quorumCall(_ context.Context, client eth2client.Service) (int, error) {
+	c := client.(*quorumClient)
+
+	return c.value, c.err
+}
+
+func quorumEqual(a int, b int) bool {
+	return a == b
+}
+
+func TestRequireQuorum(t *testing.T) {
+	tests := []struct {
+		name    string
+		service eth2client.Service
+		res     int
+		err     string
+	}{
+		{
+			name:    "SingleNode",
+			service: &quorumClient{address: "a", value: 1},
+			res:     1,
+		},
+		{
+			name: "Agree",
+			service: &multiNodeService{
+				clients: []eth2client.Service{
+					&quorumClient{address: "a", value: 1},
+					&quorumClient{address: "b", value: 1},
+					&quorumClient{address: "c", value: 1},
+				},
+				quorum: 3,
+			},
+			res: 1,
+		},
+		{
+			name: "Disagree",
+			service: &multiNodeService{
+				clients: []eth2client.Service{
+					&quorumClient{address: "a", value: 1},
+					&quorumClient{address: "b", value: 1},
+					&quorumClient{address: "c", value: 2},
+				},
+				quorum: 2,
+			},
+			err: "beacon nodes a and c disagree on value; refusing to continue",
+		},
+		{
+			name: "QuorumMetWithError",
+			service: &multiNodeService{
+				clients: []eth2client.Service{
+					&quorumClient{address: "a", err: errors.New("failed")},
+					&quorumClient{address: "b", value: 1},
+					&quorumClient{address: "c", value: 1},
+				},
+				quorum: 2,
+			},
+			res: 1,
+		},
+		{
+			name: "QuorumNotMet",
+			service: &multiNodeService{
+				clients: []eth2client.Service{
+					&quorumClient{address: "a", err: errors.New("failed")},
+					&quorumClient{address: "b", err: errors.New("failed")},
+					&quorumClient{address: "c", value: 1},
+				},
+				quorum: 2,
+			},
+			err: "only 1 beacon nodes returned value; quorum of 2 not met",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := RequireQuorum(context.Background(), test.service, "value", quorumCall, quorumEqual)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestBeaconNodeAddresses(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		res   []string
+	}{
+		{
+			name:  "Single",
+			input: "http://localhost:5052",
+			res:   []string{"http://localhost:5052"},
+		},
+		{
+			name:  "Multiple",
+			input: "http://a:5052, http://b:5052,,http://c:5052",
+			res:   []string{"http://a:5052", "http://b:5052", "http://c:5052"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, beaconNodeAddresses(test.input))
+		})
+	}
+}
+
+// slashingClient is a mock client that accepts or rejects attester slashings.
+type slashingClient struct {
+	quorumClient
+	submitted bool
+}
+
+// SubmitAttesterSlashing submits an attester slashing.
+func (c *slashingClient) SubmitAttesterSlashing(_ context.Context, _ *phase0.AttesterSlashing) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.submitted = true
+
+	return nil
+}
+
+func TestSubmitAttesterSlashing(t *testing.T) {
+	rejecting := &slashingClient{quorumClient: quorumClient{address: "2", err: errors.New("rejected")}}
+	accepting := &slashingClient{quorumClient: quorumClient{address: "3"}}
+
+	tests := []struct {
+		name    string
+		clients []eth2client.Service
+		err     string
+	}{
+		{
+			name:    "Unsupported",
+			clients: []eth2client.Service{&quorumClient{address: "1"}},
+			err:     "no beacon node supports submitting attester slashings",
+		},
+		{
+			name:    "Rejected",
+			clients: []eth2client.Service{rejecting, &quorumClient{address: "1"}},
+			err:     "rejected",
+		},
+		{
+			name:    "Accepted",
+			clients: []eth2client.Service{&quorumClient{address: "1"}, rejecting, accepting},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := &multiNodeService{clients: test.clients}
+			err := service.SubmitAttesterSlashing(context.Background(), &phase0.AttesterSlashing{})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.True(t, accepting.submitted)
+			}
+		})
+	}
+}
+
+func TestSetupConnectionQuorum(t *testing.T) {
+	tests := []struct {
+		name        string
+		quorum      int
+		unsupported bool
+		err         string
+	}{
+		{
+			name:        "NoQuorum",
+			unsupported: true,
+		},
+		{
+			name:   "Supported",
+			quorum: 2,
+		},
+		{
+			name:        "Unsupported",
+			quorum:      2,
+			unsupported: true,
+			err:         "connection quorum is not supported by this command",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("connection-quorum", test.quorum)
+			err := SetupConnectionQuorum(!test.unsupported)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright © 2025 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// recordedHeaders are the response headers that are stored with recorded responses.
+var recordedHeaders = []string{
+	"Content-Type",
+	"Eth-Consensus-Version",
+	"Eth-Execution-Payload-Blinded",
+	"Eth-Execution-Payload-Value",
+	"Eth-Consensus-Block-Value",
+}
+
+// recordedResponse is a beacon node response stored in a fixture file.
+type recordedResponse struct {
+	Method     string            `json:"method"`
+	Path       string            `json:"path"`
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       []byte            `json:"body"`
+	// Stored is the time at which the response was stored in the cache.
+	Stored *time.Time `json:"stored,omitempty"`
+}
+
+// fixtures tracks the fixture files for a record or replay directory.
+type fixtures struct {
+	dir         string
+	mu          sync.Mutex
+	occurrences map[string]int
+}
+
+var (
+	sessionFixturesMu sync.Mutex
+	sessionFixtures   = make(map[string]*fixtures)
+)
+
+// obtainFixtures obtains the fixtures for a record or replay directory.  The
+// fixtures are shared by all transports in the session, as each beacon node
+// connection has its own transport and the occurrences of a request across
+// all of them must be counted together to avoid overwriting each other.
+func obtainFixtures(mode string, dir string) *fixtures {
+	sessionFixturesMu.Lock()
+	defer sessionFixturesMu.Unlock()
+
+	key := fmt.Sprintf("%s:%s", mode, filepath.Clean(dir))
+	f, exists := sessionFixtures[key]
+	if !exists {
+		f = &fixtures{
+			dir:         dir,
+			occurrences: make(map[string]int),
+		}
+		sessionFixtures[key] = f
+	}
+
+	return f
+}
+
+// fixtureKey generates the key for a request.  The key does not include the
+// host, so that fixtures can be replayed regardless of the connection used.
+func fixtureKey(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(req.URL.RequestURI()))
+	hash.Write([]byte{0})
+	hash.Write([]byte(req.Header.Get("Accept")))
+	hash.Write([]byte{0})
+	hash.Write(body)
+
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+// nextOccurrence returns the occurrence of the given key, incrementing it for the following call.
+func (f *fixtures) nextOccurrence(key string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	occurrence := f.occurrences[key]
+	f.occurrences[key] = occurrence + 1
+
+	return occurrence
+}
+
+func (f *fixtures) filename(key string, occurrence int) string {
+	return filepath.Join(f.dir, fmt.Sprintf("%s-%d.json", key, occurrence))
+}
+
+// requestBody reads the body of a request, replacing it so that it can be sent.
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read request body")
+	}
+	if err := req.Body.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close request body")
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// isStream returns true if the request is for a stream of events, which cannot be recorded.
+func isStream(req *http.Request) bool {
+	return strings.Contains(req.Header.Get("Accept"), "text/event-stream")
+}
+
+// recordingTransport is an HTTP transport that stores all beacon node responses.
+type recordingTransport struct {
+	*fixtures
+	base http.RoundTripper
+}
+
+func newRecordingTransport(dir string, base http.RoundTripper) (*recordingTransport, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.Wrap(err, "failed to create record directory")
+	}
+
+	return &recordingTransport{
+		fixtures: obtainFixtures("record", dir),
+		base:     base,
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isStream(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	reqBody, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+	if err := resp.Body.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close response body")
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded := &recordedResponse{
+		Method:     req.Method,
+		Path:       req.URL.RequestURI(),
+		StatusCode: resp.StatusCode,
+		Headers:    make(map[string]string),
+		Body:       body,
+	}
+	for _, header := range recordedHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			recorded.Headers[header] = value
+		}
+	}
+	data, err := json.Marshal(recorded)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate recorded response")
+	}
+
+	key := fixtureKey(req, reqBody)
+	if err := os.WriteFile(t.filename(key, t.nextOccurrence(key)), data, 0o600); err != nil {
+		return nil, errors.Wrap(err, "failed to write recorded response")
+	}
+
+	return resp, nil
+}
+
+// replayingTransport is an HTTP transport that returns previously recorded responses.
+type replayingTransport struct {
+	*fixtures
+}
+
+func newReplayingTransport(dir string) (*replayingTransport, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to access replay directory")
+	}
+	if !info.IsDir() {
+		return nil, errors.New("replay location is not a directory")
+	}
+
+	return &replayingTransport{
+		fixtures: obtainFixtures("replay", dir),
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *replayingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isStream(req) {
+		return nil, errors.New("event streams cannot be replayed")
+	}
+
+	reqBody, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the request was repeated fewer times when recording we fall back
+	// to the latest recorded response.
+	key := fixtureKey(req, reqBody)
+	var data []byte
+	for occurrence := t.nextOccurrence(key); occurrence >= 0; occurrence-- {
+		data, err = os.ReadFile(t.filename(key, occurrence))
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL.RequestURI())
+	}
+
+	recorded := &recordedResponse{}
+	if err := json.Unmarshal(data, recorded); err != nil {
+		return nil, errors.Wrap(err, "invalid recorded response")
+	}
+
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+	for k, v := range recorded.Headers {
+		resp.Header.Set(k, v)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,116 @@
+// Copyright © 2025 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Eth-Consensus-Version", "electra")
+		fmt.Fprintf(w, `{"path":%q,"call":%d}`, r.URL.RequestURI(), calls)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+
+	// Each beacon node connection has its own transport, so record with more than
+	// one to ensure that they do not overwrite each other's responses.
+	recordClients := make([]*http.Client, 0, 2)
+	for range 2 {
+		recorder, err := newRecordingTransport(dir, http.DefaultTransport)
+		require.NoError(t, err)
+		recordClients = append(recordClients, &http.Client{Transport: recorder})
+	}
+	for i, path := range []string{"/eth/v1/node/version", "/eth/v1/node/version", "/eth/v1/beacon/genesis"} {
+		resp, err := recordClients[i%len(recordClients)].Get(server.URL + path)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	replayClients := make([]*http.Client, 0, 2)
+	for range 2 {
+		replayer, err := newReplayingTransport(dir)
+		require.NoError(t, err)
+		replayClients = append(replayClients, &http.Client{Transport: replayer})
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		body    string
+		version string
+		err     string
+	}{
+		{
+			name:    "First",
+			path:    "/eth/v1/node/version",
+			body:    `{"path":"/eth/v1/node/version","call":1}`,
+			version: "electra",
+		},
+		{
+			name:    "Second",
+			path:    "/eth/v1/node/version",
+			body:    `{"path":"/eth/v1/node/version","call":2}`,
+			version: "electra",
+		},
+		{
+			name:    "Repeated",
+			path:    "/eth/v1/node/version",
+			body:    `{"path":"/eth/v1/node/version","call":2}`,
+			version: "electra",
+		},
+		{
+			name:    "Other",
+			path:    "/eth/v1/beacon/genesis",
+			body:    `{"path":"/eth/v1/beacon/genesis","call":3}`,
+			version: "electra",
+		},
+		{
+			name: "Missing",
+			path: "/eth/v1/node/syncing",
+			err:  "no recorded response for GET /eth/v1/node/syncing",
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Host does not matter when replaying.
+			resp, err := replayClients[i%len(replayClients)].Get("http://replay.invalid" + test.path)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Equal(t, test.body, strings.TrimSpace(string(body)))
+			require.Equal(t, test.version, resp.Header.Get("Eth-Consensus-Version"))
+		})
+	}
+	require.Equal(t, 3, calls)
+}
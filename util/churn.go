@@ -0,0 +1,165 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// FinalityDelay is the approximate number of epochs for an epoch to finalize.
+const FinalityDelay = phase0.Epoch(2)
+
+// ChurnLimits are the per-epoch balance churn limits introduced in Electra.
+type ChurnLimits struct {
+	// Balance is the total balance churn limit.
+	Balance phase0.Gwei
+	// ActivationExit is the churn limit shared by deposits and exits.
+	ActivationExit phase0.Gwei
+	// Consolidation is the churn limit for consolidations.
+	Consolidation phase0.Gwei
+}
+
+// CalculateChurnLimits calculates the churn limits given the chain's spec and
+// total active balance.  Values missing from the spec are taken from mainnet.
+func CalculateChurnLimits(spec map[string]any, totalActiveBalance phase0.Gwei) *ChurnLimits {
+	minPerEpochChurnLimit := specGwei(spec, "MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA", 128000000000)
+	maxPerEpochActivationExitChurnLimit := specGwei(spec, "MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT", 256000000000)
+	effectiveBalanceIncrement := specGwei(spec, "EFFECTIVE_BALANCE_INCREMENT", 1000000000)
+	churnLimitQuotient := uint64(65536)
+	if val, exists := spec["CHURN_LIMIT_QUOTIENT"].(uint64); exists && val > 0 {
+		churnLimitQuotient = val
+	}
+
+	if totalActiveBalance < effectiveBalanceIncrement {
+		totalActiveBalance = effectiveBalanceIncrement
+	}
+
+	balance := max(minPerEpochChurnLimit, totalActiveBalance/phase0.Gwei(churnLimitQuotient))
+	balance -= balance % effectiveBalanceIncrement
+	activationExit := min(maxPerEpochActivationExitChurnLimit, balance)
+
+	return &ChurnLimits{
+		Balance:        balance,
+		ActivationExit: activationExit,
+		Consolidation:  balance - activationExit,
+	}
+}
+
+// EpochsToProcess returns the number of epochs required to process the
+// given balance at the given per-epoch churn.
+func EpochsToProcess(balance phase0.Gwei, churn phase0.Gwei) phase0.Epoch {
+	if balance == 0 || churn == 0 {
+		return 0
+	}
+
+	return phase0.Epoch((balance-1)/churn + 1)
+}
+
+// ExitQueue projects the exit epochs of validators, following the
+// balance-based exit churn introduced in Electra.
+type ExitQueue struct {
+	churn                phase0.Gwei
+	earliestExitEpoch    phase0.Epoch
+	exitBalanceToConsume phase0.Gwei
+}
+
+// NewExitQueue creates an exit queue from the current state of the validators.
+// The chain's earliest exit epoch and remaining exit balance are not exposed
+// by beacon nodes, so they are approximated from the furthest scheduled exit.
+func NewExitQueue(validators map[phase0.ValidatorIndex]*apiv1.Validator, churn phase0.Gwei) *ExitQueue {
+	queue := &ExitQueue{
+		churn: churn,
+	}
+
+	consumed := phase0.Gwei(0)
+	for _, validator := range validators {
+		if validator.Validator == nil {
+			continue
+		}
+		exitEpoch := validator.Validator.ExitEpoch
+		switch {
+		case exitEpoch == farFutureEpoch:
+		case exitEpoch > queue.earliestExitEpoch:
+			queue.earliestExitEpoch = exitEpoch
+			consumed = validator.Validator.EffectiveBalance
+		case exitEpoch == queue.earliestExitEpoch:
+			consumed += validator.Validator.EffectiveBalance
+		}
+	}
+	if consumed < churn {
+		queue.exitBalanceToConsume = churn - consumed
+	}
+
+	return queue
+}
+
+// Exit adds the exit of a validator with the given effective balance to the queue,
+// returning its projected exit epoch.  activationExitEpoch is the earliest epoch at
+// which the exit could take place, based on the epoch in which it is processed.
+func (q *ExitQueue) Exit(balance phase0.Gwei, activationExitEpoch phase0.Epoch) phase0.Epoch {
+	if q.earliestExitEpoch < activationExitEpoch {
+		q.earliestExitEpoch = activationExitEpoch
+		q.exitBalanceToConsume = q.churn
+	}
+	if balance > q.exitBalanceToConsume {
+		additionalEpochs := EpochsToProcess(balance-q.exitBalanceToConsume, q.churn)
+		q.earliestExitEpoch += additionalEpochs
+		q.exitBalanceToConsume += phase0.Gwei(additionalEpochs) * q.churn
+	}
+	q.exitBalanceToConsume -= balance
+
+	return q.earliestExitEpoch
+}
+
+// PendingDepositEpochs estimates the epochs at which pending deposits will be
+// processed, returning an epoch for each deposit.  Deposits are processed in
+// order, limited by the activation and exit churn, the maximum number of
+// deposits per epoch and the requirement that the deposit has been finalized.
+func PendingDepositEpochs(deposits []*electra.PendingDeposit,
+	currentEpoch phase0.Epoch,
+	churn phase0.Gwei,
+	maxPendingDepositsPerEpoch uint64,
+	slotToEpoch func(phase0.Slot) phase0.Epoch,
+) []phase0.Epoch {
+	if maxPendingDepositsPerEpoch == 0 {
+		return nil
+	}
+
+	epochs := make([]phase0.Epoch, len(deposits))
+	cumulativeBalance := phase0.Gwei(0)
+	epoch := currentEpoch
+	for i, deposit := range deposits {
+		cumulativeBalance += deposit.Amount
+		epoch = max(epoch,
+			currentEpoch+EpochsToProcess(cumulativeBalance, churn),
+			currentEpoch+phase0.Epoch(uint64(i)/maxPendingDepositsPerEpoch),
+		)
+		if deposit.Slot > 0 {
+			epoch = max(epoch, slotToEpoch(deposit.Slot)+FinalityDelay+1)
+		}
+		epochs[i] = epoch
+	}
+
+	return epochs
+}
+
+func specGwei(spec map[string]any, name string, defaultValue phase0.Gwei) phase0.Gwei {
+	if val, exists := spec[name].(uint64); exists {
+		return phase0.Gwei(val)
+	}
+
+	return defaultValue
+}
@@ -0,0 +1,161 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateChurnLimits(t *testing.T) {
+	tests := []struct {
+		name               string
+		spec               map[string]any
+		totalActiveBalance phase0.Gwei
+		res                *ChurnLimits
+	}{
+		{
+			name:               "Minimum",
+			spec:               map[string]any{},
+			totalActiveBalance: 1000000000000000,
+			res: &ChurnLimits{
+				Balance:        128000000000,
+				ActivationExit: 128000000000,
+				Consolidation:  0,
+			},
+		},
+		{
+			name:               "Mainnet",
+			spec:               map[string]any{},
+			totalActiveBalance: 34000000000000000,
+			res: &ChurnLimits{
+				Balance:        518000000000,
+				ActivationExit: 256000000000,
+				Consolidation:  262000000000,
+			},
+		},
+		{
+			name: "Spec",
+			spec: map[string]any{
+				"MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA":         uint64(64000000000),
+				"MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT": uint64(128000000000),
+				"CHURN_LIMIT_QUOTIENT":                      uint64(32),
+				"EFFECTIVE_BALANCE_INCREMENT":               uint64(1000000000),
+			},
+			totalActiveBalance: 6400000000000,
+			res: &ChurnLimits{
+				Balance:        200000000000,
+				ActivationExit: 128000000000,
+				Consolidation:  72000000000,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, CalculateChurnLimits(test.spec, test.totalActiveBalance))
+		})
+	}
+}
+
+func TestEpochsToProcess(t *testing.T) {
+	require.Equal(t, phase0.Epoch(0), EpochsToProcess(0, 256000000000))
+	require.Equal(t, phase0.Epoch(0), EpochsToProcess(32000000000, 0))
+	require.Equal(t, phase0.Epoch(1), EpochsToProcess(32000000000, 256000000000))
+	require.Equal(t, phase0.Epoch(1), EpochsToProcess(256000000000, 256000000000))
+	require.Equal(t, phase0.Epoch(2), EpochsToProcess(256000000001, 256000000000))
+}
+
+func TestPendingDepositEpochs(t *testing.T) {
+	slotToEpoch := func(slot phase0.Slot) phase0.Epoch { return phase0.Epoch(slot / 32) }
+	deposits := []*electra.PendingDeposit{
+		{Amount: 32000000000},
+		{Amount: 256000000000},
+		{Amount: 32000000000},
+		// Not yet finalized.
+		{Amount: 1000000000, Slot: 3520},
+	}
+
+	require.Nil(t, PendingDepositEpochs(deposits, 100, 256000000000, 0, slotToEpoch))
+	require.Equal(t, []phase0.Epoch{101, 102, 102, 113}, PendingDepositEpochs(deposits, 100, 256000000000, 16, slotToEpoch))
+	// Limited by the number of deposits per epoch rather than the churn.
+	require.Equal(t, []phase0.Epoch{101, 101, 101, 113}, PendingDepositEpochs(deposits, 100, 1000000000000, 16, slotToEpoch))
+	require.Equal(t, []phase0.Epoch{101, 101, 102, 113}, PendingDepositEpochs(deposits, 100, 1000000000000, 1, slotToEpoch))
+}
+
+func TestExitQueue(t *testing.T) {
+	tests := []struct {
+		name       string
+		validators map[phase0.ValidatorIndex]*apiv1.Validator
+		balances   []phase0.Gwei
+		epochs     []phase0.Epoch
+	}{
+		{
+			name:     "NoExits",
+			balances: []phase0.Gwei{32000000000},
+			epochs:   []phase0.Epoch{105},
+		},
+		{
+			name:     "NoExitsLargeBalance",
+			balances: []phase0.Gwei{2048000000000},
+			epochs:   []phase0.Epoch{112},
+		},
+		{
+			name: "ExitsBeforeEarliest",
+			validators: map[phase0.ValidatorIndex]*apiv1.Validator{
+				1: {Validator: &phase0.Validator{ExitEpoch: 104, EffectiveBalance: 256000000000}},
+			},
+			balances: []phase0.Gwei{32000000000},
+			epochs:   []phase0.Epoch{105},
+		},
+		{
+			name: "ExitsChurnRemaining",
+			validators: map[phase0.ValidatorIndex]*apiv1.Validator{
+				1: {Validator: &phase0.Validator{ExitEpoch: 110, EffectiveBalance: 32000000000}},
+				2: {Validator: &phase0.Validator{ExitEpoch: 110, EffectiveBalance: 32000000000}},
+				3: {Validator: &phase0.Validator{ExitEpoch: farFutureEpoch, EffectiveBalance: 32000000000}},
+			},
+			balances: []phase0.Gwei{32000000000},
+			epochs:   []phase0.Epoch{110},
+		},
+		{
+			name: "ExitsChurnConsumed",
+			validators: map[phase0.ValidatorIndex]*apiv1.Validator{
+				1: {Validator: &phase0.Validator{ExitEpoch: 110, EffectiveBalance: 2048000000000}},
+			},
+			balances: []phase0.Gwei{32000000000},
+			epochs:   []phase0.Epoch{111},
+		},
+		{
+			name:     "Multiple",
+			balances: []phase0.Gwei{128000000000, 128000000000, 32000000000, 2048000000000},
+			epochs:   []phase0.Epoch{105, 105, 106, 114},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			queue := NewExitQueue(test.validators, 256000000000)
+			epochs := make([]phase0.Epoch, 0, len(test.balances))
+			for _, balance := range test.balances {
+				epochs = append(epochs, queue.Exit(balance, 105))
+			}
+			require.Equal(t, test.epochs, epochs)
+		})
+	}
+}
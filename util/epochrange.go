@@ -0,0 +1,166 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+// ParseEpochRange parses input to calculate the desired range of epochs.
+// If to is not supplied it defaults to the last complete epoch.  If from is
+// not supplied it is calculated from since if present, otherwise it defaults
+// to the same epoch as to.
+func ParseEpochRange(ctx context.Context,
+	chainTime chaintime.Service,
+	fromStr string,
+	toStr string,
+	sinceStr string,
+) (
+	phase0.Epoch,
+	phase0.Epoch,
+	error,
+) {
+	if fromStr != "" && sinceStr != "" {
+		return 0, 0, errors.New("only one of from epoch and since can be supplied")
+	}
+
+	if toStr == "" {
+		toStr = "last"
+	}
+	to, err := ParseEpoch(ctx, chainTime, toStr)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to parse to epoch")
+	}
+
+	from := to
+	switch {
+	case fromStr != "":
+		from, err = ParseEpoch(ctx, chainTime, fromStr)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "failed to parse from epoch")
+		}
+	case sinceStr != "":
+		since, err := ParseDuration(sinceStr)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "failed to parse since")
+		}
+		start := time.Now().Add(-since)
+		if start.Before(chainTime.GenesisTime()) {
+			from = 0
+		} else {
+			from = chainTime.TimestampToEpoch(start)
+		}
+	}
+
+	if from > to {
+		return 0, 0, errors.New("from epoch cannot be after to epoch")
+	}
+
+	return from, to, nil
+}
+
+// ParseDuration parses a duration.  In addition to the units supported by
+// time.ParseDuration it supports whole days ("d") and weeks ("w").
+func ParseDuration(input string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	} {
+		if !strings.HasSuffix(input, suffix) {
+			continue
+		}
+		val, err := strconv.ParseUint(strings.TrimSuffix(input, suffix), 10, 32)
+		if err != nil {
+			return 0, errors.Wrap(err, "invalid duration")
+		}
+
+		return time.Duration(val) * unit, nil
+	}
+
+	duration, err := time.ParseDuration(input)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid duration")
+	}
+	if duration < 0 {
+		return 0, errors.New("duration cannot be negative")
+	}
+
+	return duration, nil
+}
+
+// ForEachEpoch calls the supplied function for each epoch in the range from
+// to to inclusive, using at most the given number of concurrent workers.  It
+// returns the first error encountered, after which no further epochs are started.
+func ForEachEpoch(ctx context.Context,
+	from phase0.Epoch,
+	to phase0.Epoch,
+	workers int,
+	fn func(ctx context.Context, epoch phase0.Epoch) error,
+) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errMu    sync.Mutex
+		firstErr error
+	)
+	epochs := make(chan phase0.Epoch)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for epoch := range epochs {
+				if err := fn(ctx, epoch); err != nil {
+					errMu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					errMu.Unlock()
+					cancel()
+				}
+			}
+		}()
+	}
+
+feed:
+	for epoch := from; epoch <= to; epoch++ {
+		select {
+		case <-ctx.Done():
+			break feed
+		case epochs <- epoch:
+		}
+	}
+	close(epochs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	// Will only be set if the parent context was cancelled.
+	return ctx.Err()
+}
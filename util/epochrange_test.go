@@ -0,0 +1,240 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/mock"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestParseEpochRange(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient, err := mock.New(ctx)
+	require.NoError(t, err)
+	// genesis is 1 day ago.
+	genesisTime := time.Now().AddDate(0, 0, -1)
+	mockClient.GenesisFunc = func(context.Context, *api.GenesisOpts) (*api.Response[*apiv1.Genesis], error) {
+		return &api.Response[*apiv1.Genesis]{
+			Data: &apiv1.Genesis{
+				GenesisTime: genesisTime,
+			},
+			Metadata: make(map[string]any),
+		}, nil
+	}
+	mockClient.SpecFunc = func(context.Context, *api.SpecOpts) (*api.Response[map[string]any], error) {
+		return &api.Response[map[string]any]{
+			Data: map[string]any{
+				"SECONDS_PER_SLOT": time.Second * 12,
+				"SLOTS_PER_EPOCH":  uint64(32),
+			},
+			Metadata: make(map[string]any),
+		}, nil
+	}
+	chainTime, err := standardchaintime.New(context.Background(),
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mockClient),
+		standardchaintime.WithSpecProvider(mockClient),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		from  string
+		to    string
+		since string
+		err   string
+		first phase0.Epoch
+		last  phase0.Epoch
+	}{
+		{
+			name:  "Default",
+			first: 224,
+			last:  224,
+		},
+		{
+			name:  "From",
+			from:  "200",
+			first: 200,
+			last:  224,
+		},
+		{
+			name:  "FromTo",
+			from:  "100",
+			to:    "110",
+			first: 100,
+			last:  110,
+		},
+		{
+			name:  "Relative",
+			from:  "-10",
+			to:    "-5",
+			first: 215,
+			last:  220,
+		},
+		{
+			name:  "SinceHours",
+			since: "6h",
+			first: 168,
+			last:  224,
+		},
+		{
+			name:  "SinceBeforeGenesis",
+			since: "7d",
+			first: 0,
+			last:  224,
+		},
+		{
+			name: "FromInvalid",
+			from: "invalid",
+			err:  `failed to parse from epoch: failed to parse epoch: strconv.ParseInt: parsing "invalid": invalid syntax`,
+		},
+		{
+			name: "ToInvalid",
+			to:   "invalid",
+			err:  `failed to parse to epoch: failed to parse epoch: strconv.ParseInt: parsing "invalid": invalid syntax`,
+		},
+		{
+			name:  "SinceInvalid",
+			since: "xd",
+			err:   `failed to parse since: invalid duration: strconv.ParseUint: parsing "x": invalid syntax`,
+		},
+		{
+			name:  "FromAndSince",
+			from:  "10",
+			since: "1d",
+			err:   "only one of from epoch and since can be supplied",
+		},
+		{
+			name: "FromAfterTo",
+			from: "20",
+			to:   "10",
+			err:  "from epoch cannot be after to epoch",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first, last, err := util.ParseEpochRange(ctx, chainTime, test.from, test.to, test.since)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.first, first)
+				require.Equal(t, test.last, last)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		err      string
+		expected time.Duration
+	}{
+		{
+			input:    "90m",
+			expected: 90 * time.Minute,
+		},
+		{
+			input:    "7d",
+			expected: 7 * 24 * time.Hour,
+		},
+		{
+			input:    "2w",
+			expected: 14 * 24 * time.Hour,
+		},
+		{
+			input: "-1h",
+			err:   "duration cannot be negative",
+		},
+		{
+			input: "-1d",
+			err:   `invalid duration: strconv.ParseUint: parsing "-1": invalid syntax`,
+		},
+		{
+			input: "abc",
+			err:   `invalid duration: time: invalid duration "abc"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			duration, err := util.ParseDuration(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, duration)
+			}
+		})
+	}
+}
+
+func TestForEachEpoch(t *testing.T) {
+	ctx := context.Background()
+
+	// All epochs processed, with bounded concurrency.
+	var (
+		mu        sync.Mutex
+		seen      = make(map[phase0.Epoch]struct{})
+		active    atomic.Int32
+		maxActive atomic.Int32
+	)
+	require.NoError(t, util.ForEachEpoch(ctx, 10, 29, 3, func(_ context.Context, epoch phase0.Epoch) error {
+		current := active.Add(1)
+		defer active.Add(-1)
+		for {
+			highest := maxActive.Load()
+			if current <= highest || maxActive.CompareAndSwap(highest, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		seen[epoch] = struct{}{}
+		mu.Unlock()
+
+		return nil
+	}))
+	require.Len(t, seen, 20)
+	require.LessOrEqual(t, maxActive.Load(), int32(3))
+
+	// Errors stop processing.
+	var processed atomic.Int32
+	err := util.ForEachEpoch(ctx, 0, 1000, 2, func(_ context.Context, epoch phase0.Epoch) error {
+		processed.Add(1)
+		if epoch == 5 {
+			return errors.New("bad epoch")
+		}
+
+		return nil
+	})
+	require.EqualError(t, err, "bad epoch")
+	require.Less(t, processed.Load(), int32(1000))
+}
@@ -0,0 +1,221 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+)
+
+// ErrorCode is a stable code for the category of an error, suitable for
+// use by scripts that wrap ethdo.
+type ErrorCode string
+
+// Error codes.
+const (
+	ErrorCodeGeneral              ErrorCode = "general"
+	ErrorCodeInvalidInput         ErrorCode = "invalid_input"
+	ErrorCodeConnectionFailed     ErrorCode = "connection_failed"
+	ErrorCodeNodeSyncing          ErrorCode = "node_syncing"
+	ErrorCodeTimeout              ErrorCode = "timeout"
+	ErrorCodeValidatorNotFound    ErrorCode = "validator_not_found"
+	ErrorCodeInvalidPassphrase    ErrorCode = "invalid_passphrase"
+	ErrorCodeWrongCredentialsType ErrorCode = "wrong_credentials_type"
+	ErrorCodeNotEligibleToExit    ErrorCode = "not_eligible_to_exit"
+	ErrorCodeBatchFailed          ErrorCode = "batch_failed"
+)
+
+// exitCodes are the process exit codes for each error code.
+var exitCodes = map[ErrorCode]int{
+	ErrorCodeGeneral:              1,
+	ErrorCodeInvalidInput:         2,
+	ErrorCodeConnectionFailed:     3,
+	ErrorCodeNodeSyncing:          4,
+	ErrorCodeTimeout:              5,
+	ErrorCodeValidatorNotFound:    6,
+	ErrorCodeInvalidPassphrase:    7,
+	ErrorCodeWrongCredentialsType: 8,
+	ErrorCodeNotEligibleToExit:    9,
+	ErrorCodeBatchFailed:          10,
+}
+
+// Error is an error with a code and optional context.
+type Error struct {
+	// Code is the category of the error.
+	Code ErrorCode
+	// Context contains additional information about the error, for
+	// example the validator that could not be found.
+	Context map[string]any
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the message of the underlying error.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NewError creates a new error with the given code.
+func NewError(code ErrorCode, message string) error {
+	return &Error{
+		Code: code,
+		Err:  errors.New(message),
+	}
+}
+
+// ErrorWithCode adds a code and optional context to an existing error.
+// It returns nil if the error is nil.
+func ErrorWithCode(err error, code ErrorCode, context map[string]any) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{
+		Code:    code,
+		Context: context,
+		Err:     err,
+	}
+}
+
+// ErrorCodeOf returns the code for an error.  If there are multiple codes
+// in the error chain then the one closest to the source of the error is
+// used, so that a specific code such as validator_not_found is not hidden
+// by a general code added by a caller.
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+
+	code, _ := findErrorCode(err, 0)
+	if code == "" {
+		return ErrorCodeGeneral
+	}
+
+	return code
+}
+
+// findErrorCode finds the deepest code in the error chain, returning it
+// along with its depth.
+func findErrorCode(err error, depth int) (ErrorCode, int) {
+	var code ErrorCode
+	codeDepth := -1
+
+	// Well-known errors from other packages have their own codes.
+	//nolint:errorlint
+	switch err {
+	case eth2client.ErrNotSynced:
+		code, codeDepth = ErrorCodeNodeSyncing, depth
+	case eth2client.ErrNotActive:
+		code, codeDepth = ErrorCodeConnectionFailed, depth
+	case context.DeadlineExceeded:
+		code, codeDepth = ErrorCodeTimeout, depth
+	}
+	if e, isError := err.(*Error); isError {
+		code, codeDepth = e.Code, depth
+	}
+
+	var children []error
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		if child := e.Unwrap(); child != nil {
+			children = []error{child}
+		}
+	case interface{ Unwrap() []error }:
+		children = e.Unwrap()
+	}
+	for _, child := range children {
+		childCode, childDepth := findErrorCode(child, depth+1)
+		if childCode != "" && childDepth > codeDepth {
+			code, codeDepth = childCode, childDepth
+		}
+	}
+
+	return code, codeDepth
+}
+
+// ErrorContext returns the context for an error, merged from all errors
+// in the chain.  It always returns a map, which may be empty.
+func ErrorContext(err error) map[string]any {
+	res := make(map[string]any)
+	mergeErrorContext(err, res)
+
+	return res
+}
+
+func mergeErrorContext(err error, res map[string]any) {
+	if err == nil {
+		return
+	}
+
+	// Context closer to the source of the error takes precedence, so
+	// merge this after the children.
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		mergeErrorContext(e.Unwrap(), res)
+	case interface{ Unwrap() []error }:
+		for _, child := range e.Unwrap() {
+			mergeErrorContext(child, res)
+		}
+	}
+	if e, isError := err.(*Error); isError {
+		for k, v := range e.Context {
+			if _, exists := res[k]; !exists {
+				res[k] = v
+			}
+		}
+	}
+}
+
+// ExitCode returns the process exit code for an error.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	return exitCodes[ErrorCodeOf(err)]
+}
+
+// FormatError formats an error for output.  If JSON output is requested
+// this is a JSON object with the code, message and context of the error,
+// otherwise it is the error message.
+func FormatError(err error) string {
+	if OutputFormat() != OutputFormatJSON {
+		return err.Error()
+	}
+
+	data, jsonErr := json.Marshal(&struct {
+		Code    ErrorCode      `json:"code"`
+		Message string         `json:"message"`
+		Context map[string]any `json:"context"`
+	}{
+		Code:    ErrorCodeOf(err),
+		Message: strings.ReplaceAll(err.Error(), "\n", ": "),
+		Context: ErrorContext(err),
+	})
+	if jsonErr != nil {
+		// Should not happen, but fall back to the plain message.
+		return err.Error()
+	}
+
+	return string(data)
+}
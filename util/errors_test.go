@@ -0,0 +1,178 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		code     util.ErrorCode
+		exitCode int
+	}{
+		{
+			name:     "Nil",
+			exitCode: 0,
+		},
+		{
+			name:     "Plain",
+			err:      errors.New("plain"),
+			code:     util.ErrorCodeGeneral,
+			exitCode: 1,
+		},
+		{
+			name:     "Coded",
+			err:      util.NewError(util.ErrorCodeValidatorNotFound, "unknown validator"),
+			code:     util.ErrorCodeValidatorNotFound,
+			exitCode: 6,
+		},
+		{
+			name:     "Wrapped",
+			err:      pkgerrors.Wrap(util.NewError(util.ErrorCodeInvalidPassphrase, "failed to unlock account"), "failed to sign"),
+			code:     util.ErrorCodeInvalidPassphrase,
+			exitCode: 7,
+		},
+		{
+			name:     "Formatted",
+			err:      fmt.Errorf("failed to connect: %w", util.NewError(util.ErrorCodeConnectionFailed, "connection refused")),
+			code:     util.ErrorCodeConnectionFailed,
+			exitCode: 3,
+		},
+		{
+			name: "DeepestWins",
+			err: util.ErrorWithCode(
+				errors.Join(errors.New("failed to set up command"), util.NewError(util.ErrorCodeConnectionFailed, "failed to connect")),
+				util.ErrorCodeInvalidInput,
+				nil,
+			),
+			code:     util.ErrorCodeConnectionFailed,
+			exitCode: 3,
+		},
+		{
+			name:     "OuterOnly",
+			err:      util.ErrorWithCode(errors.Join(errors.New("failed to set up command"), errors.New("bad")), util.ErrorCodeInvalidInput, nil),
+			code:     util.ErrorCodeInvalidInput,
+			exitCode: 2,
+		},
+		{
+			name:     "NotSynced",
+			err:      errors.Join(errors.New("failed to process"), pkgerrors.Wrap(eth2client.ErrNotSynced, "failed to obtain duties")),
+			code:     util.ErrorCodeNodeSyncing,
+			exitCode: 4,
+		},
+		{
+			name:     "NotSyncedInsideConnection",
+			err:      util.ErrorWithCode(pkgerrors.Wrap(eth2client.ErrNotSynced, "failed to connect to beacon node"), util.ErrorCodeConnectionFailed, nil),
+			code:     util.ErrorCodeNodeSyncing,
+			exitCode: 4,
+		},
+		{
+			name:     "DeadlineExceeded",
+			err:      pkgerrors.Wrap(context.DeadlineExceeded, "failed to obtain validators"),
+			code:     util.ErrorCodeTimeout,
+			exitCode: 5,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.code, util.ErrorCodeOf(test.err))
+			require.Equal(t, test.exitCode, util.ExitCode(test.err))
+		})
+	}
+}
+
+func TestErrorWithCodeNil(t *testing.T) {
+	require.NoError(t, util.ErrorWithCode(nil, util.ErrorCodeGeneral, nil))
+}
+
+func TestErrorMessage(t *testing.T) {
+	err := util.ErrorWithCode(errors.New("unknown validator"), util.ErrorCodeValidatorNotFound, map[string]any{"validator": "1"})
+	require.EqualError(t, err, "unknown validator")
+
+	var codedErr *util.Error
+	require.ErrorAs(t, pkgerrors.Wrap(err, "failed"), &codedErr)
+	require.Equal(t, util.ErrorCodeValidatorNotFound, codedErr.Code)
+}
+
+func TestErrorContext(t *testing.T) {
+	err := util.ErrorWithCode(
+		errors.Join(
+			errors.New("failed to process"),
+			util.ErrorWithCode(errors.New("unknown validator"), util.ErrorCodeValidatorNotFound, map[string]any{"validator": "inner", "index": 1}),
+		),
+		util.ErrorCodeGeneral,
+		map[string]any{"validator": "outer", "command": "exit"},
+	)
+	require.Equal(t, map[string]any{"validator": "inner", "index": 1, "command": "exit"}, util.ErrorContext(err))
+	require.Equal(t, map[string]any{}, util.ErrorContext(errors.New("plain")))
+}
+
+func TestFormatError(t *testing.T) {
+	err := errors.Join(
+		errors.New("failed to process"),
+		util.ErrorWithCode(errors.New("unknown validator"), util.ErrorCodeValidatorNotFound, map[string]any{"validator": "0x01"}),
+	)
+
+	tests := []struct {
+		name   string
+		vars   map[string]interface{}
+		err    error
+		output string
+	}{
+		{
+			name:   "Text",
+			err:    err,
+			output: "failed to process\nunknown validator",
+		},
+		{
+			name: "JSON",
+			vars: map[string]interface{}{
+				"json": true,
+			},
+			err:    err,
+			output: `{"code":"validator_not_found","message":"failed to process: unknown validator","context":{"validator":"0x01"}}`,
+		},
+		{
+			name: "JSONNoContext",
+			vars: map[string]interface{}{
+				"format": "json",
+			},
+			err:    errors.New("plain"),
+			output: `{"code":"general","message":"plain","context":{}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			require.Equal(t, test.output, util.FormatError(test.err))
+		})
+	}
+}
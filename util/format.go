@@ -0,0 +1,325 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats.
+const (
+	OutputFormatText = "text"
+	OutputFormatJSON = "json"
+	OutputFormatYAML = "yaml"
+	OutputFormatCSV  = "csv"
+)
+
+// FormatOpts are the options for FormatOutput.
+type FormatOpts struct {
+	// Format is the output format, as returned by OutputFormat.
+	Format string
+	// Template is an optional Go template to apply to the JSON output.
+	Template string
+	// JSON generates JSON output.  This is required, as it is also the
+	// data model from which YAML, CSV and template output are generated.
+	JSON func(ctx context.Context) (string, error)
+	// Text generates text output.
+	Text func(ctx context.Context) (string, error)
+	// CSV generates CSV output.  If not supplied, CSV output is
+	// generated from the JSON output.
+	CSV func(ctx context.Context) (string, error)
+}
+
+// OutputFormat returns the output format requested by the user.
+// The --json flag is treated as --format=json.
+func OutputFormat() string {
+	format := strings.ToLower(viper.GetString("format"))
+	if format == "" {
+		if viper.GetBool("json") {
+			return OutputFormatJSON
+		}
+
+		return OutputFormatText
+	}
+
+	return format
+}
+
+// SetupOutputFormat checks the output format options.  supported is true if
+// the command generates its output with FormatOutput; other commands generate
+// text output, or JSON output with --json where available, so reject any other
+// format rather than silently ignoring it.
+func SetupOutputFormat(supported bool) error {
+	format := strings.ToLower(viper.GetString("format"))
+	switch format {
+	case "", OutputFormatText, OutputFormatJSON, OutputFormatYAML, OutputFormatCSV:
+	default:
+		return fmt.Errorf("unsupported output format %q; supported formats are text, json, yaml and csv", format)
+	}
+
+	if !supported {
+		if format != "" && format != OutputFormatText {
+			return fmt.Errorf("%s output is not supported by this command; use --json where available", format)
+		}
+		if viper.GetString("template") != "" {
+			return errors.New("template output is not supported by this command")
+		}
+
+		return nil
+	}
+
+	if viper.GetString("template") != "" && OutputFormat() != OutputFormatText {
+		return fmt.Errorf("template cannot be used with %s output", OutputFormat())
+	}
+
+	return nil
+}
+
+// FormatOutput generates output in the format requested by the user.
+func FormatOutput(ctx context.Context, opts *FormatOpts) (string, error) {
+	if opts == nil {
+		return "", errors.New("no options specified")
+	}
+	if opts.JSON == nil {
+		return "", errors.New("no JSON output specified")
+	}
+
+	if opts.Template != "" {
+		data, err := opts.JSON(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		return JSONToTemplate(data, opts.Template)
+	}
+
+	switch opts.Format {
+	case OutputFormatJSON:
+		return opts.JSON(ctx)
+	case OutputFormatYAML:
+		data, err := opts.JSON(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		return JSONToYAML(data)
+	case OutputFormatCSV:
+		if opts.CSV != nil {
+			return opts.CSV(ctx)
+		}
+		data, err := opts.JSON(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		return JSONToCSV(data)
+	default:
+		if opts.Text == nil {
+			return opts.JSON(ctx)
+		}
+
+		return opts.Text(ctx)
+	}
+}
+
+// JSONToYAML converts JSON to YAML, retaining the order of fields.
+func JSONToYAML(data string) (string, error) {
+	node, err := parseJSONNode(data)
+	if err != nil {
+		return "", err
+	}
+	resetNodeStyle(node)
+
+	buf := new(bytes.Buffer)
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return "", errors.Wrap(err, "failed to generate YAML")
+	}
+	if err := encoder.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to generate YAML")
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// JSONToCSV converts JSON to CSV.  An array is output with one row per element,
+// and anything else as a single row.  Nested objects are flattened, with their
+// column names joined by '.', and arrays of values are joined by ';'.
+func JSONToCSV(data string) (string, error) {
+	node, err := parseJSONNode(data)
+	if err != nil {
+		return "", err
+	}
+
+	elements := []*yaml.Node{node}
+	if node.Kind == yaml.SequenceNode {
+		elements = node.Content
+	}
+
+	columns := make([]string, 0)
+	known := make(map[string]bool)
+	rows := make([]map[string]string, 0, len(elements))
+	for _, element := range elements {
+		row := make(map[string]string)
+		for _, cell := range flattenNode("", element) {
+			if !known[cell.column] {
+				known[cell.column] = true
+				columns = append(columns, cell.column)
+			}
+			row[cell.column] = cell.value
+		}
+		rows = append(rows, row)
+	}
+
+	buf := new(bytes.Buffer)
+	writer := csv.NewWriter(buf)
+	if err := writer.Write(columns); err != nil {
+		return "", errors.Wrap(err, "failed to write CSV header")
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = row[column]
+		}
+		if err := writer.Write(record); err != nil {
+			return "", errors.Wrap(err, "failed to write CSV row")
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", errors.Wrap(err, "failed to generate CSV")
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// JSONToTemplate executes a Go template against JSON.  Fields in the template
+// are referenced by their JSON names, for example {{.epoch}}.
+func JSONToTemplate(data string, tmpl string) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	var input any
+	if err := decoder.Decode(&input); err != nil {
+		return "", errors.Wrap(err, "failed to parse JSON")
+	}
+
+	t, err := template.New("output").Parse(tmpl)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid template")
+	}
+
+	builder := strings.Builder{}
+	if err := t.Execute(&builder, input); err != nil {
+		return "", errors.Wrap(err, "failed to execute template")
+	}
+
+	return builder.String(), nil
+}
+
+// parseJSONNode parses JSON in to a YAML node, which retains the order of fields.
+func parseJSONNode(data string) (*yaml.Node, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(data), doc); err != nil {
+		return nil, errors.Wrap(err, "failed to parse JSON")
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) != 1 {
+		return nil, errors.New("failed to parse JSON")
+	}
+
+	return doc.Content[0], nil
+}
+
+// resetNodeStyle removes the JSON styling from a node, so that it is output as block YAML.
+func resetNodeStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetNodeStyle(child)
+	}
+}
+
+// csvCell is a single cell of a CSV row.
+type csvCell struct {
+	column string
+	value  string
+}
+
+// flattenNode flattens a node in to cells.
+func flattenNode(prefix string, node *yaml.Node) []*csvCell {
+	switch node.Kind {
+	case yaml.MappingNode:
+		res := make([]*csvCell, 0)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			column := node.Content[i].Value
+			if prefix != "" {
+				column = fmt.Sprintf("%s.%s", prefix, column)
+			}
+			res = append(res, flattenNode(column, node.Content[i+1])...)
+		}
+
+		return res
+	case yaml.SequenceNode:
+		scalars := true
+		for _, child := range node.Content {
+			if child.Kind != yaml.ScalarNode {
+				scalars = false
+				break
+			}
+		}
+		if !scalars {
+			res := make([]*csvCell, 0)
+			for i, child := range node.Content {
+				res = append(res, flattenNode(fmt.Sprintf("%s.%d", prefix, i), child)...)
+			}
+
+			return res
+		}
+		values := make([]string, len(node.Content))
+		for i, child := range node.Content {
+			values[i] = scalarValue(child)
+		}
+
+		return []*csvCell{{column: columnName(prefix), value: strings.Join(values, ";")}}
+	default:
+		return []*csvCell{{column: columnName(prefix), value: scalarValue(node)}}
+	}
+}
+
+// columnName returns the name of a column, defaulting to "value" for top-level scalars.
+func columnName(prefix string) string {
+	if prefix == "" {
+		return "value"
+	}
+
+	return prefix
+}
+
+// scalarValue returns the value of a scalar node, with null as an empty string.
+func scalarValue(node *yaml.Node) string {
+	if node.Tag == "!!null" {
+		return ""
+	}
+
+	return node.Value
+}
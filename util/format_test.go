@@ -0,0 +1,273 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestSetupOutputFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		vars        map[string]interface{}
+		unsupported bool
+		format      string
+		err         string
+	}{
+		{
+			name:   "Default",
+			format: util.OutputFormatText,
+		},
+		{
+			name: "JSONFlag",
+			vars: map[string]interface{}{
+				"json": true,
+			},
+			format: util.OutputFormatJSON,
+		},
+		{
+			name: "JSONFormat",
+			vars: map[string]interface{}{
+				"format": "JSON",
+			},
+			format: util.OutputFormatJSON,
+		},
+		{
+			name: "YAMLFormat",
+			vars: map[string]interface{}{
+				"format": "yaml",
+			},
+			format: util.OutputFormatYAML,
+		},
+		{
+			name: "UnknownFormat",
+			vars: map[string]interface{}{
+				"format": "xml",
+			},
+			err: `unsupported output format "xml"; supported formats are text, json, yaml and csv`,
+		},
+		{
+			name: "TemplateWithCSV",
+			vars: map[string]interface{}{
+				"format":   "csv",
+				"template": "{{.epoch}}",
+			},
+			err: "template cannot be used with csv output",
+		},
+		{
+			name: "TemplateWithJSONFlag",
+			vars: map[string]interface{}{
+				"json":     true,
+				"template": "{{.epoch}}",
+			},
+			err: "template cannot be used with json output",
+		},
+		{
+			name: "UnsupportedText",
+			vars: map[string]interface{}{
+				"format": "text",
+			},
+			unsupported: true,
+			format:      util.OutputFormatText,
+		},
+		{
+			name: "UnsupportedJSONFlag",
+			vars: map[string]interface{}{
+				"json": true,
+			},
+			unsupported: true,
+			format:      util.OutputFormatJSON,
+		},
+		{
+			name: "UnsupportedYAML",
+			vars: map[string]interface{}{
+				"format": "yaml",
+			},
+			unsupported: true,
+			err:         "yaml output is not supported by this command; use --json where available",
+		},
+		{
+			name: "UnsupportedJSON",
+			vars: map[string]interface{}{
+				"format": "json",
+			},
+			unsupported: true,
+			err:         "json output is not supported by this command; use --json where available",
+		},
+		{
+			name: "UnsupportedTemplate",
+			vars: map[string]interface{}{
+				"template": "{{.epoch}}",
+			},
+			unsupported: true,
+			err:         "template output is not supported by this command",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			json := viper.GetBool("json")
+			err := util.SetupOutputFormat(!test.unsupported)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.format, util.OutputFormat())
+				// The json key must not be altered, as some commands give --json a different meaning.
+				require.Equal(t, json, viper.GetBool("json"))
+			}
+		})
+	}
+}
+
+func TestFormatOutput(t *testing.T) {
+	ctx := context.Background()
+
+	jsonOutput := func(context.Context) (string, error) {
+		return `{"epoch":"10","root":"0x0102","validators":[1,2],"totals":{"included":3,"missed":null}}`, nil
+	}
+	textOutput := func(context.Context) (string, error) {
+		return "Epoch: 10", nil
+	}
+	csvOutput := func(context.Context) (string, error) {
+		return "epoch\n10", nil
+	}
+
+	tests := []struct {
+		name string
+		opts *util.FormatOpts
+		res  string
+		err  string
+	}{
+		{
+			name: "Nil",
+			err:  "no options specified",
+		},
+		{
+			name: "JSONMissing",
+			opts: &util.FormatOpts{Text: textOutput},
+			err:  "no JSON output specified",
+		},
+		{
+			name: "Text",
+			opts: &util.FormatOpts{JSON: jsonOutput, Text: textOutput},
+			res:  "Epoch: 10",
+		},
+		{
+			name: "TextMissing",
+			opts: &util.FormatOpts{JSON: jsonOutput},
+			res:  `{"epoch":"10","root":"0x0102","validators":[1,2],"totals":{"included":3,"missed":null}}`,
+		},
+		{
+			name: "JSON",
+			opts: &util.FormatOpts{Format: util.OutputFormatJSON, JSON: jsonOutput, Text: textOutput},
+			res:  `{"epoch":"10","root":"0x0102","validators":[1,2],"totals":{"included":3,"missed":null}}`,
+		},
+		{
+			name: "YAML",
+			opts: &util.FormatOpts{Format: util.OutputFormatYAML, JSON: jsonOutput, Text: textOutput},
+			res: `epoch: "10"
+root: "0x0102"
+validators:
+  - 1
+  - 2
+totals:
+  included: 3
+  missed: null`,
+		},
+		{
+			name: "CSV",
+			opts: &util.FormatOpts{Format: util.OutputFormatCSV, JSON: jsonOutput, Text: textOutput},
+			res:  "epoch,root,validators,totals.included,totals.missed\n10,0x0102,1;2,3,",
+		},
+		{
+			name: "CSVSupplied",
+			opts: &util.FormatOpts{Format: util.OutputFormatCSV, JSON: jsonOutput, Text: textOutput, CSV: csvOutput},
+			res:  "epoch\n10",
+		},
+		{
+			name: "Template",
+			opts: &util.FormatOpts{Template: "{{.epoch}} {{.totals.included}} {{range .validators}}[{{.}}]{{end}}", JSON: jsonOutput, Text: textOutput},
+			res:  "10 3 [1][2]",
+		},
+		{
+			name: "TemplateInvalid",
+			opts: &util.FormatOpts{Template: "{{.epoch", JSON: jsonOutput, Text: textOutput},
+			err:  "invalid template: template: output:1: unclosed action",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.FormatOutput(ctx, test.opts)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestJSONToCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		res  string
+		err  string
+	}{
+		{
+			name: "Invalid",
+			data: `{"a":`,
+			err:  "failed to parse JSON: yaml: line 1: did not find expected node content",
+		},
+		{
+			name: "Array",
+			data: `[{"slot":"1","validator_index":"2"},{"slot":"2","validator_index":"3","extra":"a,b"}]`,
+			res:  "slot,validator_index,extra\n1,2,\n2,3,\"a,b\"",
+		},
+		{
+			name: "NestedArray",
+			data: `{"epoch":"1","proposals":[{"slot":"32","proposed":true},{"slot":"33","proposed":false}]}`,
+			res:  "epoch,proposals.0.slot,proposals.0.proposed,proposals.1.slot,proposals.1.proposed\n1,32,true,33,false",
+		},
+		{
+			name: "Scalars",
+			data: `["a","b"]`,
+			res:  "value\na\nb",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.JSONToCSV(test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// InterchangeFormatVersion is the version of the EIP-3076 interchange format supported.
+const InterchangeFormatVersion = "5"
+
+// Interchange is an EIP-3076 slashing protection interchange file.
+type Interchange struct {
+	Metadata *InterchangeMetadata `json:"metadata"`
+	Data     []*InterchangeData   `json:"data"`
+}
+
+// InterchangeMetadata is the metadata of an interchange file.
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string      `json:"interchange_format_version"`
+	GenesisValidatorsRoot    phase0.Root `json:"genesis_validators_root"`
+}
+
+// InterchangeData is the slashing protection data for a single public key.
+type InterchangeData struct {
+	Pubkey             phase0.BLSPubKey          `json:"pubkey"`
+	SignedBlocks       []*InterchangeBlock       `json:"signed_blocks"`
+	SignedAttestations []*InterchangeAttestation `json:"signed_attestations"`
+}
+
+// InterchangeBlock is a signed block in an interchange file.
+type InterchangeBlock struct {
+	Slot        phase0.Slot  `json:"slot"`
+	SigningRoot *phase0.Root `json:"signing_root,omitempty"`
+}
+
+// InterchangeAttestation is a signed attestation in an interchange file.
+type InterchangeAttestation struct {
+	SourceEpoch phase0.Epoch `json:"source_epoch"`
+	TargetEpoch phase0.Epoch `json:"target_epoch"`
+	SigningRoot *phase0.Root `json:"signing_root,omitempty"`
+}
+
+// ReadInterchange reads an interchange file.
+func ReadInterchange(path string) (*Interchange, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read interchange file")
+	}
+
+	interchange := &Interchange{}
+	if err := json.Unmarshal(data, interchange); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse interchange file %s", path))
+	}
+	if interchange.Metadata == nil {
+		return nil, fmt.Errorf("interchange file %s has no metadata", path)
+	}
+
+	return interchange, nil
+}
+
+// Validate checks the interchange data for problems, returning a description of each problem found.
+func (i *Interchange) Validate() []string {
+	problems := make([]string, 0)
+
+	if i.Metadata == nil {
+		return append(problems, "metadata missing")
+	}
+	if i.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		problems = append(problems, fmt.Sprintf("unsupported interchange format version %q", i.Metadata.InterchangeFormatVersion))
+	}
+	if i.Metadata.GenesisValidatorsRoot.IsZero() {
+		problems = append(problems, "genesis validators root missing")
+	}
+
+	for _, data := range i.ByPubkey() {
+		problems = append(problems, data.validate()...)
+	}
+
+	return problems
+}
+
+// validate checks the data for a single public key for problems.
+func (d *InterchangeData) validate() []string {
+	problems := make([]string, 0)
+
+	blocks := make(map[phase0.Slot]*phase0.Root)
+	for _, block := range d.SignedBlocks {
+		if existing, exists := blocks[block.Slot]; exists && conflictingRoots(existing, block.SigningRoot) {
+			problems = append(problems, fmt.Sprintf("%#x: conflicting blocks at slot %d", d.Pubkey, block.Slot))
+		}
+		blocks[block.Slot] = block.SigningRoot
+	}
+
+	targets := make(map[phase0.Epoch]*phase0.Root)
+	for _, attestation := range d.SignedAttestations {
+		if attestation.SourceEpoch > attestation.TargetEpoch {
+			problems = append(problems, fmt.Sprintf("%#x: attestation source epoch %d is after target epoch %d", d.Pubkey, attestation.SourceEpoch, attestation.TargetEpoch))
+		}
+		if existing, exists := targets[attestation.TargetEpoch]; exists && conflictingRoots(existing, attestation.SigningRoot) {
+			problems = append(problems, fmt.Sprintf("%#x: conflicting attestations with target epoch %d", d.Pubkey, attestation.TargetEpoch))
+		}
+		targets[attestation.TargetEpoch] = attestation.SigningRoot
+	}
+
+	// An attestation is surrounded if an attestation with an earlier source
+	// has a later target, so sort by source and track the highest target seen.
+	attestations := make([]*InterchangeAttestation, len(d.SignedAttestations))
+	copy(attestations, d.SignedAttestations)
+	sort.SliceStable(attestations, func(i int, j int) bool {
+		return attestations[i].SourceEpoch < attestations[j].SourceEpoch
+	})
+	maxTarget := phase0.Epoch(0)
+	groupMaxTarget := phase0.Epoch(0)
+	for i, attestation := range attestations {
+		if i > 0 && attestation.SourceEpoch != attestations[i-1].SourceEpoch {
+			// Moving on to a later source.
+			maxTarget = max(maxTarget, groupMaxTarget)
+		}
+		if maxTarget > attestation.TargetEpoch {
+			problems = append(problems, fmt.Sprintf("%#x: attestation with source epoch %d and target epoch %d is surrounded", d.Pubkey, attestation.SourceEpoch, attestation.TargetEpoch))
+		}
+		groupMaxTarget = max(groupMaxTarget, attestation.TargetEpoch)
+	}
+
+	return problems
+}
+
+// conflictingRoots returns true if two signing roots are known to differ.
+func conflictingRoots(a *phase0.Root, b *phase0.Root) bool {
+	if a == nil || b == nil {
+		return false
+	}
+
+	return !bytes.Equal(a[:], b[:])
+}
+
+// ByPubkey returns the data in the interchange with a single entry per public key,
+// ordered by public key.  Duplicate entries are combined.
+func (i *Interchange) ByPubkey() []*InterchangeData {
+	return mergeInterchangeData(i.Data)
+}
+
+// MergeInterchanges merges multiple interchanges in to a single interchange.
+func MergeInterchanges(interchanges ...*Interchange) (*Interchange, error) {
+	if len(interchanges) == 0 {
+		return nil, errors.New("no interchanges to merge")
+	}
+
+	data := make([]*InterchangeData, 0)
+	for _, interchange := range interchanges {
+		if interchange.Metadata == nil {
+			return nil, errors.New("interchange has no metadata")
+		}
+		if interchange.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+			return nil, fmt.Errorf("unsupported interchange format version %q", interchange.Metadata.InterchangeFormatVersion)
+		}
+		if !bytes.Equal(interchange.Metadata.GenesisValidatorsRoot[:], interchanges[0].Metadata.GenesisValidatorsRoot[:]) {
+			return nil, errors.New("interchanges have different genesis validators roots")
+		}
+		data = append(data, interchange.Data...)
+	}
+
+	return &Interchange{
+		Metadata: &InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    interchanges[0].Metadata.GenesisValidatorsRoot,
+		},
+		Data: mergeInterchangeData(data),
+	}, nil
+}
+
+// mergeInterchangeData combines data entries with the same public key, removing
+// duplicate blocks and attestations.
+func mergeInterchangeData(data []*InterchangeData) []*InterchangeData {
+	type blockKey struct {
+		slot phase0.Slot
+		root phase0.Root
+		set  bool
+	}
+	type attestationKey struct {
+		source phase0.Epoch
+		target phase0.Epoch
+		root   phase0.Root
+		set    bool
+	}
+
+	merged := make(map[phase0.BLSPubKey]*InterchangeData)
+	blocks := make(map[phase0.BLSPubKey]map[blockKey]bool)
+	attestations := make(map[phase0.BLSPubKey]map[attestationKey]bool)
+	for _, entry := range data {
+		if _, exists := merged[entry.Pubkey]; !exists {
+			merged[entry.Pubkey] = &InterchangeData{
+				Pubkey:             entry.Pubkey,
+				SignedBlocks:       make([]*InterchangeBlock, 0),
+				SignedAttestations: make([]*InterchangeAttestation, 0),
+			}
+			blocks[entry.Pubkey] = make(map[blockKey]bool)
+			attestations[entry.Pubkey] = make(map[attestationKey]bool)
+		}
+		res := merged[entry.Pubkey]
+		for _, block := range entry.SignedBlocks {
+			key := blockKey{slot: block.Slot}
+			if block.SigningRoot != nil {
+				key.root = *block.SigningRoot
+				key.set = true
+			}
+			if !blocks[entry.Pubkey][key] {
+				blocks[entry.Pubkey][key] = true
+				res.SignedBlocks = append(res.SignedBlocks, block)
+			}
+		}
+		for _, attestation := range entry.SignedAttestations {
+			key := attestationKey{source: attestation.SourceEpoch, target: attestation.TargetEpoch}
+			if attestation.SigningRoot != nil {
+				key.root = *attestation.SigningRoot
+				key.set = true
+			}
+			if !attestations[entry.Pubkey][key] {
+				attestations[entry.Pubkey][key] = true
+				res.SignedAttestations = append(res.SignedAttestations, attestation)
+			}
+		}
+	}
+
+	res := make([]*InterchangeData, 0, len(merged))
+	for _, entry := range merged {
+		sort.SliceStable(entry.SignedBlocks, func(i int, j int) bool {
+			return entry.SignedBlocks[i].Slot < entry.SignedBlocks[j].Slot
+		})
+		sort.SliceStable(entry.SignedAttestations, func(i int, j int) bool {
+			if entry.SignedAttestations[i].TargetEpoch != entry.SignedAttestations[j].TargetEpoch {
+				return entry.SignedAttestations[i].TargetEpoch < entry.SignedAttestations[j].TargetEpoch
+			}
+
+			return entry.SignedAttestations[i].SourceEpoch < entry.SignedAttestations[j].SourceEpoch
+		})
+		res = append(res, entry)
+	}
+	sort.Slice(res, func(i int, j int) bool {
+		return bytes.Compare(res[i].Pubkey[:], res[j].Pubkey[:]) < 0
+	})
+
+	return res
+}
+
+// Minify returns the minimal form of the interchange, as defined in EIP-3076.
+// This contains, for each public key, only the highest block slot and an
+// attestation with the highest source and target epochs.
+func (i *Interchange) Minify() *Interchange {
+	res := &Interchange{
+		Metadata: &InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+		},
+		Data: make([]*InterchangeData, 0),
+	}
+	if i.Metadata != nil {
+		res.Metadata.GenesisValidatorsRoot = i.Metadata.GenesisValidatorsRoot
+	}
+
+	for _, data := range i.ByPubkey() {
+		minified := &InterchangeData{
+			Pubkey:             data.Pubkey,
+			SignedBlocks:       make([]*InterchangeBlock, 0, 1),
+			SignedAttestations: make([]*InterchangeAttestation, 0, 1),
+		}
+		if len(data.SignedBlocks) > 0 {
+			// Blocks are ordered by slot.
+			minified.SignedBlocks = append(minified.SignedBlocks, &InterchangeBlock{
+				Slot: data.SignedBlocks[len(data.SignedBlocks)-1].Slot,
+			})
+		}
+		if len(data.SignedAttestations) > 0 {
+			attestation := &InterchangeAttestation{}
+			for _, signedAttestation := range data.SignedAttestations {
+				attestation.SourceEpoch = max(attestation.SourceEpoch, signedAttestation.SourceEpoch)
+				attestation.TargetEpoch = max(attestation.TargetEpoch, signedAttestation.TargetEpoch)
+			}
+			minified.SignedAttestations = append(minified.SignedAttestations, attestation)
+		}
+		res.Data = append(res.Data, minified)
+	}
+
+	return res
+}
@@ -0,0 +1,141 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+const (
+	interchangeGVR     = "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+	interchangePubkey1 = "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed"
+	interchangePubkey2 = "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	interchangeRoot1   = "0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"
+	interchangeRoot2   = "0x587d6a4f59a58fe24f406e0502413e77fe1babddee641fda30034ed37ecc884d"
+)
+
+func parseInterchange(t *testing.T, input string) *util.Interchange {
+	t.Helper()
+
+	interchange := &util.Interchange{}
+	require.NoError(t, json.Unmarshal([]byte(input), interchange))
+
+	return interchange
+}
+
+func TestReadInterchange(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.json")
+	require.NoError(t, os.WriteFile(valid, []byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+interchangeGVR+`"},"data":[]}`), 0o600))
+	interchange, err := util.ReadInterchange(valid)
+	require.NoError(t, err)
+	require.Equal(t, "5", interchange.Metadata.InterchangeFormatVersion)
+
+	noMetadata := filepath.Join(dir, "nometadata.json")
+	require.NoError(t, os.WriteFile(noMetadata, []byte(`{"data":[]}`), 0o600))
+	_, err = util.ReadInterchange(noMetadata)
+	require.ErrorContains(t, err, "has no metadata")
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"bad"}}`), 0o600))
+	_, err = util.ReadInterchange(invalid)
+	require.ErrorContains(t, err, "failed to parse interchange file")
+
+	_, err = util.ReadInterchange(filepath.Join(dir, "missing.json"))
+	require.ErrorContains(t, err, "failed to read interchange file")
+}
+
+func TestInterchangeValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		problems []string
+	}{
+		{
+			name:  "Valid",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + interchangeGVR + `"},"data":[{"pubkey":"` + interchangePubkey1 + `","signed_blocks":[{"slot":"10","signing_root":"` + interchangeRoot1 + `"},{"slot":"10","signing_root":"` + interchangeRoot1 + `"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"},{"source_epoch":"2","target_epoch":"3"}]}]}`,
+		},
+		{
+			name:     "VersionWrong",
+			input:    `{"metadata":{"interchange_format_version":"4","genesis_validators_root":"` + interchangeGVR + `"},"data":[]}`,
+			problems: []string{`unsupported interchange format version "4"`},
+		},
+		{
+			name:     "GenesisValidatorsRootMissing",
+			input:    `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"data":[]}`,
+			problems: []string{"genesis validators root missing"},
+		},
+		{
+			name:  "ConflictingBlocks",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + interchangeGVR + `"},"data":[{"pubkey":"` + interchangePubkey1 + `","signed_blocks":[{"slot":"10","signing_root":"` + interchangeRoot1 + `"}],"signed_attestations":[]},{"pubkey":"` + interchangePubkey1 + `","signed_blocks":[{"slot":"10","signing_root":"` + interchangeRoot2 + `"}],"signed_attestations":[]}]}`,
+			problems: []string{
+				interchangePubkey1 + ": conflicting blocks at slot 10",
+			},
+		},
+		{
+			name:  "BadAttestations",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + interchangeGVR + `"},"data":[{"pubkey":"` + interchangePubkey2 + `","signed_blocks":[],"signed_attestations":[{"source_epoch":"5","target_epoch":"4"},{"source_epoch":"1","target_epoch":"6","signing_root":"` + interchangeRoot1 + `"},{"source_epoch":"2","target_epoch":"6","signing_root":"` + interchangeRoot2 + `"},{"source_epoch":"2","target_epoch":"3"}]}]}`,
+			problems: []string{
+				interchangePubkey2 + ": attestation source epoch 5 is after target epoch 4",
+				interchangePubkey2 + ": conflicting attestations with target epoch 6",
+				interchangePubkey2 + ": attestation with source epoch 2 and target epoch 3 is surrounded",
+				interchangePubkey2 + ": attestation with source epoch 5 and target epoch 4 is surrounded",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems := parseInterchange(t, test.input).Validate()
+			if len(test.problems) == 0 {
+				require.Empty(t, problems)
+			} else {
+				require.Equal(t, test.problems, problems)
+			}
+		})
+	}
+}
+
+func TestMergeInterchanges(t *testing.T) {
+	first := parseInterchange(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+interchangeGVR+`"},"data":[{"pubkey":"`+interchangePubkey1+`","signed_blocks":[{"slot":"20"},{"slot":"10"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"}]}]}`)
+	second := parseInterchange(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+interchangeGVR+`"},"data":[{"pubkey":"`+interchangePubkey2+`","signed_blocks":[],"signed_attestations":[]},{"pubkey":"`+interchangePubkey1+`","signed_blocks":[{"slot":"10"},{"slot":"15"}],"signed_attestations":[{"source_epoch":"2","target_epoch":"3"},{"source_epoch":"1","target_epoch":"2"}]}]}`)
+
+	merged, err := util.MergeInterchanges(first, second)
+	require.NoError(t, err)
+	data, err := json.Marshal(merged)
+	require.NoError(t, err)
+	require.Equal(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+interchangeGVR+`"},"data":[{"pubkey":"`+interchangePubkey2+`","signed_blocks":[],"signed_attestations":[]},{"pubkey":"`+interchangePubkey1+`","signed_blocks":[{"slot":"10"},{"slot":"15"},{"slot":"20"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"},{"source_epoch":"2","target_epoch":"3"}]}]}`, string(data))
+
+	other := parseInterchange(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+interchangeRoot1+`"},"data":[]}`)
+	_, err = util.MergeInterchanges(first, other)
+	require.EqualError(t, err, "interchanges have different genesis validators roots")
+
+	_, err = util.MergeInterchanges()
+	require.EqualError(t, err, "no interchanges to merge")
+}
+
+func TestInterchangeMinify(t *testing.T) {
+	interchange := parseInterchange(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+interchangeGVR+`"},"data":[{"pubkey":"`+interchangePubkey1+`","signed_blocks":[{"slot":"20","signing_root":"`+interchangeRoot1+`"},{"slot":"10"}],"signed_attestations":[{"source_epoch":"4","target_epoch":"5"},{"source_epoch":"1","target_epoch":"7"}]},{"pubkey":"`+interchangePubkey2+`","signed_blocks":[],"signed_attestations":[]}]}`)
+
+	data, err := json.Marshal(interchange.Minify())
+	require.NoError(t, err)
+	require.Equal(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+interchangeGVR+`"},"data":[{"pubkey":"`+interchangePubkey2+`","signed_blocks":[],"signed_attestations":[]},{"pubkey":"`+interchangePubkey1+`","signed_blocks":[{"slot":"20"}],"signed_attestations":[{"source_epoch":"4","target_epoch":"7"}]}]}`, string(data))
+}
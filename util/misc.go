@@ -159,7 +159,7 @@ func WalletAndAccountFromPath(ctx context.Context, path string) (e2wtypes.Wallet
 		if isLocker {
 			err = locker.Unlock(ctx, []byte(GetWalletPassphrase()))
 			if err != nil {
-				return nil, nil, errors.New("failed to unlock wallet")
+				return nil, nil, NewError(ErrorCodeInvalidPassphrase, "failed to unlock wallet")
 			}
 			defer relockAccount(locker)
 		}
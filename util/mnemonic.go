@@ -1,4 +1,4 @@
-// Copyright © 2020 - 2023 Weald Technology Trading
+// Copyright © 2020 - 2025 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -26,7 +26,8 @@ import (
 // hdPathRegex is the regular expression that matches an HD path.
 var hdPathRegex = regexp.MustCompile("^m/[0-9]+/[0-9]+(/[0-9+])+")
 
-var mnemonicWordLists = [][]string{
+// MnemonicWordLists are the BIP-39 word lists supported for mnemonics.
+var MnemonicWordLists = [][]string{
 	wordlists.English,
 	wordlists.ChineseSimplified,
 	wordlists.ChineseTraditional,
@@ -62,7 +63,7 @@ func SeedFromMnemonic(mnemonic string) ([]byte, error) {
 	mnemonicPassphrase = string(norm.NFKD.Bytes([]byte(mnemonicPassphrase)))
 
 	// Try with the various word lists.
-	for _, wl := range mnemonicWordLists {
+	for _, wl := range MnemonicWordLists {
 		bip39.SetWordList(wl)
 		seed, err := bip39.NewSeedWithErrorChecking(expandMnemonic(mnemonic), mnemonicPassphrase)
 		if err == nil {
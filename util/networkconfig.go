@@ -0,0 +1,421 @@
+// Copyright © 2025 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// NetworkConfig provides chain information from a network configuration rather
+// than a beacon node, allowing commands to run without a connection.
+// It implements the spec, genesis and fork schedule providers, and can be used
+// in place of a beacon node client for those functions.
+type NetworkConfig struct {
+	name    string
+	spec    map[string]any
+	genesis *apiv1.Genesis
+}
+
+// SetupNetworkConfig checks the network configuration options.  supported is
+// true if the command obtains its chain configuration with NetworkConfigFromConfig;
+// other commands reject the options rather than silently ignoring them.
+func SetupNetworkConfig(supported bool) error {
+	if supported {
+		return nil
+	}
+	for _, option := range []string{"network", "network-config", "network-genesis-time", "network-genesis-validators-root"} {
+		if viper.GetString(option) != "" {
+			return fmt.Errorf("%s is not supported by this command", option)
+		}
+	}
+
+	return nil
+}
+
+// NetworkConfigFromConfig obtains the network configuration from the
+// --network or --network-config options.
+// It returns nil if neither option is supplied.
+func NetworkConfigFromConfig() (*NetworkConfig, error) {
+	name := viper.GetString("network")
+	configPath := viper.GetString("network-config")
+
+	switch {
+	case name != "" && configPath != "":
+		return nil, errors.New("only one of network and network config allowed")
+	case name != "":
+		return BuiltinNetworkConfig(name)
+	case configPath != "":
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read network config")
+		}
+		if viper.GetString("network-genesis-time") == "" {
+			return nil, errors.New("network genesis time is required with network config")
+		}
+		genesisTime, err := strconv.ParseInt(viper.GetString("network-genesis-time"), 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid network genesis time")
+		}
+		if viper.GetString("network-genesis-validators-root") == "" {
+			return nil, errors.New("network genesis validators root is required with network config")
+		}
+		genesisValidatorsRoot, err := parseRoot(viper.GetString("network-genesis-validators-root"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid network genesis validators root")
+		}
+
+		return NewNetworkConfig(data, time.Unix(genesisTime, 0), genesisValidatorsRoot)
+	default:
+		return nil, nil
+	}
+}
+
+// BuiltinNetworkConfig returns the network configuration for a named network.
+func BuiltinNetworkConfig(name string) (*NetworkConfig, error) {
+	network, exists := builtinNetworks[strings.ToLower(name)]
+	if !exists {
+		return nil, fmt.Errorf("unknown network %q; supported networks are %s", name, strings.Join(BuiltinNetworks(), ", "))
+	}
+
+	genesisValidatorsRoot, err := parseRoot(network.genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	config := make(map[string]any, len(baseNetworkConfig)+len(network.config))
+	for k, v := range baseNetworkConfig {
+		config[k] = v
+	}
+	for k, v := range network.config {
+		config[k] = v
+	}
+
+	res := newNetworkConfig(config, time.Unix(network.genesisTime, 0), genesisValidatorsRoot)
+	res.name = network.name
+
+	return res, nil
+}
+
+// BuiltinNetworks returns the names of the built-in networks.
+func BuiltinNetworks() []string {
+	res := make([]string, 0, len(builtinNetworks))
+	for name := range builtinNetworks {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+
+	return res
+}
+
+// NewNetworkConfig creates a network configuration from the contents of a
+// standard consensus config.yaml file, along with the genesis information for
+// the chain.
+func NewNetworkConfig(data []byte, genesisTime time.Time, genesisValidatorsRoot phase0.Root) (*NetworkConfig, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, errors.Wrap(err, "failed to parse network config")
+	}
+	config, isMap := yamlNodeValue(&root).(map[string]any)
+	if !isMap {
+		return nil, errors.New("network config is not a map")
+	}
+	if _, exists := config["SECONDS_PER_SLOT"]; !exists {
+		return nil, errors.New("network config does not contain SECONDS_PER_SLOT")
+	}
+	if _, exists := config["GENESIS_FORK_VERSION"]; !exists {
+		return nil, errors.New("network config does not contain GENESIS_FORK_VERSION")
+	}
+
+	res := newNetworkConfig(config, genesisTime, genesisValidatorsRoot)
+	if name, isString := config["CONFIG_NAME"].(string); isString {
+		res.name = name
+	}
+
+	return res, nil
+}
+
+// newNetworkConfig creates a network configuration from raw configuration values.
+func newNetworkConfig(config map[string]any, genesisTime time.Time, genesisValidatorsRoot phase0.Root) *NetworkConfig {
+	// Start with the preset and constant values, as these are not included in config.yaml.
+	raw := make(map[string]any)
+	for k, v := range mainnetPreset {
+		raw[k] = v
+	}
+	if preset, isString := config["PRESET_BASE"].(string); isString && strings.EqualFold(strings.Trim(preset, `'"`), "minimal") {
+		for k, v := range minimalPreset {
+			raw[k] = v
+		}
+	}
+	for k, v := range specConstants {
+		raw[k] = v
+	}
+	for k, v := range config {
+		raw[k] = v
+	}
+
+	spec := parseNetworkConfigMap(raw)
+	genesisForkVersion, _ := spec["GENESIS_FORK_VERSION"].(phase0.Version)
+
+	return &NetworkConfig{
+		spec: spec,
+		genesis: &apiv1.Genesis{
+			GenesisTime:           genesisTime,
+			GenesisValidatorsRoot: genesisValidatorsRoot,
+			GenesisForkVersion:    genesisForkVersion,
+		},
+	}
+}
+
+// NetworkName returns the name of the network.
+func (n *NetworkConfig) NetworkName() string {
+	if n.name == "" {
+		return "Unknown"
+	}
+
+	return n.name
+}
+
+// Name returns the name of the client implementation.
+func (*NetworkConfig) Name() string {
+	return "offline"
+}
+
+// Address returns the address of the client.
+func (*NetworkConfig) Address() string {
+	return "offline"
+}
+
+// IsActive returns true if the client is active.
+func (*NetworkConfig) IsActive() bool {
+	return true
+}
+
+// IsSynced returns true if the client is synced.
+func (*NetworkConfig) IsSynced() bool {
+	return true
+}
+
+// Spec provides the spec information of the chain.
+func (n *NetworkConfig) Spec(_ context.Context,
+	_ *api.SpecOpts,
+) (
+	*api.Response[map[string]any],
+	error,
+) {
+	return &api.Response[map[string]any]{
+		Data:     n.spec,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// Genesis provides the genesis information of the chain.
+func (n *NetworkConfig) Genesis(_ context.Context,
+	_ *api.GenesisOpts,
+) (
+	*api.Response[*apiv1.Genesis],
+	error,
+) {
+	return &api.Response[*apiv1.Genesis]{
+		Data:     n.genesis,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// ForkSchedule provides details of past and future changes in the chain's fork version.
+func (n *NetworkConfig) ForkSchedule(_ context.Context,
+	_ *api.ForkScheduleOpts,
+) (
+	*api.Response[[]*phase0.Fork],
+	error,
+) {
+	forks := make([]*phase0.Fork, 0)
+	for k, v := range n.spec {
+		if !strings.HasSuffix(k, "_FORK_EPOCH") {
+			continue
+		}
+		version, isVersion := n.spec[strings.TrimSuffix(k, "_FORK_EPOCH")+"_FORK_VERSION"].(phase0.Version)
+		if !isVersion {
+			continue
+		}
+		epoch, isEpoch := v.(uint64)
+		if !isEpoch || phase0.Epoch(epoch) == farFutureEpoch {
+			continue
+		}
+		forks = append(forks, &phase0.Fork{
+			CurrentVersion: version,
+			Epoch:          phase0.Epoch(epoch),
+		})
+	}
+	// Multiple forks can occur at the same epoch, in which case the order is given by their versions.
+	sort.Slice(forks, func(i int, j int) bool {
+		if forks[i].Epoch != forks[j].Epoch {
+			return forks[i].Epoch < forks[j].Epoch
+		}
+
+		return bytes.Compare(forks[i].CurrentVersion[:], forks[j].CurrentVersion[:]) < 0
+	})
+
+	res := []*phase0.Fork{
+		{
+			PreviousVersion: n.genesis.GenesisForkVersion,
+			CurrentVersion:  n.genesis.GenesisForkVersion,
+			Epoch:           0,
+		},
+	}
+	for _, fork := range forks {
+		fork.PreviousVersion = res[len(res)-1].CurrentVersion
+		res = append(res, fork)
+	}
+
+	return &api.Response[[]*phase0.Fork]{
+		Data:     res,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// yamlNodeValue turns a YAML node in to a value, retaining scalars as strings
+// so that they can be parsed in the same way as values from a beacon node.
+func yamlNodeValue(node *yaml.Node) any {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil
+		}
+
+		return yamlNodeValue(node.Content[0])
+	case yaml.MappingNode:
+		res := make(map[string]any, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			res[node.Content[i].Value] = yamlNodeValue(node.Content[i+1])
+		}
+
+		return res
+	case yaml.SequenceNode:
+		res := make([]any, len(node.Content))
+		for i := range node.Content {
+			res[i] = yamlNodeValue(node.Content[i])
+		}
+
+		return res
+	case yaml.AliasNode:
+		return yamlNodeValue(node.Alias)
+	default:
+		return node.Value
+	}
+}
+
+// parseNetworkConfigMap parses configuration values in to the types
+// provided by a beacon node's spec endpoint.
+func parseNetworkConfigMap(data map[string]any) map[string]any {
+	res := make(map[string]any, len(data))
+	for k, v := range data {
+		res[k] = parseNetworkConfigValue(k, v)
+	}
+
+	return res
+}
+
+func parseNetworkConfigValue(k string, v any) any {
+	switch value := v.(type) {
+	case string:
+		return parseNetworkConfigString(k, value)
+	case []any:
+		res := make([]any, len(value))
+		for i := range value {
+			res[i] = parseNetworkConfigValue("", value[i])
+		}
+
+		return res
+	case map[string]any:
+		return parseNetworkConfigMap(value)
+	default:
+		return v
+	}
+}
+
+func parseNetworkConfigString(k string, v string) any {
+	v = strings.Trim(v, `'"`)
+
+	switch {
+	case strings.HasPrefix(k, "DOMAIN_"):
+		if byteVal, err := hex.DecodeString(strings.TrimPrefix(v, "0x")); err == nil {
+			var domainType phase0.DomainType
+			copy(domainType[:], byteVal)
+
+			return domainType
+		}
+	case strings.HasSuffix(k, "_FORK_VERSION"):
+		if byteVal, err := hex.DecodeString(strings.TrimPrefix(v, "0x")); err == nil {
+			var version phase0.Version
+			copy(version[:], byteVal)
+
+			return version
+		}
+	}
+
+	if strings.HasPrefix(v, "0x") {
+		if byteVal, err := hex.DecodeString(strings.TrimPrefix(v, "0x")); err == nil {
+			return byteVal
+		}
+	}
+
+	if strings.HasSuffix(k, "_TIME") {
+		if intVal, err := strconv.ParseInt(v, 10, 64); err == nil && intVal != 0 {
+			return time.Unix(intVal, 0)
+		}
+	}
+
+	if strings.HasPrefix(k, "SECONDS_PER_") || k == "GENESIS_DELAY" {
+		if intVal, err := strconv.ParseInt(v, 10, 64); err == nil && intVal >= 0 {
+			return time.Duration(intVal) * time.Second
+		}
+	}
+
+	if intVal, err := strconv.ParseUint(v, 10, 64); err == nil {
+		return intVal
+	}
+
+	return v
+}
+
+// parseRoot parses a hex string in to a root.
+func parseRoot(input string) (phase0.Root, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "invalid root")
+	}
+	if len(data) != phase0.RootLength {
+		return phase0.Root{}, errors.New("incorrect length for root")
+	}
+
+	var res phase0.Root
+	copy(res[:], data)
+
+	return res, nil
+}
@@ -0,0 +1,297 @@
+// Copyright © 2025 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+const testNetworkConfig = `# Devnet configuration.
+PRESET_BASE: 'minimal'
+CONFIG_NAME: 'devnet'
+GENESIS_FORK_VERSION: 0x10000038
+ALTAIR_FORK_VERSION: 0x20000038
+ALTAIR_FORK_EPOCH: 0
+CAPELLA_FORK_VERSION: 0x40000038
+CAPELLA_FORK_EPOCH: 10
+ELECTRA_FORK_VERSION: 0x60000038
+ELECTRA_FORK_EPOCH: 18446744073709551615
+SECONDS_PER_SLOT: 6
+DEPOSIT_CONTRACT_ADDRESS: 0x4242424242424242424242424242424242424242
+BLOB_SCHEDULE:
+  - EPOCH: 20
+    MAX_BLOBS_PER_BLOCK: 12
+`
+
+func TestNewNetworkConfig(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{
+			name: "Invalid",
+			data: "[",
+			err:  "failed to parse network config: yaml: line 1: did not find expected node content",
+		},
+		{
+			name: "NotMap",
+			data: "- a",
+			err:  "network config is not a map",
+		},
+		{
+			name: "SecondsPerSlotMissing",
+			data: "GENESIS_FORK_VERSION: 0x00000000",
+			err:  "network config does not contain SECONDS_PER_SLOT",
+		},
+		{
+			name: "GenesisForkVersionMissing",
+			data: "SECONDS_PER_SLOT: 12",
+			err:  "network config does not contain GENESIS_FORK_VERSION",
+		},
+		{
+			name: "Good",
+			data: testNetworkConfig,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			networkConfig, err := NewNetworkConfig([]byte(test.data), time.Unix(1700000000, 0), phase0.Root{0x01})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "devnet", networkConfig.NetworkName())
+
+			specResponse, err := networkConfig.Spec(ctx, &api.SpecOpts{})
+			require.NoError(t, err)
+			spec := specResponse.Data
+			require.Equal(t, phase0.Version{0x10, 0x00, 0x00, 0x38}, spec["GENESIS_FORK_VERSION"])
+			require.Equal(t, 6*time.Second, spec["SECONDS_PER_SLOT"])
+			require.Equal(t, uint64(8), spec["SLOTS_PER_EPOCH"])
+			require.Equal(t, phase0.DomainType{0x04, 0x00, 0x00, 0x00}, spec["DOMAIN_VOLUNTARY_EXIT"])
+			require.Equal(t, []byte{0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42}, spec["DEPOSIT_CONTRACT_ADDRESS"])
+			require.Equal(t, []any{map[string]any{"EPOCH": uint64(20), "MAX_BLOBS_PER_BLOCK": uint64(12)}}, spec["BLOB_SCHEDULE"])
+
+			genesisResponse, err := networkConfig.Genesis(ctx, &api.GenesisOpts{})
+			require.NoError(t, err)
+			require.Equal(t, int64(1700000000), genesisResponse.Data.GenesisTime.Unix())
+			require.Equal(t, phase0.Root{0x01}, genesisResponse.Data.GenesisValidatorsRoot)
+			require.Equal(t, phase0.Version{0x10, 0x00, 0x00, 0x38}, genesisResponse.Data.GenesisForkVersion)
+
+			// Electra is not scheduled so should not show in the fork schedule.
+			forkScheduleResponse, err := networkConfig.ForkSchedule(ctx, &api.ForkScheduleOpts{})
+			require.NoError(t, err)
+			require.Equal(t, []*phase0.Fork{
+				{
+					PreviousVersion: phase0.Version{0x10, 0x00, 0x00, 0x38},
+					CurrentVersion:  phase0.Version{0x10, 0x00, 0x00, 0x38},
+					Epoch:           0,
+				},
+				{
+					PreviousVersion: phase0.Version{0x10, 0x00, 0x00, 0x38},
+					CurrentVersion:  phase0.Version{0x20, 0x00, 0x00, 0x38},
+					Epoch:           0,
+				},
+				{
+					PreviousVersion: phase0.Version{0x20, 0x00, 0x00, 0x38},
+					CurrentVersion:  phase0.Version{0x40, 0x00, 0x00, 0x38},
+					Epoch:           10,
+				},
+			}, forkScheduleResponse.Data)
+		})
+	}
+}
+
+func TestNetworkConfigFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(testNetworkConfig), 0o600))
+
+	tests := []struct {
+		name      string
+		vars      map[string]any
+		network   string
+		forkEpoch phase0.Epoch
+		err       string
+	}{
+		{
+			name: "None",
+		},
+		{
+			name: "Both",
+			vars: map[string]any{
+				"network":        "hoodi",
+				"network-config": configPath,
+			},
+			err: "only one of network and network config allowed",
+		},
+		{
+			name: "NetworkUnknown",
+			vars: map[string]any{
+				"network": "unknown",
+			},
+			err: `unknown network "unknown"; supported networks are holesky, hoodi, mainnet, sepolia`,
+		},
+		{
+			name: "Network",
+			vars: map[string]any{
+				"network": "Hoodi",
+			},
+			network:   "Hoodi",
+			forkEpoch: 50688,
+		},
+		{
+			name: "ConfigMissing",
+			vars: map[string]any{
+				"network-config":                  filepath.Join(dir, "missing.yaml"),
+				"network-genesis-time":            "1700000000",
+				"network-genesis-validators-root": "0x0100000000000000000000000000000000000000000000000000000000000000",
+			},
+			err: "failed to read network config: open " + filepath.Join(dir, "missing.yaml") + ": no such file or directory",
+		},
+		{
+			name: "GenesisTimeMissing",
+			vars: map[string]any{
+				"network-config":                  configPath,
+				"network-genesis-validators-root": "0x0100000000000000000000000000000000000000000000000000000000000000",
+			},
+			err: "network genesis time is required with network config",
+		},
+		{
+			name: "GenesisValidatorsRootMissing",
+			vars: map[string]any{
+				"network-config":       configPath,
+				"network-genesis-time": "1700000000",
+			},
+			err: "network genesis validators root is required with network config",
+		},
+		{
+			name: "GenesisValidatorsRootShort",
+			vars: map[string]any{
+				"network-config":                  configPath,
+				"network-genesis-time":            "1700000000",
+				"network-genesis-validators-root": "0x01",
+			},
+			err: "invalid network genesis validators root: incorrect length for root",
+		},
+		{
+			name: "NetworkConfig",
+			vars: map[string]any{
+				"network-config":                  configPath,
+				"network-genesis-time":            "1700000000",
+				"network-genesis-validators-root": "0x0100000000000000000000000000000000000000000000000000000000000000",
+			},
+			network: "devnet",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			networkConfig, err := NetworkConfigFromConfig()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			if test.network == "" {
+				require.Nil(t, networkConfig)
+				return
+			}
+			require.Equal(t, test.network, networkConfig.NetworkName())
+			if test.forkEpoch != 0 {
+				specResponse, err := networkConfig.Spec(context.Background(), &api.SpecOpts{})
+				require.NoError(t, err)
+				require.Equal(t, uint64(test.forkEpoch), specResponse.Data["FULU_FORK_EPOCH"])
+			}
+		})
+	}
+}
+
+func TestNetworkFromNetworkConfig(t *testing.T) {
+	// Hoodi shares its deposit contract address with mainnet, so must be identified by genesis validators root.
+	networkConfig, err := BuiltinNetworkConfig("hoodi")
+	require.NoError(t, err)
+	network, err := Network(context.Background(), networkConfig)
+	require.NoError(t, err)
+	require.Equal(t, "Hoodi", network)
+}
+
+func TestSetupNetworkConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		vars        map[string]any
+		unsupported bool
+		err         string
+	}{
+		{
+			name:        "None",
+			unsupported: true,
+		},
+		{
+			name: "Supported",
+			vars: map[string]any{
+				"network": "hoodi",
+			},
+		},
+		{
+			name: "NetworkUnsupported",
+			vars: map[string]any{
+				"network": "hoodi",
+			},
+			unsupported: true,
+			err:         "network is not supported by this command",
+		},
+		{
+			name: "NetworkConfigUnsupported",
+			vars: map[string]any{
+				"network-config": "config.yaml",
+			},
+			unsupported: true,
+			err:         "network-config is not supported by this command",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			err := SetupNetworkConfig(!test.unsupported)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
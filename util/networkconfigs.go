@@ -0,0 +1,238 @@
+// Copyright © 2025 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import "github.com/attestantio/go-eth2-client/spec/phase0"
+
+// farFutureEpoch is the epoch used for forks that are not scheduled.
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+// builtinNetwork contains the information for a built-in network.
+type builtinNetwork struct {
+	name                  string
+	genesisTime           int64
+	genesisValidatorsRoot string
+	config                map[string]any
+}
+
+// builtinNetworks are the networks for which configuration is built in.
+var builtinNetworks = map[string]*builtinNetwork{
+	"mainnet": {
+		name:                  "Mainnet",
+		genesisTime:           1606824023,
+		genesisValidatorsRoot: "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+		config: map[string]any{
+			"CONFIG_NAME":            "mainnet",
+			"GENESIS_FORK_VERSION":   "0x00000000",
+			"ALTAIR_FORK_VERSION":    "0x01000000",
+			"ALTAIR_FORK_EPOCH":      "74240",
+			"BELLATRIX_FORK_VERSION": "0x02000000",
+			"BELLATRIX_FORK_EPOCH":   "144896",
+			"CAPELLA_FORK_VERSION":   "0x03000000",
+			"CAPELLA_FORK_EPOCH":     "194048",
+			"DENEB_FORK_VERSION":     "0x04000000",
+			"DENEB_FORK_EPOCH":       "269568",
+			"ELECTRA_FORK_VERSION":   "0x05000000",
+			"ELECTRA_FORK_EPOCH":     "364032",
+			"FULU_FORK_VERSION":      "0x06000000",
+			"FULU_FORK_EPOCH":        "411392",
+			"BLOB_SCHEDULE": []any{
+				map[string]any{"EPOCH": "412672", "MAX_BLOBS_PER_BLOCK": "15"},
+				map[string]any{"EPOCH": "419072", "MAX_BLOBS_PER_BLOCK": "21"},
+			},
+			"DEPOSIT_CHAIN_ID":         "1",
+			"DEPOSIT_NETWORK_ID":       "1",
+			"DEPOSIT_CONTRACT_ADDRESS": "0x00000000219ab540356cBB839Cbe05303d7705Fa",
+		},
+	},
+	"sepolia": {
+		name:                  "Sepolia",
+		genesisTime:           1655733600,
+		genesisValidatorsRoot: "0xd8ea171f3c94aea21ebc42a1ed61052acf3f9209c00e4efbaaddac09ed9b8078",
+		config: map[string]any{
+			"CONFIG_NAME":            "sepolia",
+			"GENESIS_FORK_VERSION":   "0x90000069",
+			"ALTAIR_FORK_VERSION":    "0x90000070",
+			"ALTAIR_FORK_EPOCH":      "50",
+			"BELLATRIX_FORK_VERSION": "0x90000071",
+			"BELLATRIX_FORK_EPOCH":   "100",
+			"CAPELLA_FORK_VERSION":   "0x90000072",
+			"CAPELLA_FORK_EPOCH":     "56832",
+			"DENEB_FORK_VERSION":     "0x90000073",
+			"DENEB_FORK_EPOCH":       "132608",
+			"ELECTRA_FORK_VERSION":   "0x90000074",
+			"ELECTRA_FORK_EPOCH":     "222464",
+			"FULU_FORK_VERSION":      "0x90000075",
+			"FULU_FORK_EPOCH":        "272640",
+			"BLOB_SCHEDULE": []any{
+				map[string]any{"EPOCH": "274176", "MAX_BLOBS_PER_BLOCK": "15"},
+				map[string]any{"EPOCH": "275456", "MAX_BLOBS_PER_BLOCK": "21"},
+			},
+			"DEPOSIT_CHAIN_ID":         "11155111",
+			"DEPOSIT_NETWORK_ID":       "11155111",
+			"DEPOSIT_CONTRACT_ADDRESS": "0x7f02C3E3c98b133055B8B348B2Ac625669Ed295D",
+		},
+	},
+	"holesky": {
+		name:                  "Holesky",
+		genesisTime:           1695902400,
+		genesisValidatorsRoot: "0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1",
+		config: map[string]any{
+			"CONFIG_NAME":            "holesky",
+			"GENESIS_FORK_VERSION":   "0x01017000",
+			"ALTAIR_FORK_VERSION":    "0x02017000",
+			"ALTAIR_FORK_EPOCH":      "0",
+			"BELLATRIX_FORK_VERSION": "0x03017000",
+			"BELLATRIX_FORK_EPOCH":   "0",
+			"CAPELLA_FORK_VERSION":   "0x04017000",
+			"CAPELLA_FORK_EPOCH":     "256",
+			"DENEB_FORK_VERSION":     "0x05017000",
+			"DENEB_FORK_EPOCH":       "29696",
+			"ELECTRA_FORK_VERSION":   "0x06017000",
+			"ELECTRA_FORK_EPOCH":     "115968",
+			"FULU_FORK_VERSION":      "0x07017000",
+			"FULU_FORK_EPOCH":        "165120",
+			"BLOB_SCHEDULE": []any{
+				map[string]any{"EPOCH": "166400", "MAX_BLOBS_PER_BLOCK": "15"},
+				map[string]any{"EPOCH": "167936", "MAX_BLOBS_PER_BLOCK": "21"},
+			},
+			"EJECTION_BALANCE":         "28000000000",
+			"DEPOSIT_CHAIN_ID":         "17000",
+			"DEPOSIT_NETWORK_ID":       "17000",
+			"DEPOSIT_CONTRACT_ADDRESS": "0x4242424242424242424242424242424242424242",
+		},
+	},
+	"hoodi": {
+		name:                  "Hoodi",
+		genesisTime:           1742213400,
+		genesisValidatorsRoot: "0x212f13fc4df078b6cb7db228f1c8307566dcecf900867401a92023d7ba99cb5f",
+		config: map[string]any{
+			"CONFIG_NAME":            "hoodi",
+			"GENESIS_FORK_VERSION":   "0x10000910",
+			"ALTAIR_FORK_VERSION":    "0x20000910",
+			"ALTAIR_FORK_EPOCH":      "0",
+			"BELLATRIX_FORK_VERSION": "0x30000910",
+			"BELLATRIX_FORK_EPOCH":   "0",
+			"CAPELLA_FORK_VERSION":   "0x40000910",
+			"CAPELLA_FORK_EPOCH":     "0",
+			"DENEB_FORK_VERSION":     "0x50000910",
+			"DENEB_FORK_EPOCH":       "0",
+			"ELECTRA_FORK_VERSION":   "0x60000910",
+			"ELECTRA_FORK_EPOCH":     "2048",
+			"FULU_FORK_VERSION":      "0x70000910",
+			"FULU_FORK_EPOCH":        "50688",
+			"BLOB_SCHEDULE": []any{
+				map[string]any{"EPOCH": "52480", "MAX_BLOBS_PER_BLOCK": "15"},
+				map[string]any{"EPOCH": "54016", "MAX_BLOBS_PER_BLOCK": "21"},
+			},
+			"DEPOSIT_CHAIN_ID":         "560048",
+			"DEPOSIT_NETWORK_ID":       "560048",
+			"DEPOSIT_CONTRACT_ADDRESS": "0x00000000219ab540356cBB839Cbe05303d7705Fa",
+		},
+	},
+}
+
+// baseNetworkConfig contains the configuration values common to the built-in networks.
+var baseNetworkConfig = map[string]any{
+	"PRESET_BASE":                               "mainnet",
+	"SECONDS_PER_SLOT":                          "12",
+	"SECONDS_PER_ETH1_BLOCK":                    "14",
+	"MIN_VALIDATOR_WITHDRAWABILITY_DELAY":       "256",
+	"SHARD_COMMITTEE_PERIOD":                    "256",
+	"ETH1_FOLLOW_DISTANCE":                      "2048",
+	"INACTIVITY_SCORE_BIAS":                     "4",
+	"INACTIVITY_SCORE_RECOVERY_RATE":            "16",
+	"EJECTION_BALANCE":                          "16000000000",
+	"MIN_PER_EPOCH_CHURN_LIMIT":                 "4",
+	"CHURN_LIMIT_QUOTIENT":                      "65536",
+	"MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT":      "8",
+	"MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA":         "128000000000",
+	"MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT": "256000000000",
+	"MAX_BLOBS_PER_BLOCK":                       "6",
+	"MAX_BLOBS_PER_BLOCK_ELECTRA":               "9",
+}
+
+// mainnetPreset contains the values of the mainnet preset.
+var mainnetPreset = map[string]any{
+	"MAX_COMMITTEES_PER_SLOT":                    "64",
+	"TARGET_COMMITTEE_SIZE":                      "128",
+	"MAX_VALIDATORS_PER_COMMITTEE":               "2048",
+	"SHUFFLE_ROUND_COUNT":                        "90",
+	"MIN_DEPOSIT_AMOUNT":                         "1000000000",
+	"MAX_EFFECTIVE_BALANCE":                      "32000000000",
+	"EFFECTIVE_BALANCE_INCREMENT":                "1000000000",
+	"MIN_ATTESTATION_INCLUSION_DELAY":            "1",
+	"SLOTS_PER_EPOCH":                            "32",
+	"MIN_SEED_LOOKAHEAD":                         "1",
+	"MAX_SEED_LOOKAHEAD":                         "4",
+	"EPOCHS_PER_ETH1_VOTING_PERIOD":              "64",
+	"SLOTS_PER_HISTORICAL_ROOT":                  "8192",
+	"MIN_EPOCHS_TO_INACTIVITY_PENALTY":           "4",
+	"EPOCHS_PER_HISTORICAL_VECTOR":               "65536",
+	"EPOCHS_PER_SLASHINGS_VECTOR":                "8192",
+	"BASE_REWARD_FACTOR":                         "64",
+	"WHISTLEBLOWER_REWARD_QUOTIENT":              "512",
+	"PROPOSER_REWARD_QUOTIENT":                   "8",
+	"SYNC_COMMITTEE_SIZE":                        "512",
+	"EPOCHS_PER_SYNC_COMMITTEE_PERIOD":           "256",
+	"MAX_WITHDRAWALS_PER_PAYLOAD":                "16",
+	"MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP":       "16384",
+	"MIN_ACTIVATION_BALANCE":                     "32000000000",
+	"MAX_EFFECTIVE_BALANCE_ELECTRA":              "2048000000000",
+	"MIN_SLASHING_PENALTY_QUOTIENT_ELECTRA":      "4096",
+	"WHISTLEBLOWER_REWARD_QUOTIENT_ELECTRA":      "4096",
+	"PENDING_DEPOSITS_LIMIT":                     "134217728",
+	"PENDING_PARTIAL_WITHDRAWALS_LIMIT":          "134217728",
+	"PENDING_CONSOLIDATIONS_LIMIT":               "262144",
+	"MAX_PENDING_PARTIALS_PER_WITHDRAWALS_SWEEP": "8",
+	"MAX_PENDING_DEPOSITS_PER_EPOCH":             "16",
+}
+
+// minimalPreset contains the values of the minimal preset that differ from
+// the mainnet preset.
+var minimalPreset = map[string]any{
+	"MAX_COMMITTEES_PER_SLOT":              "4",
+	"TARGET_COMMITTEE_SIZE":                "4",
+	"SHUFFLE_ROUND_COUNT":                  "10",
+	"SLOTS_PER_EPOCH":                      "8",
+	"EPOCHS_PER_ETH1_VOTING_PERIOD":        "4",
+	"SLOTS_PER_HISTORICAL_ROOT":            "64",
+	"EPOCHS_PER_HISTORICAL_VECTOR":         "64",
+	"EPOCHS_PER_SLASHINGS_VECTOR":          "64",
+	"SYNC_COMMITTEE_SIZE":                  "32",
+	"EPOCHS_PER_SYNC_COMMITTEE_PERIOD":     "8",
+	"MAX_WITHDRAWALS_PER_PAYLOAD":          "4",
+	"MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP": "16",
+}
+
+// specConstants contains the constant values provided by a beacon node's spec.
+var specConstants = map[string]any{
+	"FAR_FUTURE_EPOCH":                      "18446744073709551615",
+	"BLS_WITHDRAWAL_PREFIX":                 "0x00",
+	"ETH1_ADDRESS_WITHDRAWAL_PREFIX":        "0x01",
+	"COMPOUNDING_WITHDRAWAL_PREFIX":         "0x02",
+	"DOMAIN_BEACON_PROPOSER":                "0x00000000",
+	"DOMAIN_BEACON_ATTESTER":                "0x01000000",
+	"DOMAIN_RANDAO":                         "0x02000000",
+	"DOMAIN_DEPOSIT":                        "0x03000000",
+	"DOMAIN_VOLUNTARY_EXIT":                 "0x04000000",
+	"DOMAIN_SELECTION_PROOF":                "0x05000000",
+	"DOMAIN_AGGREGATE_AND_PROOF":            "0x06000000",
+	"DOMAIN_SYNC_COMMITTEE":                 "0x07000000",
+	"DOMAIN_SYNC_COMMITTEE_SELECTION_PROOF": "0x08000000",
+	"DOMAIN_CONTRIBUTION_AND_PROOF":         "0x09000000",
+	"DOMAIN_BLS_TO_EXECUTION_CHANGE":        "0x0a000000",
+	"DOMAIN_APPLICATION_MASK":               "0x00000001",
+	"DOMAIN_APPLICATION_BUILDER":            "0x00000001",
+}
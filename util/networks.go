@@ -1,4 +1,4 @@
-// Copyright © 2020 - 2023 Weald Technology Trading.
+// Copyright © 2020 - 2025 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -16,9 +16,12 @@ package util
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
+	"strings"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
 
@@ -33,7 +36,8 @@ var networks = map[string]string{
 	"4242424242424242424242424242424242424242": "Holesky",
 }
 
-// Network returns the name of the network., calculated from the deposit contract information.
+// Network returns the name of the network, calculated from the genesis validators root
+// or, if that is not recognised, the deposit contract information.
 // If not known, returns "Unknown".
 func Network(ctx context.Context, eth2Client eth2client.Service) (string, error) {
 	var address []byte
@@ -42,6 +46,17 @@ func Network(ctx context.Context, eth2Client eth2client.Service) (string, error)
 		return "", errors.New("no Ethereum 2 client supplied")
 	}
 
+	// Some networks share a deposit contract address, so use the genesis validators root where possible.
+	if provider, isProvider := eth2Client.(eth2client.GenesisProvider); isProvider {
+		genesisResponse, err := provider.Genesis(ctx, &api.GenesisOpts{})
+		if err != nil {
+			return "", errors.Wrap(err, "failed to obtain genesis information")
+		}
+		if network, exists := networkFromGenesisValidatorsRoot(genesisResponse.Data.GenesisValidatorsRoot); exists {
+			return network, nil
+		}
+	}
+
 	provider, isProvider := eth2Client.(eth2client.SpecProvider)
 	if !isProvider {
 		return "", errors.New("client does not provide deposit contract address")
@@ -58,6 +73,17 @@ func Network(ctx context.Context, eth2Client eth2client.Service) (string, error)
 	return network(address), nil
 }
 
+// networkFromGenesisValidatorsRoot returns a built-in network given its genesis validators root.
+func networkFromGenesisValidatorsRoot(root phase0.Root) (string, bool) {
+	for _, network := range builtinNetworks {
+		if strings.EqualFold(network.genesisValidatorsRoot, fmt.Sprintf("%#x", root)) {
+			return network.name, true
+		}
+	}
+
+	return "", false
+}
+
 // network returns a network given an Ethereum 1 contract address.
 func network(address []byte) string {
 	if network, exists := networks[hex.EncodeToString(address)]; exists {
@@ -147,7 +147,7 @@ func unlock(account e2wtypes.Account) (bool, error) {
 	}
 
 	// Failed to unlock it.
-	return false, errors.New("failed to unlock account")
+	return false, NewError(ErrorCodeInvalidPassphrase, "failed to unlock account")
 }
 
 // lock attempts to lock an account.
@@ -56,7 +56,7 @@ func ValidatorIndex(ctx context.Context, client consensusclient.Service, account
 		}
 		return phase0.ValidatorIndex(val), nil
 	default:
-		return 0, errors.New("no validator")
+		return 0, NewError(ErrorCodeInvalidInput, "no validator")
 	}
 }
 
@@ -79,5 +79,8 @@ func accountToIndex(ctx context.Context, account e2wtypes.Account, client consen
 	for index := range validatorsResponse.Data {
 		return index, nil
 	}
-	return 0, errors.New("validator not found")
+	return 0, ErrorWithCode(errors.New("validator not found"),
+		ErrorCodeValidatorNotFound,
+		map[string]any{"pubkey": fmt.Sprintf("%#x", pubKeys[0])},
+	)
 }
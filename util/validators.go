@@ -27,11 +27,14 @@ import (
 )
 
 // ParseValidators parses input to obtain the list of validators.
+// Each input can be an index, a range of indices such as 10-20, or anything
+// accepted by ParseAccount such as an account path or public key.
 func ParseValidators(ctx context.Context, validatorsProvider eth2client.ValidatorsProvider, validatorsStr []string, stateID string) ([]*apiv1.Validator, error) {
 	validators := make([]*apiv1.Validator, 0, len(validatorsStr))
 	indices := make([]phase0.ValidatorIndex, 0)
+	pubKeys := make([]phase0.BLSPubKey, 0)
 	for i := range validatorsStr {
-		if strings.Contains(validatorsStr[i], "-") {
+		if isValidatorRange(validatorsStr[i]) {
 			// Range.
 			bits := strings.Split(validatorsStr[i], "-")
 			if len(bits) != 2 {
@@ -50,28 +53,67 @@ func ParseValidators(ctx context.Context, validatorsProvider eth2client.Validato
 			}
 		} else {
 			index, err := strconv.ParseUint(validatorsStr[i], 10, 64)
+			if err == nil {
+				indices = append(indices, phase0.ValidatorIndex(index))
+
+				continue
+			}
+
+			// Some sort of specifier.
+			account, err := ParseAccount(ctx, validatorsStr[i], nil, false)
 			if err != nil {
 				return nil, errors.Wrapf(err, "failed to parse validator %s", validatorsStr[i])
 			}
-			indices = append(indices, phase0.ValidatorIndex(index))
+			accPubKey, err := BestPublicKey(account)
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to obtain public key for account")
+			}
+			pubKey := phase0.BLSPubKey{}
+			copy(pubKey[:], accPubKey.Marshal())
+			pubKeys = append(pubKeys, pubKey)
 		}
 	}
 
-	if len(validators) == 0 && len(indices) == 0 {
+	if len(indices) == 0 && len(pubKeys) == 0 {
 		// Nothing to obtain.
 		return validators, nil
 	}
 
-	response, err := validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: stateID, Indices: indices})
-	if err != nil {
-		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain validators %v", indices))
+	found := make(map[phase0.ValidatorIndex]struct{})
+	if len(indices) > 0 {
+		response, err := validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: stateID, Indices: indices})
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain validators %v", indices))
+		}
+		for _, validator := range response.Data {
+			found[validator.Index] = struct{}{}
+			validators = append(validators, validator)
+		}
 	}
-	for _, validator := range response.Data {
-		validators = append(validators, validator)
+	if len(pubKeys) > 0 {
+		response, err := validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: stateID, PubKeys: pubKeys})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators by public key")
+		}
+		for _, validator := range response.Data {
+			if _, exists := found[validator.Index]; exists {
+				// Already obtained by index.
+				continue
+			}
+			validators = append(validators, validator)
+		}
 	}
+
 	return validators, nil
 }
 
+// isValidatorRange returns true if the input looks like a range of validator indices.
+func isValidatorRange(input string) bool {
+	return strings.Contains(input, "-") &&
+		input[0] >= '0' && input[0] <= '9' &&
+		!strings.ContainsAny(input, "/ ")
+}
+
 // ParseValidator parses input to obtain the validator.
 func ParseValidator(ctx context.Context,
 	validatorsProvider eth2client.ValidatorsProvider,
@@ -122,5 +164,8 @@ func ParseValidator(ctx context.Context,
 		return validator, nil
 	}
 
-	return nil, errors.New("unknown validator")
+	return nil, ErrorWithCode(errors.New("unknown validator"),
+		ErrorCodeValidatorNotFound,
+		map[string]any{"validator": validatorStr},
+	)
 }
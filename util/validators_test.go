@@ -0,0 +1,114 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/hex"
+	"sort"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/mock"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestParseValidators(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	pubKey := phase0.BLSPubKey{}
+	pubKeyBytes, err := hex.DecodeString("99b1f1d84d76185466d86c34bde1101316afddae76217aa86cd066979b19858c2c9d9e56eebc1e067ac54277a61790db")
+	require.NoError(t, err)
+	copy(pubKey[:], pubKeyBytes)
+
+	mockClient, err := mock.New(ctx)
+	require.NoError(t, err)
+	mockClient.ValidatorsFunc = func(_ context.Context, opts *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator], error) {
+		data := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+		for _, index := range opts.Indices {
+			data[index] = &apiv1.Validator{Index: index}
+		}
+		for _, key := range opts.PubKeys {
+			if key == pubKey {
+				data[5] = &apiv1.Validator{Index: 5, Validator: &phase0.Validator{PublicKey: key}}
+			}
+		}
+
+		return &api.Response[map[phase0.ValidatorIndex]*apiv1.Validator]{
+			Data:     data,
+			Metadata: make(map[string]any),
+		}, nil
+	}
+
+	tests := []struct {
+		name          string
+		validatorsStr []string
+		expected      []phase0.ValidatorIndex
+		err           string
+	}{
+		{
+			name:     "Empty",
+			expected: []phase0.ValidatorIndex{},
+		},
+		{
+			name:          "Indices",
+			validatorsStr: []string{"1", "3-4"},
+			expected:      []phase0.ValidatorIndex{1, 3, 4},
+		},
+		{
+			name:          "RangeInvalid",
+			validatorsStr: []string{"3-x"},
+			err:           "invalid range end: strconv.ParseUint: parsing \"x\": invalid syntax",
+		},
+		{
+			name:          "PublicKey",
+			validatorsStr: []string{"0x99b1f1d84d76185466d86c34bde1101316afddae76217aa86cd066979b19858c2c9d9e56eebc1e067ac54277a61790db"},
+			expected:      []phase0.ValidatorIndex{5},
+		},
+		{
+			name:          "Mixed",
+			validatorsStr: []string{"1", "0x99b1f1d84d76185466d86c34bde1101316afddae76217aa86cd066979b19858c2c9d9e56eebc1e067ac54277a61790db", "5"},
+			expected:      []phase0.ValidatorIndex{1, 5},
+		},
+		{
+			name:          "Unknown",
+			validatorsStr: []string{"bad"},
+			err:           "failed to parse validator bad: unknown account specifier bad",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validators, err := util.ParseValidators(ctx, mockClient, test.validatorsStr, "head")
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				indices := make([]phase0.ValidatorIndex, 0, len(validators))
+				for _, validator := range validators {
+					indices = append(indices, validator.Index)
+				}
+				sort.Slice(indices, func(i int, j int) bool {
+					return indices[i] < indices[j]
+				})
+				require.Equal(t, test.expected, indices)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestAddressBytesToEIP55(t *testing.T) {
+	tests := []struct {
+		name    string
+		address []byte
+		res     string
+	}{
+		{
+			name:    "Zero",
+			address: make([]byte, 20),
+			res:     "0x0000000000000000000000000000000000000000",
+		},
+		{
+			name:    "Mixed",
+			address: []byte{0x5a, 0xae, 0xb6, 0x05, 0x3f, 0x3e, 0x94, 0xc9, 0xb9, 0xa0, 0x9f, 0x33, 0x66, 0x94, 0x35, 0xe7, 0xef, 0x1b, 0xea, 0xed},
+			res:     "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, util.AddressBytesToEIP55(test.address))
+		})
+	}
+}